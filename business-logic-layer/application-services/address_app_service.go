@@ -0,0 +1,328 @@
+package applicationservices
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// Layout of countries without an address format, as in libaddressinput
+const (
+	defaultAddressTemplate       = "%N%n%O%n%A%n%C"
+	defaultAddressRequiredFields = "AC"
+)
+
+// addressFields names the template field letters in results
+var addressFields = map[byte]string{
+	'N': "recipient",
+	'O': "organization",
+	'A': "address_lines",
+	'D': "dependent_locality",
+	'C': "locality",
+	'S': "subdivision",
+	'Z': "postal_code",
+	'X': "sorting_code",
+}
+
+// addressLabels are the English display labels of the label keys of
+// address formats
+var addressLabels = map[string]string{
+	"area":       "Area",
+	"city":       "City",
+	"county":     "County",
+	"department": "Department",
+	"district":   "District",
+	"do_si":      "Do/Si",
+	"eircode":    "Eircode",
+	"emirate":    "Emirate",
+	"island":     "Island",
+	"oblast":     "Oblast",
+	"parish":     "Parish",
+	"pin":        "PIN code",
+	"post_town":  "Post town",
+	"postal":     "Postal code",
+	"prefecture": "Prefecture",
+	"province":   "Province",
+	"region":     "Region",
+	"state":      "State",
+	"suburb":     "Suburb",
+	"zip":        "ZIP code",
+}
+
+// Address is a structured postal address. Subdivision may be an ISO 3166-2
+// code, with or without the country prefix, or free text.
+type Address struct {
+	Recipient         string   `json:"recipient,omitempty"`
+	Organization      string   `json:"organization,omitempty"`
+	AddressLines      []string `json:"address_lines,omitempty"`
+	DependentLocality string   `json:"dependent_locality,omitempty"`
+	Locality          string   `json:"locality,omitempty"`
+	Subdivision       string   `json:"subdivision,omitempty"`
+	PostalCode        string   `json:"postal_code,omitempty"`
+	SortingCode       string   `json:"sorting_code,omitempty"`
+	// OriginCountry adds the destination country line when mail is sent
+	// from another country
+	OriginCountry string `json:"origin_country,omitempty"`
+	// Latin selects the romanized layout of countries that have one
+	Latin bool `json:"latin,omitempty"`
+}
+
+// AddressRendering is an address laid out for its country. Errors maps the
+// fields of missing or invalid parts to a reason.
+type AddressRendering struct {
+	CountryCode string            `json:"country_code"`
+	Valid       bool              `json:"valid"`
+	Lines       []string          `json:"lines"`
+	Formatted   string            `json:"formatted"`
+	Errors      map[string]string `json:"errors,omitempty"`
+	Labels      map[string]string `json:"labels"`
+}
+
+// AddressAppService lays out and checks addresses with the address formats
+// of countries
+type AddressAppService struct {
+	formatRepo      *repositories.AddressFormatRepository
+	subdivisionRepo *repositories.SubdivisionRepository
+	postalCodes     *PostalCodeAppService
+	tracer          tracing.Tracer
+}
+
+func NewAddressAppService(formatRepo *repositories.AddressFormatRepository, subdivisionRepo *repositories.SubdivisionRepository,
+	postalCodes *PostalCodeAppService, tracer tracing.Tracer) *AddressAppService {
+	return &AddressAppService{formatRepo: formatRepo, subdivisionRepo: subdivisionRepo, postalCodes: postalCodes, tracer: tracer}
+}
+
+// Render lays out an address of country line by line and checks its
+// required parts and postal code. An incomplete address is not an error; it
+// is still rendered and its result lists what is wrong.
+func (s *AddressAppService) Render(ctx context.Context, tenantID string, country *models.Country, address Address) (*AddressRendering, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "AddressAppService.Render", attribute.String("country.code", country.CountryCode))
+	defer span.End()
+
+	format, err := s.formatRepo.ForCountry(ctx, tenantID, country.CountryCode)
+	if err != nil {
+		return nil, err
+	}
+	if format == nil || !format.IsActive {
+		required := defaultAddressRequiredFields
+		format = &repositories.AddressFormatView{AddressFormat: models.AddressFormat{
+			Template:         defaultAddressTemplate,
+			RequiredFields:   &required,
+			SubdivisionStyle: models.SubdivisionStyleName,
+		}}
+	}
+
+	errs := map[string]string{}
+	values := map[byte]string{
+		'N': strings.TrimSpace(address.Recipient),
+		'O': strings.TrimSpace(address.Organization),
+		'D': strings.TrimSpace(address.DependentLocality),
+		'C': strings.TrimSpace(address.Locality),
+		'S': strings.TrimSpace(address.Subdivision),
+		'Z': strings.TrimSpace(address.PostalCode),
+		'X': strings.TrimSpace(address.SortingCode),
+	}
+	var lines []string
+	for _, line := range address.AddressLines {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	values['A'] = strings.Join(lines, "\n")
+
+	subdivisionCode := ""
+	if values['S'] != "" {
+		code := strings.ToUpper(values['S'])
+		if !strings.Contains(code, "-") {
+			code = country.CountryCode + "-" + code
+		}
+		subdivision, err := s.subdivisionRepo.GetByCountryAndCode(ctx, tenantID, country.CountryID, code)
+		if err != nil {
+			return nil, err
+		}
+		if subdivision != nil {
+			subdivisionCode = subdivision.SubdivisionCode
+			values['S'] = subdivision.SubdivisionName
+			if format.SubdivisionStyle == models.SubdivisionStyleCode {
+				values['S'] = strings.TrimPrefix(subdivision.SubdivisionCode, country.CountryCode+"-")
+			}
+		}
+	}
+
+	if values['Z'] != "" {
+		check, err := s.postalCodes.Validate(ctx, tenantID, country, values['Z'], subdivisionCode)
+		if layerErr, ok := err.(*errors.LayerError); ok && layerErr.Code == "NOT_FOUND" {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if check != nil {
+			if check.Valid && check.Normalized != "" {
+				values['Z'] = check.Normalized
+			} else if !check.Valid {
+				errs[addressFields['Z']] = check.Reason
+			}
+		}
+	}
+
+	if format.RequiredFields != nil {
+		for i := 0; i < len(*format.RequiredFields); i++ {
+			letter := (*format.RequiredFields)[i]
+			if values[letter] == "" {
+				errs[addressFields[letter]] = "required in " + country.CountryCode
+			}
+		}
+	}
+	if format.UppercaseFields != nil {
+		for i := 0; i < len(*format.UppercaseFields); i++ {
+			letter := (*format.UppercaseFields)[i]
+			values[letter] = strings.ToUpper(values[letter])
+		}
+	}
+
+	template := format.Template
+	if address.Latin && format.LatinTemplate != nil {
+		template = *format.LatinTemplate
+	}
+	rendered := renderAddress(template, values)
+	if address.OriginCountry != "" && !strings.EqualFold(address.OriginCountry, country.CountryCode) {
+		rendered = append(rendered, strings.ToUpper(country.CountryName))
+	}
+
+	result := &AddressRendering{
+		CountryCode: country.CountryCode,
+		Valid:       len(errs) == 0,
+		Lines:       rendered,
+		Formatted:   strings.Join(rendered, "\n"),
+		Labels:      addressFormatLabels(format.AddressFormat),
+	}
+	if len(errs) > 0 {
+		result.Errors = errs
+	}
+	return result, nil
+}
+
+// addressToken is a field letter of a template, or literal text when field
+// is zero
+type addressToken struct {
+	field   byte
+	literal string
+}
+
+// renderAddress fills a template with field values. Literal text next to
+// empty fields is dropped, as are lines left empty. Address lines on a line
+// of their own become one line each; elsewhere they are joined with commas.
+func renderAddress(template string, values map[byte]string) []string {
+	var out []string
+	for _, line := range strings.Split(template, "%n") {
+		tokens := tokenizeAddressLine(line)
+		if len(tokens) == 1 && tokens[0].field == 'A' {
+			if values['A'] != "" {
+				out = append(out, strings.Split(values['A'], "\n")...)
+			}
+			continue
+		}
+
+		value := func(t addressToken) string {
+			if t.field == 'A' {
+				return strings.ReplaceAll(values['A'], "\n", ", ")
+			}
+			return values[t.field]
+		}
+		first, last := -1, -1
+		for i, t := range tokens {
+			if t.field != 0 {
+				if first < 0 {
+					first = i
+				}
+				last = i
+			}
+		}
+
+		var b strings.Builder
+		seen, separated := false, false
+		for i, t := range tokens {
+			if t.field != 0 {
+				if v := value(t); v != "" {
+					b.WriteString(v)
+					seen, separated = true, false
+				}
+				continue
+			}
+			switch {
+			case first < 0 || i < first:
+				// A prefix such as the postal mark of JP stays with its field
+				if first >= 0 && value(tokens[first]) != "" {
+					b.WriteString(t.literal)
+				}
+			case i > last:
+				if value(tokens[last]) != "" {
+					b.WriteString(t.literal)
+				}
+			default:
+				// Between fields keep one separator, and only between values
+				if seen && !separated && anyAddressValue(tokens[i+1:], value) {
+					b.WriteString(t.literal)
+					separated = true
+				}
+			}
+		}
+		if text := strings.TrimSpace(b.String()); text != "" {
+			out = append(out, text)
+		}
+	}
+	return out
+}
+
+func tokenizeAddressLine(line string) []addressToken {
+	var tokens []addressToken
+	var literal strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '%' && i+1 < len(line) {
+			if literal.Len() > 0 {
+				tokens = append(tokens, addressToken{literal: literal.String()})
+				literal.Reset()
+			}
+			i++
+			tokens = append(tokens, addressToken{field: line[i]})
+			continue
+		}
+		literal.WriteByte(line[i])
+	}
+	if literal.Len() > 0 {
+		tokens = append(tokens, addressToken{literal: literal.String()})
+	}
+	return tokens
+}
+
+func anyAddressValue(tokens []addressToken, value func(addressToken) string) bool {
+	for _, t := range tokens {
+		if t.field != 0 && value(t) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// addressFormatLabels returns the display labels of the subdivision,
+// locality and postal code fields of a format
+func addressFormatLabels(format models.AddressFormat) map[string]string {
+	labels := map[string]string{
+		addressFields['S']: addressLabels["province"],
+		addressFields['C']: addressLabels["city"],
+		addressFields['Z']: addressLabels["postal"],
+	}
+	for letter, key := range map[byte]*string{'S': format.SubdivisionLabel, 'C': format.LocalityLabel, 'Z': format.PostalCodeLabel} {
+		if key != nil && addressLabels[*key] != "" {
+			labels[addressFields[letter]] = addressLabels[*key]
+		}
+	}
+	return labels
+}
@@ -0,0 +1,126 @@
+package applicationservices
+
+import (
+	"reflect"
+	"testing"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestRenderAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		values   map[byte]string
+		want     []string
+	}{
+		{
+			name:     "empty lines are dropped",
+			template: "%N%n%O%n%A%n%C, %S %Z",
+			values:   map[byte]string{'N': "Jane Doe", 'A': "1600 Pennsylvania Ave NW", 'C': "WASHINGTON", 'S': "DC", 'Z': "20500"},
+			want:     []string{"Jane Doe", "1600 Pennsylvania Ave NW", "WASHINGTON, DC 20500"},
+		},
+		{
+			name:     "one separator is kept between remaining values",
+			template: "%C, %S %Z",
+			values:   map[byte]string{'C': "WASHINGTON", 'Z': "20500"},
+			want:     []string{"WASHINGTON, 20500"},
+		},
+		{
+			name:     "address lines on their own line",
+			template: "%O%n%N%n%A%n%Z %C %X",
+			values:   map[byte]string{'N': "Jean Dupont", 'A': "55 Rue du Faubourg Saint-Honoré\nBâtiment B", 'Z': "75008", 'C': "PARIS"},
+			want:     []string{"Jean Dupont", "55 Rue du Faubourg Saint-Honoré", "Bâtiment B", "75008 PARIS"},
+		},
+		{
+			name:     "address lines within a line are joined",
+			template: "%N%n%O%n%A, %S%n%Z",
+			values:   map[byte]string{'N': "Taro Yamada", 'A': "1-1 Chiyoda\nChiyoda-ku", 'S': "TOKYO", 'Z': "100-0001"},
+			want:     []string{"Taro Yamada", "1-1 Chiyoda, Chiyoda-ku, TOKYO", "100-0001"},
+		},
+		{
+			name:     "prefix stays with its field",
+			template: "〒%Z%n%S%n%A",
+			values:   map[byte]string{'Z': "100-0001", 'S': "東京都", 'A': "千代田区千代田1-1"},
+			want:     []string{"〒100-0001", "東京都", "千代田区千代田1-1"},
+		},
+		{
+			name:     "prefix of an empty field is dropped",
+			template: "〒%Z%n%S%n%A",
+			values:   map[byte]string{'S': "東京都", 'A': "千代田区千代田1-1"},
+			want:     []string{"東京都", "千代田区千代田1-1"},
+		},
+		{
+			name:     "literal country prefix",
+			template: "%O%n%N%n%A%nCH-%Z %C",
+			values:   map[byte]string{'A': "Bundesplatz 3", 'Z': "3003", 'C': "Bern"},
+			want:     []string{"Bundesplatz 3", "CH-3003 Bern"},
+		},
+		{
+			name:     "separator before an empty last field is dropped",
+			template: "%A%n%C-%S",
+			values:   map[byte]string{'A': "Praça dos Três Poderes", 'C': "BRASÍLIA"},
+			want:     []string{"Praça dos Três Poderes", "BRASÍLIA"},
+		},
+		{
+			name:     "fields without separators",
+			template: "%Z%n%S%C%D%n%A",
+			values:   map[byte]string{'Z': "100010", 'S': "北京市", 'C': "东城区", 'A': "东长安街1号"},
+			want:     []string{"100010", "北京市东城区", "东长安街1号"},
+		},
+		{
+			name:     "literal only lines are dropped",
+			template: "%A%nPOSTE RESTANTE",
+			values:   map[byte]string{'A': "1 Main Street"},
+			want:     []string{"1 Main Street"},
+		},
+		{
+			name:     "nothing to render",
+			template: "%N%n%A%n%C",
+			values:   map[byte]string{},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderAddress(tt.template, tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("renderAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressFormatLabels(t *testing.T) {
+	state, zip, postTown, unknown := "state", "zip", "post_town", "canton"
+
+	tests := []struct {
+		name   string
+		format models.AddressFormat
+		want   map[string]string
+	}{
+		{
+			name:   "defaults",
+			format: models.AddressFormat{},
+			want:   map[string]string{"subdivision": "Province", "locality": "City", "postal_code": "Postal code"},
+		},
+		{
+			name:   "labels of the format",
+			format: models.AddressFormat{SubdivisionLabel: &state, LocalityLabel: &postTown, PostalCodeLabel: &zip},
+			want:   map[string]string{"subdivision": "State", "locality": "Post town", "postal_code": "ZIP code"},
+		},
+		{
+			name:   "unknown label keeps the default",
+			format: models.AddressFormat{SubdivisionLabel: &unknown},
+			want:   map[string]string{"subdivision": "Province", "locality": "City", "postal_code": "Postal code"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := addressFormatLabels(tt.format); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("addressFormatLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
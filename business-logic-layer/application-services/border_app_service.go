@@ -0,0 +1,198 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// MaxBorderDepth bounds the number of borders a reach query may cross
+const MaxBorderDepth = 10
+
+// BorderStep is a country reached over borders. Borders is the number of
+// borders crossed to reach it.
+type BorderStep struct {
+	CountryCode string `json:"country_code"`
+	CountryName string `json:"country_name"`
+	Borders     int    `json:"borders"`
+}
+
+// BorderRoute is the shortest land route between two countries, from the
+// first country to the last. Path is empty when no land route exists.
+type BorderRoute struct {
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Reachable bool         `json:"reachable"`
+	Borders   int          `json:"borders"`
+	Path      []BorderStep `json:"path"`
+}
+
+// BorderAppService answers neighbor and path queries over the border graph
+// of a tenant. Each query loads the graph afresh, which is small enough:
+// the world has a few hundred land borders.
+type BorderAppService struct {
+	repo      *repositories.CountryBorderRepository
+	validator *validation.CountryBorderValidator
+	tracer    tracing.Tracer
+}
+
+func NewBorderAppService(repo *repositories.CountryBorderRepository, tracer tracing.Tracer) *BorderAppService {
+	return &BorderAppService{repo: repo, validator: validation.NewCountryBorderValidator(), tracer: tracer}
+}
+
+// Neighbors returns the borders of a country by neighbor. borderType may be
+// empty for all types.
+func (s *BorderAppService) Neighbors(ctx context.Context, tenantID string, country *models.Country, borderType string) ([]repositories.CountryBorderView, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "BorderAppService.Neighbors", attribute.String("country.code", country.CountryCode))
+	defer span.End()
+
+	if err := s.checkType(borderType); err != nil {
+		return nil, err
+	}
+	return s.repo.ForCountry(ctx, tenantID, country.CountryID, borderType)
+}
+
+// Route returns the land route between two countries crossing the fewest
+// borders. Of equally short routes it returns the first by country code.
+func (s *BorderAppService) Route(ctx context.Context, tenantID string, from, to *models.Country) (*BorderRoute, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "BorderAppService.Route",
+		attribute.String("country.from", from.CountryCode), attribute.String("country.to", to.CountryCode))
+	defer span.End()
+
+	graph, err := s.graph(ctx, tenantID, models.BorderTypeLand)
+	if err != nil {
+		return nil, err
+	}
+	route := &BorderRoute{From: from.CountryCode, To: to.CountryCode, Path: []BorderStep{}}
+	codes := graph.route(from.CountryCode, to.CountryCode)
+	if codes == nil {
+		return route, nil
+	}
+
+	graph.names[from.CountryCode], graph.names[to.CountryCode] = from.CountryName, to.CountryName
+	for i, code := range codes {
+		route.Path = append(route.Path, BorderStep{CountryCode: code, CountryName: graph.names[code], Borders: i})
+	}
+	route.Reachable = true
+	route.Borders = len(codes) - 1
+	return route, nil
+}
+
+// Within returns the countries at most borders crossings away from a
+// country, nearest first and then by code. borderType may be empty to cross
+// borders of any type.
+func (s *BorderAppService) Within(ctx context.Context, tenantID string, country *models.Country, borders int, borderType string) ([]BorderStep, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "BorderAppService.Within",
+		attribute.String("country.code", country.CountryCode), attribute.Int("borders", borders))
+	defer span.End()
+
+	if borders < 1 || borders > MaxBorderDepth {
+		return nil, errors.NewValidationError("borders", fmt.Sprintf("must be between 1 and %d", MaxBorderDepth))
+	}
+	if err := s.checkType(borderType); err != nil {
+		return nil, err
+	}
+	graph, err := s.graph(ctx, tenantID, borderType)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []BorderStep{}
+	graph.search(country.CountryCode, func(code string, depth int) bool {
+		if depth > borders {
+			return true
+		}
+		if depth > 0 {
+			steps = append(steps, BorderStep{CountryCode: code, CountryName: graph.names[code], Borders: depth})
+		}
+		return false
+	})
+	return steps, nil
+}
+
+func (s *BorderAppService) checkType(borderType string) error {
+	if borderType != "" && !s.validator.ValidBorderType(borderType) {
+		return errors.NewValidationError("type", "must be LAND or MARITIME")
+	}
+	return nil
+}
+
+// borderGraph is the adjacency of countries by code
+type borderGraph struct {
+	neighbors map[string][]string
+	names     map[string]string
+}
+
+func (s *BorderAppService) graph(ctx context.Context, tenantID, borderType string) (*borderGraph, error) {
+	borders, err := s.repo.Graph(ctx, tenantID, borderType)
+	if err != nil {
+		return nil, err
+	}
+	return newBorderGraph(borders), nil
+}
+
+// newBorderGraph links both countries of each border
+func newBorderGraph(borders []repositories.CountryBorderView) *borderGraph {
+	g := &borderGraph{neighbors: map[string][]string{}, names: map[string]string{}}
+	for _, b := range borders {
+		g.neighbors[b.CountryCode] = append(g.neighbors[b.CountryCode], b.NeighborCode)
+		g.neighbors[b.NeighborCode] = append(g.neighbors[b.NeighborCode], b.CountryCode)
+		g.names[b.CountryCode] = b.CountryName
+		g.names[b.NeighborCode] = b.NeighborName
+	}
+	for _, codes := range g.neighbors {
+		sort.Strings(codes)
+	}
+	return g
+}
+
+// search walks the graph breadth first from start, visiting countries in
+// order of borders crossed and then by code, until visit returns true. It
+// returns the country each visited country was reached from; start maps to
+// the empty string.
+func (g *borderGraph) search(start string, visit func(code string, depth int) bool) map[string]string {
+	previous := map[string]string{start: ""}
+	queue := []string{start}
+	for depth := 0; len(queue) > 0; depth++ {
+		var next []string
+		for _, code := range queue {
+			if visit(code, depth) {
+				return previous
+			}
+			for _, neighbor := range g.neighbors[code] {
+				if _, seen := previous[neighbor]; !seen {
+					previous[neighbor] = code
+					next = append(next, neighbor)
+				}
+			}
+		}
+		sort.Strings(next)
+		queue = next
+	}
+	return previous
+}
+
+// route returns the codes of the countries on the shortest route from one
+// country to another, both included, or nil when there is none
+func (g *borderGraph) route(from, to string) []string {
+	previous := g.search(from, func(code string, _ int) bool { return code == to })
+	if _, ok := previous[to]; !ok {
+		return nil
+	}
+	var codes []string
+	for code := to; code != ""; code = previous[code] {
+		codes = append(codes, code)
+	}
+	for i, j := 0, len(codes)-1; i < j; i, j = i+1, j-1 {
+		codes[i], codes[j] = codes[j], codes[i]
+	}
+	return codes
+}
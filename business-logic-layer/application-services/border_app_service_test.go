@@ -0,0 +1,116 @@
+package applicationservices
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+)
+
+// landBorders are the seeded land borders, plus Luxembourg for two routes of
+// equal length and an island pair that reaches nothing else
+func landBorders() *borderGraph {
+	pairs := [][2]string{
+		{"CA", "US"}, {"CH", "DE"}, {"CH", "FR"}, {"CN", "IN"},
+		{"DE", "FR"}, {"ES", "FR"}, {"ES", "GB"}, {"FR", "BR"},
+		{"LU", "DE"}, {"LU", "FR"}, {"HT", "DO"},
+	}
+	var borders []repositories.CountryBorderView
+	for _, p := range pairs {
+		borders = append(borders, repositories.CountryBorderView{
+			CountryCode: p[0], CountryName: "Country " + p[0],
+			NeighborCode: p[1], NeighborName: "Country " + p[1],
+		})
+	}
+	return newBorderGraph(borders)
+}
+
+func TestBorderGraphRoute(t *testing.T) {
+	graph := landBorders()
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+	}{
+		{"neighbors", "DE", "FR", []string{"DE", "FR"}},
+		{"borders are symmetric", "FR", "DE", []string{"FR", "DE"}},
+		{"two borders", "GB", "FR", []string{"GB", "ES", "FR"}},
+		{"through a neighbor", "DE", "ES", []string{"DE", "FR", "ES"}},
+		{"first of equal routes by code", "LU", "CH", []string{"LU", "DE", "CH"}},
+		{"across several countries", "GB", "CH", []string{"GB", "ES", "FR", "CH"}},
+		{"same country", "FR", "FR", []string{"FR"}},
+		{"separate component", "DE", "IN", nil},
+		{"country without borders", "JP", "FR", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graph.route(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("route() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBorderGraphSearch(t *testing.T) {
+	graph := landBorders()
+
+	tests := []struct {
+		name   string
+		start  string
+		depth  int
+		want   []string
+		depths []int
+	}{
+		{"start only", "DE", 0, []string{"DE"}, []int{0}},
+		{"neighbors by code", "FR", 1, []string{"FR", "BR", "CH", "DE", "ES", "LU"}, []int{0, 1, 1, 1, 1, 1}},
+		{"nearest first", "GB", 2, []string{"GB", "ES", "FR"}, []int{0, 1, 2}},
+		{"whole component", "GB", 10, []string{"GB", "ES", "FR", "BR", "CH", "DE", "LU"}, []int{0, 1, 2, 3, 3, 3, 3}},
+		{"isolated country", "JP", 3, []string{"JP"}, []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var depths []int
+			graph.search(tt.start, func(code string, depth int) bool {
+				if depth > tt.depth {
+					return true
+				}
+				got, depths = append(got, code), append(depths, depth)
+				return false
+			})
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(depths, tt.depths) {
+				t.Errorf("search() visited %v at %v, want %v at %v", got, depths, tt.want, tt.depths)
+			}
+		})
+	}
+}
+
+func TestBorderRejects(t *testing.T) {
+	service := NewBorderAppService(nil, noopTracer{})
+	ctx := context.Background()
+	country := &models.Country{CountryCode: "FR"}
+
+	tests := []struct {
+		name       string
+		borders    int
+		borderType string
+	}{
+		{"no borders", 0, ""},
+		{"too many borders", MaxBorderDepth + 1, ""},
+		{"unknown type", 1, "AIR"},
+		{"lowercase type", 1, "land"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Within(ctx, "default-tenant", country, tt.borders, tt.borderType); err == nil {
+				t.Error("Within() succeeded, want error")
+			}
+		})
+	}
+}
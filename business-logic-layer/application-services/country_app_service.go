@@ -67,6 +67,11 @@ func (s *CountryAppService) CreateCountry(ctx context.Context, tenantID string,
 	if country.CountryCode == "" || country.CountryName == "" {
 		return errors.NewValidationError("country_code", "country code and name are required")
 	}
+	if country.RetentionPolicy != nil {
+		if _, err := ParseRetentionPolicy(*country.RetentionPolicy); err != nil {
+			return err
+		}
+	}
 
 	// Reference data - no tenant context needed
 	err := s.countryRepo.Create(ctx, tenantID, country)
@@ -114,4 +119,73 @@ func (s *CountryAppService) GetCountryByCode(ctx context.Context, tenantID, coun
 		logging.Field{Key: "country_id", Value: country.CountryID})
 
 	return country, nil
-}
\ No newline at end of file
+}
+
+// UpdateCountry replaces the mutable fields of a country. country.Version is
+// the version the caller last saw; a stale version fails with GEO-1009.
+func (s *CountryAppService) UpdateCountry(ctx context.Context, tenantID string, country *models.Country) error {
+	ctx, span := s.tracer.StartSpan(ctx, "CountryAppService.UpdateCountry")
+	defer span.End()
+
+	s.logger.Info(ctx, "Updating country",
+		logging.Field{Key: "country_code", Value: country.CountryCode},
+		logging.Field{Key: "version", Value: country.Version},
+		logging.Field{Key: "operation", Value: "update_country"})
+
+	if country.CountryCode == "" || country.CountryName == "" {
+		return errors.NewValidationError("country_code", "country code and name are required")
+	}
+	if country.RetentionPolicy != nil {
+		if _, err := ParseRetentionPolicy(*country.RetentionPolicy); err != nil {
+			return err
+		}
+	}
+
+	if err := s.countryRepo.Update(ctx, tenantID, country); err != nil {
+		s.logger.Error(ctx, "Failed to update country", err,
+			logging.Field{Key: "country_code", Value: country.CountryCode})
+		return repositoryError(err)
+	}
+
+	s.logger.Info(ctx, "Successfully updated country",
+		logging.Field{Key: "country_code", Value: country.CountryCode},
+		logging.Field{Key: "version", Value: country.Version})
+
+	return nil
+}
+
+// DeleteCountry soft deletes a country. A positive expectedVersion must match
+// the stored version.
+func (s *CountryAppService) DeleteCountry(ctx context.Context, tenantID, countryCode string, expectedVersion int) error {
+	ctx, span := s.tracer.StartSpan(ctx, "CountryAppService.DeleteCountry")
+	defer span.End()
+
+	s.logger.Info(ctx, "Deleting country",
+		logging.Field{Key: "country_code", Value: countryCode},
+		logging.Field{Key: "version", Value: expectedVersion},
+		logging.Field{Key: "operation", Value: "delete_country"})
+
+	if countryCode == "" {
+		return errors.NewValidationError("country_code", "country code is required")
+	}
+
+	if err := s.countryRepo.Delete(ctx, tenantID, countryCode, expectedVersion); err != nil {
+		s.logger.Error(ctx, "Failed to delete country", err,
+			logging.Field{Key: "country_code", Value: countryCode})
+		return repositoryError(err)
+	}
+
+	s.logger.Info(ctx, "Successfully deleted country",
+		logging.Field{Key: "country_code", Value: countryCode})
+
+	return nil
+}
+
+// repositoryError keeps typed layer errors (validation, not found, version
+// conflict) and maps anything else to a database error
+func repositoryError(err error) error {
+	if layerErr, ok := err.(*errors.LayerError); ok {
+		return layerErr
+	}
+	return errors.MapDatabaseError(err)
+}
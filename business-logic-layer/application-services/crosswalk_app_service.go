@@ -0,0 +1,131 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// MaxCrosswalkBatch bounds the codes of one batch translation
+const MaxCrosswalkBatch = 1000
+
+// CrosswalkResult is a country code translated from one code system to
+// another. Reason explains a code that could not be translated.
+type CrosswalkResult struct {
+	From        string  `json:"from"`
+	Code        string  `json:"code"`
+	To          string  `json:"to"`
+	Found       bool    `json:"found"`
+	Result      *string `json:"result"`
+	CountryCode string  `json:"country_code,omitempty"`
+	CountryName string  `json:"country_name,omitempty"`
+	Reason      string  `json:"reason,omitempty"`
+}
+
+// crosswalkSource translates codes, the method of
+// CountryExternalCodeRepository that the crosswalk needs
+type crosswalkSource interface {
+	Translate(ctx context.Context, tenantID, from, to string, codes []string, asOf time.Time) ([]repositories.CrosswalkRow, error)
+}
+
+// CrosswalkAppService translates country codes between the ISO 3166-1
+// systems and external systems such as FIPS 10-4, IOC, ITU, GENC and World
+// Bank codes
+type CrosswalkAppService struct {
+	repo      crosswalkSource
+	validator *validation.CountryCodeValidator
+	tracer    tracing.Tracer
+}
+
+func NewCrosswalkAppService(repo *repositories.CountryExternalCodeRepository, tracer tracing.Tracer) *CrosswalkAppService {
+	return &CrosswalkAppService{repo: repo, validator: validation.NewCountryCodeValidator(), tracer: tracer}
+}
+
+// Translate translates one code as the systems stood on asOf
+func (s *CrosswalkAppService) Translate(ctx context.Context, tenantID, from, code, to string, asOf time.Time) (*CrosswalkResult, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "CrosswalkAppService.Translate",
+		attribute.String("crosswalk.from", from), attribute.String("crosswalk.to", to))
+	defer span.End()
+
+	results, err := s.translate(ctx, tenantID, from, to, []string{code}, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// TranslateAll translates a batch of codes of one system, in order
+func (s *CrosswalkAppService) TranslateAll(ctx context.Context, tenantID, from string, codes []string, to string, asOf time.Time) ([]*CrosswalkResult, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "CrosswalkAppService.TranslateAll",
+		attribute.String("crosswalk.from", from), attribute.String("crosswalk.to", to), attribute.Int("crosswalk.count", len(codes)))
+	defer span.End()
+
+	if len(codes) > MaxCrosswalkBatch {
+		return nil, errors.NewValidationError("codes", fmt.Sprintf("at most %d codes per request", MaxCrosswalkBatch))
+	}
+	return s.translate(ctx, tenantID, from, to, codes, asOf)
+}
+
+func (s *CrosswalkAppService) translate(ctx context.Context, tenantID, from, to string, codes []string, asOf time.Time) ([]*CrosswalkResult, error) {
+	from, to = strings.ToLower(strings.TrimSpace(from)), strings.ToLower(strings.TrimSpace(to))
+	if !s.validator.ValidCodeSystem(from) {
+		return nil, errors.NewValidationError("from", "must be a code system such as iso2, iso3, numeric, fips or ioc")
+	}
+	if !s.validator.ValidCodeSystem(to) {
+		return nil, errors.NewValidationError("to", "must be a code system such as iso2, iso3, numeric, fips or ioc")
+	}
+
+	if len(codes) == 0 {
+		return []*CrosswalkResult{}, nil
+	}
+
+	normalized := make([]string, len(codes))
+	for i, code := range codes {
+		normalized[i] = normalizeSystemCode(from, code)
+	}
+	rows, err := s.repo.Translate(ctx, tenantID, from, to, normalized, asOf)
+	if err != nil {
+		return nil, err
+	}
+	bySource := make(map[string]repositories.CrosswalkRow, len(rows))
+	for _, row := range rows {
+		bySource[row.Source] = row
+	}
+
+	results := make([]*CrosswalkResult, len(codes))
+	for i, code := range codes {
+		result := &CrosswalkResult{From: from, Code: code, To: to}
+		row, ok := bySource[normalized[i]]
+		switch {
+		case !ok:
+			result.Reason = "no country has this " + from + " code"
+		case row.Target == nil:
+			result.CountryCode, result.CountryName = row.CountryCode, row.CountryName
+			result.Reason = row.CountryCode + " has no " + to + " code"
+		default:
+			result.Found, result.Result = true, row.Target
+			result.CountryCode, result.CountryName = row.CountryCode, row.CountryName
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// normalizeSystemCode uppercases a code and pads numeric codes to three
+// digits, so that 76 finds 076
+func normalizeSystemCode(system, code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if system == models.CodeSystemNumeric && code != "" && len(code) < 3 && strings.Trim(code, "0123456789") == "" {
+		code = strings.Repeat("0", 3-len(code)) + code
+	}
+	return code
+}
@@ -0,0 +1,160 @@
+package applicationservices
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+)
+
+// crosswalkRows serves the translations of source codes from memory,
+// whatever the systems asked for
+type crosswalkRows map[string]repositories.CrosswalkRow
+
+func (r crosswalkRows) Translate(_ context.Context, _, _, _ string, codes []string, _ time.Time) ([]repositories.CrosswalkRow, error) {
+	var rows []repositories.CrosswalkRow
+	for _, code := range codes {
+		if row, ok := r[code]; ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func crosswalkRow(source, target, countryCode string) repositories.CrosswalkRow {
+	row := repositories.CrosswalkRow{Source: source, CountryCode: countryCode, CountryName: "Country " + countryCode}
+	if target != "" {
+		row.Target = &target
+	}
+	return row
+}
+
+func TestCrosswalkTranslate(t *testing.T) {
+	service := &CrosswalkAppService{
+		repo: crosswalkRows{
+			"GM":  crosswalkRow("GM", "DEU", "DE"),
+			"076": crosswalkRow("076", "BRA", "BR"),
+			"AY":  crosswalkRow("AY", "", "AQ"),
+		},
+		validator: validation.NewCountryCodeValidator(),
+		tracer:    noopTracer{},
+	}
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    string
+		code    string
+		to      string
+		result  string
+		want    CrosswalkResult
+		wantErr bool
+	}{
+		{
+			name: "found",
+			from: "fips", code: "GM", to: "iso3", result: "DEU",
+			want: CrosswalkResult{From: "fips", Code: "GM", To: "iso3", Found: true, CountryCode: "DE", CountryName: "Country DE"},
+		},
+		{
+			name: "code and systems are normalized",
+			from: " FIPS", code: " gm ", to: "ISO3", result: "DEU",
+			want: CrosswalkResult{From: "fips", Code: " gm ", To: "iso3", Found: true, CountryCode: "DE", CountryName: "Country DE"},
+		},
+		{
+			name: "numeric code is padded",
+			from: "numeric", code: "76", to: "iso3", result: "BRA",
+			want: CrosswalkResult{From: "numeric", Code: "76", To: "iso3", Found: true, CountryCode: "BR", CountryName: "Country BR"},
+		},
+		{
+			name: "no country has the code",
+			from: "fips", code: "ZZ", to: "iso3",
+			want: CrosswalkResult{From: "fips", Code: "ZZ", To: "iso3", Reason: "no country has this fips code"},
+		},
+		{
+			name: "country without a target code",
+			from: "fips", code: "AY", to: "ioc",
+			want: CrosswalkResult{From: "fips", Code: "AY", To: "ioc", CountryCode: "AQ", CountryName: "Country AQ", Reason: "AQ has no ioc code"},
+		},
+		{name: "unknown source system", from: "fips 10-4", code: "GM", to: "iso3", wantErr: true},
+		{name: "missing target system", from: "fips", code: "GM", to: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.Translate(context.Background(), "default-tenant", tt.from, tt.code, tt.to, asOf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Translate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			result := ""
+			if got.Result != nil {
+				result = *got.Result
+			}
+			got.Result = nil
+			if !reflect.DeepEqual(*got, tt.want) || result != tt.result {
+				t.Errorf("Translate() = %+v with result %q, want %+v with %q", *got, result, tt.want, tt.result)
+			}
+		})
+	}
+}
+
+func TestCrosswalkTranslateAll(t *testing.T) {
+	service := &CrosswalkAppService{
+		repo:      crosswalkRows{"DE": crosswalkRow("DE", "GM", "DE"), "ES": crosswalkRow("ES", "SP", "ES")},
+		validator: validation.NewCountryCodeValidator(),
+		tracer:    noopTracer{},
+	}
+	ctx := context.Background()
+
+	got, err := service.TranslateAll(ctx, "default-tenant", "iso2", []string{"es", "XX", "DE", "es"}, "fips", time.Now())
+	if err != nil {
+		t.Fatalf("TranslateAll() error = %v", err)
+	}
+	var results []string
+	for _, r := range got {
+		if r.Found {
+			results = append(results, *r.Result)
+		} else {
+			results = append(results, "")
+		}
+	}
+	if want := []string{"SP", "", "GM", "SP"}; !reflect.DeepEqual(results, want) {
+		t.Errorf("TranslateAll() = %q, want %q", results, want)
+	}
+
+	if got, err := service.TranslateAll(ctx, "default-tenant", "iso2", nil, "fips", time.Now()); err != nil || len(got) != 0 {
+		t.Errorf("TranslateAll() of no codes = %v, %v; want an empty result", got, err)
+	}
+	if _, err := service.TranslateAll(ctx, "default-tenant", "iso2", make([]string, MaxCrosswalkBatch+1), "fips", time.Now()); err == nil {
+		t.Error("TranslateAll() of too many codes succeeded, want error")
+	}
+}
+
+func TestNormalizeSystemCode(t *testing.T) {
+	tests := []struct {
+		system string
+		code   string
+		want   string
+	}{
+		{"numeric", "76", "076"},
+		{"numeric", " 8 ", "008"},
+		{"numeric", "276", "276"},
+		{"numeric", "7a", "7A"},
+		{"iso2", "de", "DE"},
+		{"wb", "1w", "1W"},
+		{"itu", "76", "76"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.system+" "+tt.code, func(t *testing.T) {
+			if got := normalizeSystemCode(tt.system, tt.code); got != tt.want {
+				t.Errorf("normalizeSystemCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
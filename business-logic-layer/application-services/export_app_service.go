@@ -0,0 +1,218 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/exporters"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// ExportRequest selects one export
+type ExportRequest struct {
+	Dataset string
+	Format  string
+	AsOf    *time.Time
+	// Fields are the exported columns in output order; empty exports all
+	Fields []string
+}
+
+// ExtractConfig configures the full extract
+type ExtractConfig struct {
+	// Dir receives one directory per run, <Dir>/<YYYY-MM-DD>/<tenant>/
+	Dir     string
+	Format  string
+	Tenants []string
+}
+
+// ExportAppService streams reference data exports and writes the full
+// extract loaded by the data warehouse
+type ExportAppService struct {
+	sources map[string]repositories.ExportSource
+	logger  logging.Logger
+	tracer  tracing.Tracer
+}
+
+// NewExportAppService creates an export service for the given datasets
+func NewExportAppService(logger logging.Logger, tracer tracing.Tracer, sources ...repositories.ExportSource) *ExportAppService {
+	byName := make(map[string]repositories.ExportSource, len(sources))
+	for _, source := range sources {
+		byName[source.Name] = source
+	}
+	return &ExportAppService{sources: byName, logger: logger, tracer: tracer}
+}
+
+// Datasets returns the names of the exportable datasets
+func (s *ExportAppService) Datasets() []string {
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks a request before anything is written, so that errors can
+// still be reported as such
+func (s *ExportAppService) Validate(req ExportRequest) error {
+	source, ok := s.sources[req.Dataset]
+	if !ok {
+		return errors.NewBusinessError("NOT_FOUND", fmt.Sprintf("unknown dataset %q", req.Dataset), nil)
+	}
+	if _, err := exporters.NewWriter(req.Format, io.Discard); err != nil {
+		return err
+	}
+	if req.AsOf != nil && !source.HasValidity {
+		return errors.NewValidationError("as_of", fmt.Sprintf("%s have no validity period", req.Dataset))
+	}
+	for _, field := range req.Fields {
+		if !source.HasColumn(field) {
+			return errors.NewValidationError("fields", fmt.Sprintf("unknown %s column %q", req.Dataset, field))
+		}
+	}
+	return nil
+}
+
+// Export streams one dataset of a tenant to w and returns the number of rows
+func (s *ExportAppService) Export(ctx context.Context, tenantID string, req ExportRequest, w io.Writer) (int, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "ExportAppService.Export",
+		attribute.String("export.dataset", req.Dataset),
+		attribute.String("export.format", req.Format))
+	defer span.End()
+
+	req.Format = strings.ToLower(req.Format)
+	if err := s.Validate(req); err != nil {
+		return 0, err
+	}
+	source := s.sources[req.Dataset]
+
+	fields := req.Fields
+	if req.Format == exporters.FormatGeoJSON && len(fields) > 0 {
+		fields = withCoordinates(source, fields)
+	}
+
+	writer, err := exporters.NewWriter(req.Format, w)
+	if err != nil {
+		return 0, err
+	}
+	counter := &countingWriter{Writer: writer}
+	if err := source.Export(ctx, tenantID, repositories.ExportOptions{AsOf: req.AsOf, Columns: fields}, counter); err != nil {
+		s.logger.Error(ctx, "Export failed", err,
+			logging.Field{Key: "dataset", Value: req.Dataset},
+			logging.Field{Key: "rows", Value: counter.rows})
+		return counter.rows, err
+	}
+	if err := writer.Close(); err != nil {
+		return counter.rows, err
+	}
+
+	s.logger.Info(ctx, "Exported dataset",
+		logging.Field{Key: "dataset", Value: req.Dataset},
+		logging.Field{Key: "format", Value: req.Format},
+		logging.Field{Key: "rows", Value: counter.rows},
+		logging.Field{Key: "operation", Value: "export"})
+	return counter.rows, nil
+}
+
+// Schedule writes the full extract at once and then every interval until ctx
+// is cancelled, so that a restart never leaves the day without an extract
+func (s *ExportAppService) Schedule(ctx context.Context, cfg ExtractConfig, interval time.Duration) {
+	extract := func(now time.Time) {
+		if err := s.Extract(ctx, cfg, now); err != nil {
+			s.logger.Error(ctx, "Full extract failed", err)
+		}
+	}
+
+	extract(time.Now())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			extract(now)
+		}
+	}
+}
+
+// Extract writes every dataset of every configured tenant, valid as of now,
+// to <Dir>/<YYYY-MM-DD>/<tenant>/<dataset>.<format>. Files appear under
+// their final name only once complete.
+func (s *ExportAppService) Extract(ctx context.Context, cfg ExtractConfig, now time.Time) error {
+	ctx, span := s.tracer.StartSpan(ctx, "ExportAppService.Extract")
+	defer span.End()
+
+	day := now.Format(repositories.DateLayout)
+	for _, tenantID := range cfg.Tenants {
+		dir := filepath.Join(cfg.Dir, day, tenantID)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		for _, dataset := range s.Datasets() {
+			path := filepath.Join(dir, dataset+exporters.Extension(cfg.Format))
+			if err := s.extractFile(ctx, tenantID, ExportRequest{Dataset: dataset, Format: cfg.Format}, path); err != nil {
+				return fmt.Errorf("extract %s of tenant %s: %w", dataset, tenantID, err)
+			}
+		}
+	}
+
+	s.logger.Info(ctx, "Full extract completed",
+		logging.Field{Key: "dir", Value: filepath.Join(cfg.Dir, day)},
+		logging.Field{Key: "tenants", Value: len(cfg.Tenants)})
+	return nil
+}
+
+func (s *ExportAppService) extractFile(ctx context.Context, tenantID string, req ExportRequest, path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := s.Export(ctx, tenantID, req, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// withCoordinates adds the coordinate columns of source to a field selection
+// so that GeoJSON features keep their geometry
+func withCoordinates(source repositories.ExportSource, fields []string) []string {
+	selected := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		selected[field] = true
+	}
+	for _, column := range []string{exporters.LatitudeColumn, exporters.LongitudeColumn} {
+		if source.HasColumn(column) && !selected[column] {
+			fields = append(fields, column)
+		}
+	}
+	return fields
+}
+
+// countingWriter counts the rows passed to an export writer
+type countingWriter struct {
+	exporters.Writer
+	rows int
+}
+
+func (w *countingWriter) Row(values []interface{}) error {
+	w.rows++
+	return w.Writer.Row(values)
+}
@@ -0,0 +1,110 @@
+package applicationservices
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/geo"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// GeoArea is a country or subdivision found at a point
+type GeoArea struct {
+	Code     string    `json:"code"`
+	Name     string    `json:"name"`
+	Centroid geo.Point `json:"centroid"`
+	BBox     geo.BBox  `json:"bbox"`
+}
+
+// GeoLookupResult is the country and subdivision containing a point, null
+// at sea or outside the bundled boundaries. MatchesDeclared compares the
+// country with a declared one, such as a billing country.
+type GeoLookupResult struct {
+	Latitude        float64  `json:"latitude"`
+	Longitude       float64  `json:"longitude"`
+	Country         *GeoArea `json:"country"`
+	Subdivision     *GeoArea `json:"subdivision"`
+	DeclaredCountry string   `json:"declared_country,omitempty"`
+	MatchesDeclared *bool    `json:"matches_declared,omitempty"`
+}
+
+// GeoAppService reverse geocodes points to countries and subdivisions with
+// the in-process boundary index, without calling external services
+type GeoAppService struct {
+	index           *geo.Index
+	countryRepo     *repositories.CountryRepository
+	subdivisionRepo *repositories.SubdivisionRepository
+	tracer          tracing.Tracer
+}
+
+func NewGeoAppService(index *geo.Index, countryRepo *repositories.CountryRepository, subdivisionRepo *repositories.SubdivisionRepository, tracer tracing.Tracer) *GeoAppService {
+	return &GeoAppService{index: index, countryRepo: countryRepo, subdivisionRepo: subdivisionRepo, tracer: tracer}
+}
+
+// Lookup returns the country and subdivision containing a point, named as in
+// the tenant's reference data where it has them. declaredCountry may be
+// empty.
+func (s *GeoAppService) Lookup(ctx context.Context, tenantID string, point geo.Point, declaredCountry string) (*GeoLookupResult, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "GeoAppService.Lookup",
+		attribute.Float64("geo.latitude", point.Latitude), attribute.Float64("geo.longitude", point.Longitude))
+	defer span.End()
+
+	if !point.Valid() {
+		return nil, errors.NewValidationError("lat", "latitude must be within -90 and 90 and longitude within -180 and 180")
+	}
+	result := &GeoLookupResult{Latitude: point.Latitude, Longitude: point.Longitude}
+	country, subdivision := s.index.Lookup(point)
+
+	if country != nil {
+		result.Country = geoArea(country)
+	}
+	if subdivision != nil {
+		result.Subdivision = geoArea(subdivision)
+	}
+	if country != nil {
+		stored, err := s.countryRepo.GetByCode(ctx, tenantID, country.Code)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			result.Country.Name = stored.CountryName
+		}
+		if stored != nil && subdivision != nil {
+			sub, err := s.subdivisionRepo.GetByCountryAndCode(ctx, tenantID, stored.CountryID, subdivision.Code)
+			if err != nil {
+				return nil, err
+			}
+			if sub != nil {
+				result.Subdivision.Name = sub.SubdivisionName
+			}
+		}
+	}
+
+	if declaredCountry != "" {
+		result.DeclaredCountry = strings.ToUpper(declaredCountry)
+		matches := result.Country != nil && result.Country.Code == result.DeclaredCountry
+		result.MatchesDeclared = &matches
+	}
+	return result, nil
+}
+
+// Boundary returns the simplified outline of a country or subdivision code
+func (s *GeoAppService) Boundary(ctx context.Context, code string) (*geo.Feature, error) {
+	_, span := s.tracer.StartSpan(ctx, "GeoAppService.Boundary", attribute.String("geo.code", code))
+	defer span.End()
+
+	boundary := s.index.Boundary(strings.ToUpper(code))
+	if boundary == nil {
+		return nil, errors.NewRepositoryError("NOT_FOUND", "no boundary for this code", nil)
+	}
+	feature := boundary.Feature()
+	return &feature, nil
+}
+
+func geoArea(b *geo.Boundary) *GeoArea {
+	return &GeoArea{Code: b.Code, Name: b.Name, Centroid: b.Centroid, BBox: b.BBox}
+}
@@ -0,0 +1,311 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/importers"
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// DefaultImportReason is the change reason of imports that give none
+const DefaultImportReason = "ISO 3166 import"
+
+// ImportOptions controls an import. Without Apply the import is a dry run
+// that only returns the plan.
+type ImportOptions struct {
+	Apply  bool
+	Reason string
+}
+
+// ImportCounts summarises the plan of one entity
+type ImportCounts struct {
+	Create     int `json:"create"`
+	Update     int `json:"update"`
+	Deactivate int `json:"deactivate"`
+	Unchanged  int `json:"unchanged"`
+}
+
+func (c *ImportCounts) add(action string) {
+	switch action {
+	case repositories.ImportCreate:
+		c.Create++
+	case repositories.ImportUpdate:
+		c.Update++
+	case repositories.ImportDeactivate:
+		c.Deactivate++
+	}
+}
+
+// ImportResult is the outcome of an import
+type ImportResult struct {
+	ImportID     uuid.UUID                `json:"import_id"`
+	Applied      bool                     `json:"applied"`
+	Countries    ImportCounts             `json:"countries"`
+	Subdivisions ImportCounts             `json:"subdivisions"`
+	Plan         *repositories.ImportPlan `json:"plan"`
+}
+
+// ImportAppService imports ISO 3166 countries and subdivisions. Rows are
+// matched on their natural keys; rows missing from the file are deactivated.
+type ImportAppService struct {
+	importRepo      *repositories.ImportRepository
+	countryRepo     *repositories.CountryRepository
+	subdivisionRepo *repositories.SubdivisionRepository
+	logger          logging.Logger
+	tracer          tracing.Tracer
+}
+
+// NewImportAppService creates an ISO 3166 import service
+func NewImportAppService(
+	importRepo *repositories.ImportRepository,
+	countryRepo *repositories.CountryRepository,
+	subdivisionRepo *repositories.SubdivisionRepository,
+	logger logging.Logger,
+	tracer tracing.Tracer,
+) *ImportAppService {
+	return &ImportAppService{
+		importRepo:      importRepo,
+		countryRepo:     countryRepo,
+		subdivisionRepo: subdivisionRepo,
+		logger:          logger,
+		tracer:          tracer,
+	}
+}
+
+// Import plans the changes that bring the tenant in line with dataset and,
+// when opts.Apply is set, applies them in one transaction
+func (s *ImportAppService) Import(ctx context.Context, tenantID string, dataset *importers.Dataset, opts ImportOptions) (*ImportResult, error) {
+	result := &ImportResult{ImportID: uuid.New()}
+	ctx, span := s.tracer.StartSpan(ctx, "ImportAppService.Import",
+		attribute.String("import.id", result.ImportID.String()),
+		attribute.Bool("import.apply", opts.Apply))
+	defer span.End()
+
+	plan, err := s.plan(ctx, tenantID, dataset, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Plan = plan
+
+	s.logger.Info(ctx, "Planned ISO 3166 import",
+		logging.Field{Key: "import_id", Value: result.ImportID},
+		logging.Field{Key: "countries", Value: result.Countries},
+		logging.Field{Key: "subdivisions", Value: result.Subdivisions},
+		logging.Field{Key: "operation", Value: "iso3166_import"})
+
+	if !opts.Apply || len(plan.Countries)+len(plan.Subdivisions) == 0 {
+		return result, nil
+	}
+
+	reason := opts.Reason
+	if reason == "" {
+		reason = DefaultImportReason
+	}
+	ctx = audit.WithChangeReason(ctx, fmt.Sprintf("%s %s", reason, result.ImportID))
+	if err := s.importRepo.Apply(ctx, tenantID, plan); err != nil {
+		s.logger.Error(ctx, "ISO 3166 import failed", err,
+			logging.Field{Key: "import_id", Value: result.ImportID})
+		return nil, err
+	}
+	result.Applied = true
+
+	s.logger.Info(ctx, "Applied ISO 3166 import",
+		logging.Field{Key: "import_id", Value: result.ImportID})
+	return result, nil
+}
+
+// plan diffs dataset against the stored rows and counts the changes
+func (s *ImportAppService) plan(ctx context.Context, tenantID string, dataset *importers.Dataset, result *ImportResult) (*repositories.ImportPlan, error) {
+	plan := &repositories.ImportPlan{
+		Countries:    []repositories.ImportChange[models.Country]{},
+		Subdivisions: []repositories.ImportChange[repositories.SubdivisionRecord]{},
+	}
+
+	current, err := s.importRepo.Countries(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	countries := make(map[string]*models.Country, len(current))
+	for i := range current {
+		countries[current[i].CountryCode] = &current[i]
+	}
+
+	countryValidator := validation.NewCountryValidator()
+	imported := make(map[string]bool, len(dataset.Countries))
+	for i := range dataset.Countries {
+		patch := dataset.Countries[i]
+		code := patch.CountryCode
+		if check := countryValidator.ValidateCountryCode(code); !check.IsValid {
+			return nil, errors.NewValidationError("country_code", fmt.Sprintf("%s: %s", code, check.Errors["country_code"]))
+		}
+		if imported[code] {
+			return nil, errors.NewValidationError("country_code", fmt.Sprintf("country %s appears more than once", code))
+		}
+		imported[code] = true
+		patch.IsActive = true
+
+		change := repositories.ImportChange[models.Country]{Key: code, Patch: &patch}
+		if existing, ok := countries[code]; !ok {
+			change.Action = repositories.ImportCreate
+			change.Changes = s.countryRepo.Changes(ctx, nil, &patch)
+		} else {
+			merged := s.countryRepo.Merge(ctx, existing, &patch)
+			change.Changes = s.countryRepo.Changes(ctx, existing, merged)
+			if len(change.Changes) == 0 {
+				result.Countries.Unchanged++
+				continue
+			}
+			change.Action = repositories.ImportUpdate
+			change.ID, change.Version, change.Patch = &existing.CountryID, existing.Version, merged
+		}
+		result.Countries.add(change.Action)
+		plan.Countries = append(plan.Countries, change)
+	}
+	if len(dataset.Countries) > 0 {
+		for _, existing := range current {
+			if imported[existing.CountryCode] || !existing.IsActive {
+				continue
+			}
+			existing := existing
+			plan.Countries = append(plan.Countries, repositories.ImportChange[models.Country]{
+				Action:  repositories.ImportDeactivate,
+				Key:     existing.CountryCode,
+				ID:      &existing.CountryID,
+				Version: existing.Version,
+				Changes: map[string]repositories.FieldChange{"is_active": {Before: true, After: false}},
+			})
+			result.Countries.add(repositories.ImportDeactivate)
+		}
+	}
+
+	if len(dataset.Subdivisions) == 0 {
+		return plan, nil
+	}
+	return plan, s.planSubdivisions(ctx, tenantID, dataset, countries, plan, result)
+}
+
+// planSubdivisions adds the subdivision changes to plan. Subdivisions missing
+// from the file are deactivated only in countries the file has subdivisions
+// for. An empty parent leaves the stored parent unchanged.
+func (s *ImportAppService) planSubdivisions(ctx context.Context, tenantID string, dataset *importers.Dataset, countries map[string]*models.Country, plan *repositories.ImportPlan, result *ImportResult) error {
+	current, err := s.importRepo.Subdivisions(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	subdivisions := make(map[string]*repositories.SubdivisionRecord, len(current))
+	for i := range current {
+		subdivisions[current[i].SubdivisionCode] = &current[i]
+	}
+
+	importedCountries := make(map[string]bool, len(dataset.Countries))
+	for _, c := range dataset.Countries {
+		importedCountries[c.CountryCode] = true
+	}
+
+	subdivisionValidator := validation.NewSubdivisionValidator()
+	parents := make(map[string]string, len(dataset.Subdivisions))
+	covered := make(map[string]bool)
+	for _, record := range dataset.Subdivisions {
+		code := record.SubdivisionCode
+		if check := subdivisionValidator.ValidateSubdivisionCode(code, record.CountryCode); !check.IsValid {
+			return errors.NewValidationError("subdivision_code", fmt.Sprintf("%s: %s", code, check.Errors["subdivision_code"]))
+		}
+		if _, ok := countries[record.CountryCode]; !ok && !importedCountries[record.CountryCode] {
+			return errors.NewValidationError("country_code", fmt.Sprintf("subdivision %s: unknown country %s", code, record.CountryCode))
+		}
+		if _, ok := parents[code]; ok {
+			return errors.NewValidationError("subdivision_code", fmt.Sprintf("subdivision %s appears more than once", code))
+		}
+		parents[code] = record.ParentCode
+		covered[record.CountryCode] = true
+	}
+
+	var changes []repositories.ImportChange[repositories.SubdivisionRecord]
+	levels := make(map[string]int, len(dataset.Subdivisions))
+	for i := range dataset.Subdivisions {
+		patch := dataset.Subdivisions[i]
+		code := patch.SubdivisionCode
+		if patch.ParentCode != "" {
+			if _, ok := parents[patch.ParentCode]; !ok && subdivisions[patch.ParentCode] == nil {
+				return errors.NewValidationError("parent_code", fmt.Sprintf("subdivision %s: unknown parent %s", code, patch.ParentCode))
+			}
+		}
+		level, err := importLevel(code, parents)
+		if err != nil {
+			return err
+		}
+		levels[code] = level
+		patch.IsActive = true
+
+		change := repositories.ImportChange[repositories.SubdivisionRecord]{Key: code, Patch: &patch}
+		existing, ok := subdivisions[code]
+		if !ok {
+			change.Action = repositories.ImportCreate
+			change.Changes = s.subdivisionRepo.Changes(ctx, nil, &patch.CountrySubdivision)
+			if patch.ParentCode != "" {
+				change.Changes["parent_code"] = repositories.FieldChange{After: patch.ParentCode}
+			}
+		} else {
+			merged := s.subdivisionRepo.Merge(ctx, &existing.CountrySubdivision, &patch.CountrySubdivision)
+			change.Changes = s.subdivisionRepo.Changes(ctx, &existing.CountrySubdivision, merged)
+			if patch.ParentCode != "" && patch.ParentCode != existing.ParentCode {
+				change.Changes["parent_code"] = repositories.FieldChange{Before: existing.ParentCode, After: patch.ParentCode}
+			}
+			if len(change.Changes) == 0 {
+				result.Subdivisions.Unchanged++
+				continue
+			}
+			change.Action = repositories.ImportUpdate
+			change.ID, change.Version = &existing.SubdivisionID, existing.Version
+			patch.CountrySubdivision = *merged
+		}
+		result.Subdivisions.add(change.Action)
+		changes = append(changes, change)
+	}
+
+	// Parents are written before their children
+	sort.SliceStable(changes, func(i, j int) bool {
+		return levels[changes[i].Key] < levels[changes[j].Key]
+	})
+	plan.Subdivisions = append(plan.Subdivisions, changes...)
+
+	for _, existing := range current {
+		if _, ok := parents[existing.SubdivisionCode]; ok || !covered[existing.CountryCode] || !existing.IsActive {
+			continue
+		}
+		existing := existing
+		plan.Subdivisions = append(plan.Subdivisions, repositories.ImportChange[repositories.SubdivisionRecord]{
+			Action:  repositories.ImportDeactivate,
+			Key:     existing.SubdivisionCode,
+			ID:      &existing.SubdivisionID,
+			Version: existing.Version,
+			Changes: map[string]repositories.FieldChange{"is_active": {Before: true, After: false}},
+		})
+		result.Subdivisions.add(repositories.ImportDeactivate)
+	}
+	return nil
+}
+
+// importLevel returns the number of ancestors code has within the imported
+// file, rejecting parent cycles
+func importLevel(code string, parents map[string]string) (int, error) {
+	level := 0
+	for parent := parents[code]; parent != ""; parent = parents[parent] {
+		level++
+		if level > len(parents) {
+			return 0, errors.NewValidationError("parent_code", fmt.Sprintf("subdivision %s is its own ancestor", code))
+		}
+	}
+	return level, nil
+}
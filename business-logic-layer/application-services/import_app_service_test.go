@@ -0,0 +1,42 @@
+package applicationservices
+
+import "testing"
+
+func TestImportLevel(t *testing.T) {
+	parents := map[string]string{
+		"FR-IDF": "",
+		"FR-75C": "FR-IDF",
+		"FR-XX1": "FR-75C",
+		"ES-M":   "ES-MD",
+		"GB-AAA": "GB-BBB",
+		"GB-BBB": "GB-AAA",
+		"GB-SLF": "GB-SLF",
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		want    int
+		wantErr bool
+	}{
+		{"top level", "FR-IDF", 0, false},
+		{"second level", "FR-75C", 1, false},
+		{"third level", "FR-XX1", 2, false},
+		{"parent stored outside the file", "ES-M", 1, false},
+		{"not in the file", "DE-BY", 0, false},
+		{"two-step cycle", "GB-AAA", 0, true},
+		{"own parent", "GB-SLF", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importLevel(tt.code, parents)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("importLevel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("importLevel() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
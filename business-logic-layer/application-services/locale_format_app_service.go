@@ -0,0 +1,148 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/i18n"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// Value types accepted by LocaleFormatAppService.Format
+const (
+	FormatTypeNumber   = "number"
+	FormatTypePercent  = "percent"
+	FormatTypeCurrency = "currency"
+	FormatTypeDate     = "date"
+	FormatTypeTime     = "time"
+	FormatTypeDateTime = "datetime"
+	FormatTypePlural   = "plural"
+)
+
+// FormatRequest is a value to format for a locale. Numbers are decimal
+// strings, dates RFC 3339 instants shown in Zone.
+type FormatRequest struct {
+	Type     string
+	Value    string
+	Currency string
+	// CurrencyDigits is the number of fraction digits of Currency
+	CurrencyDigits int
+	Style          string
+	Zone           string
+}
+
+// FormatResult is a formatted value; Category is set for plural requests
+type FormatResult struct {
+	Locale    string `json:"locale"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Currency  string `json:"currency,omitempty"`
+	Style     string `json:"style,omitempty"`
+	Zone      string `json:"zone,omitempty"`
+	Formatted string `json:"formatted,omitempty"`
+	Category  string `json:"category,omitempty"`
+}
+
+// LocaleFormatAppService formats numbers, currencies and dates with CLDR data
+type LocaleFormatAppService struct {
+	cldr      *i18n.CLDR
+	validator *validation.TimezoneValidator
+	tracer    tracing.Tracer
+}
+
+func NewLocaleFormatAppService(cldr *i18n.CLDR, tracer tracing.Tracer) *LocaleFormatAppService {
+	return &LocaleFormatAppService{cldr: cldr, validator: validation.NewTimezoneValidator(), tracer: tracer}
+}
+
+// Formats returns the separators, patterns, first day of the week and plural
+// categories of a BCP 47 locale
+func (s *LocaleFormatAppService) Formats(ctx context.Context, locale string) (*i18n.LocaleFormat, error) {
+	_, span := s.tracer.StartSpan(ctx, "LocaleFormatAppService.Formats", attribute.String("locale.code", locale))
+	defer span.End()
+
+	format, err := s.cldr.Locale(locale)
+	if err != nil {
+		return nil, errors.NewValidationError("locale", err.Error())
+	}
+	return format, nil
+}
+
+// Format formats a value for a BCP 47 locale
+func (s *LocaleFormatAppService) Format(ctx context.Context, locale string, req FormatRequest) (*FormatResult, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "LocaleFormatAppService.Format",
+		attribute.String("locale.code", locale), attribute.String("format.type", req.Type))
+	defer span.End()
+
+	format, err := s.Formats(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+	result := &FormatResult{Locale: format.Locale, Type: req.Type, Value: req.Value}
+
+	switch req.Type {
+	case FormatTypeNumber, FormatTypePercent, FormatTypeCurrency:
+		value, err := strconv.ParseFloat(req.Value, 64)
+		if err != nil {
+			return nil, errors.NewValidationError("value", fmt.Sprintf("%q is not a number", req.Value))
+		}
+		switch req.Type {
+		case FormatTypeNumber:
+			result.Formatted = format.FormatNumber(value)
+		case FormatTypePercent:
+			result.Formatted = format.FormatPercent(value)
+		default:
+			if len(req.Currency) != 3 {
+				return nil, errors.NewValidationError("currency", "an ISO 4217 currency code is required")
+			}
+			result.Currency = req.Currency
+			result.Formatted = format.FormatCurrency(value, req.Currency, req.CurrencyDigits)
+		}
+	case FormatTypeDate, FormatTypeTime, FormatTypeDateTime:
+		at, err := time.Parse(time.RFC3339, req.Value)
+		if err != nil {
+			return nil, errors.NewValidationError("value", "value must be an RFC 3339 instant")
+		}
+		zone := req.Zone
+		if zone == "" {
+			zone = "UTC"
+		}
+		loc, err := s.validator.Location(zone)
+		if err != nil {
+			return nil, errors.NewValidationError("zone", fmt.Sprintf("unknown IANA time zone %q", zone))
+		}
+		style := req.Style
+		if style == "" {
+			style = "medium"
+		}
+		result.Style, result.Zone = style, loc.String()
+
+		var formatted string
+		switch req.Type {
+		case FormatTypeDate:
+			formatted, err = format.FormatDate(at.In(loc), style)
+		case FormatTypeTime:
+			formatted, err = format.FormatTime(at.In(loc), style)
+		default:
+			formatted, err = format.FormatDateTime(at.In(loc), style)
+		}
+		if err != nil {
+			return nil, errors.NewValidationError("style", err.Error())
+		}
+		result.Formatted = formatted
+	case FormatTypePlural:
+		category, err := format.PluralCategory(req.Value)
+		if err != nil {
+			return nil, errors.NewValidationError("value", err.Error())
+		}
+		result.Category = category
+	default:
+		return nil, errors.NewValidationError("type", "type must be number, percent, currency, date, time, datetime or plural")
+	}
+	return result, nil
+}
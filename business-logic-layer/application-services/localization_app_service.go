@@ -0,0 +1,183 @@
+package applicationservices
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/i18n"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// BaseNameLocale is the locale of the names held on the entities themselves
+const BaseNameLocale = "en"
+
+// LocalizedName is the name of an entity in the negotiated locale, or in the
+// nearest locale of its fallback chain. Locale is BaseNameLocale when the
+// entity has no translation in any of them.
+type LocalizedName struct {
+	Locale       string  `json:"locale"`
+	Name         string  `json:"name"`
+	ShortName    *string `json:"short_name,omitempty"`
+	OfficialName *string `json:"official_name,omitempty"`
+	SortName     *string `json:"sort_name,omitempty"`
+}
+
+// LocalizedCountry is a country with its localized name
+type LocalizedCountry struct {
+	models.Country
+	Localized *LocalizedName `json:"localized,omitempty"`
+}
+
+// LocalizedRegion is a region with its localized name
+type LocalizedRegion struct {
+	models.Region
+	Localized *LocalizedName `json:"localized,omitempty"`
+}
+
+// LocalizedLanguage is a language with its localized name
+type LocalizedLanguage struct {
+	models.Language
+	Localized *LocalizedName `json:"localized,omitempty"`
+}
+
+// LocalizedSubdivision is a subdivision with its localized name
+type LocalizedSubdivision struct {
+	models.CountrySubdivision
+	Localized *LocalizedName `json:"localized,omitempty"`
+}
+
+// LocalizationAppService negotiates the locale of a request among the
+// tenant's locales and names entities in it
+type LocalizationAppService struct {
+	repo       *repositories.NameTranslationRepository
+	localeRepo *repositories.LocaleRepository
+	tracer     tracing.Tracer
+}
+
+func NewLocalizationAppService(repo *repositories.NameTranslationRepository, localeRepo *repositories.LocaleRepository, tracer tracing.Tracer) *LocalizationAppService {
+	return &LocalizationAppService{repo: repo, localeRepo: localeRepo, tracer: tracer}
+}
+
+// Negotiate returns the active locale of the tenant that best serves an
+// Accept-Language header, or the empty string when the header names none of
+// them
+func (s *LocalizationAppService) Negotiate(ctx context.Context, tenantID, acceptLanguage string) (string, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "LocalizationAppService.Negotiate")
+	defer span.End()
+
+	preferred := i18n.ParseAcceptLanguage(acceptLanguage)
+	if len(preferred) == 0 {
+		return "", nil
+	}
+	codes, err := s.localeRepo.ActiveCodes(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	supported := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if tag, err := i18n.CanonicalTag(code); err == nil {
+			supported = append(supported, tag)
+		}
+	}
+	sort.Strings(supported)
+
+	locale := i18n.Negotiate(preferred, supported)
+	span.SetAttributes(attribute.String("locale.code", locale))
+	return locale, nil
+}
+
+// Countries names countries in locale
+func (s *LocalizationAppService) Countries(ctx context.Context, tenantID, locale string, countries []models.Country) ([]LocalizedCountry, error) {
+	ids, names := make([]uuid.UUID, len(countries)), make([]string, len(countries))
+	for i, c := range countries {
+		ids[i], names[i] = c.CountryID, c.CountryName
+	}
+	localized, err := s.localize(ctx, tenantID, locale, repositories.CountrySpec.Name, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]LocalizedCountry, len(countries))
+	for i, c := range countries {
+		result[i] = LocalizedCountry{c, localized[i]}
+	}
+	return result, nil
+}
+
+// Regions names regions in locale
+func (s *LocalizationAppService) Regions(ctx context.Context, tenantID, locale string, regions []models.Region) ([]LocalizedRegion, error) {
+	ids, names := make([]uuid.UUID, len(regions)), make([]string, len(regions))
+	for i, r := range regions {
+		ids[i], names[i] = r.RegionID, r.RegionName
+	}
+	localized, err := s.localize(ctx, tenantID, locale, repositories.RegionSpec.Name, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]LocalizedRegion, len(regions))
+	for i, r := range regions {
+		result[i] = LocalizedRegion{r, localized[i]}
+	}
+	return result, nil
+}
+
+// Languages names languages in locale
+func (s *LocalizationAppService) Languages(ctx context.Context, tenantID, locale string, languages []models.Language) ([]LocalizedLanguage, error) {
+	ids, names := make([]uuid.UUID, len(languages)), make([]string, len(languages))
+	for i, l := range languages {
+		ids[i], names[i] = l.LanguageID, l.LanguageName
+	}
+	localized, err := s.localize(ctx, tenantID, locale, repositories.LanguageSpec.Name, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]LocalizedLanguage, len(languages))
+	for i, l := range languages {
+		result[i] = LocalizedLanguage{l, localized[i]}
+	}
+	return result, nil
+}
+
+// Subdivisions names subdivisions in locale
+func (s *LocalizationAppService) Subdivisions(ctx context.Context, tenantID, locale string, subdivisions []models.CountrySubdivision) ([]LocalizedSubdivision, error) {
+	ids, names := make([]uuid.UUID, len(subdivisions)), make([]string, len(subdivisions))
+	for i, sub := range subdivisions {
+		ids[i], names[i] = sub.SubdivisionID, sub.SubdivisionName
+	}
+	localized, err := s.localize(ctx, tenantID, locale, repositories.SubdivisionSpec.Name, ids, names)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]LocalizedSubdivision, len(subdivisions))
+	for i, sub := range subdivisions {
+		result[i] = LocalizedSubdivision{sub, localized[i]}
+	}
+	return result, nil
+}
+
+// localize names the entities of one type in locale, looking each up along
+// the fallback chain of locale and falling back to its base name
+func (s *LocalizationAppService) localize(ctx context.Context, tenantID, locale, entityType string, ids []uuid.UUID, names []string) ([]*LocalizedName, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "LocalizationAppService.Localize",
+		attribute.String("entity.type", entityType), attribute.String("locale.code", locale), attribute.Int("entity.count", len(ids)))
+	defer span.End()
+
+	translations, err := s.repo.Lookup(ctx, tenantID, entityType, ids, i18n.FallbackChain(locale))
+	if err != nil {
+		return nil, err
+	}
+	localized := make([]*LocalizedName, len(ids))
+	for i, id := range ids {
+		t, ok := translations[id]
+		if !ok {
+			localized[i] = &LocalizedName{Locale: BaseNameLocale, Name: names[i]}
+			continue
+		}
+		localized[i] = &LocalizedName{Locale: t.LocaleCode, Name: t.Name, ShortName: t.ShortName, OfficialName: t.OfficialName, SortName: t.SortName}
+	}
+	return localized, nil
+}
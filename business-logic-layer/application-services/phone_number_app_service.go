@@ -0,0 +1,287 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// MaxPhoneNumberBatch bounds the numbers normalized in one call
+const MaxPhoneNumberBatch = 1000
+
+var (
+	// extensionPattern matches a trailing extension: "ext. 12", "x12", "#12"
+	extensionPattern = regexp.MustCompile(`(?i)\s*(?:ext\.?|extension|x|#)\s*([0-9]{1,6})\s*$`)
+	// phoneCharsPattern is what may remain of a number besides its digits
+	phoneCharsPattern = regexp.MustCompile(`^\+?[0-9 ()./\-\x{00a0}]+$`)
+)
+
+// Dialling prefixes tried when no default country says which one applies
+var commonInternationalPrefixes = []string{"00", "011"}
+
+// PhoneNumberResult is a parsed phone number. Reason explains why a number
+// is not valid.
+type PhoneNumberResult struct {
+	Input          string `json:"input"`
+	Valid          bool   `json:"valid"`
+	E164           string `json:"e164,omitempty"`
+	CallingCode    string `json:"calling_code,omitempty"`
+	NationalNumber string `json:"national_number,omitempty"`
+	Extension      string `json:"extension,omitempty"`
+	CountryCode    string `json:"country_code,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// phonePlanSource looks up numbering plans, the methods of
+// PhoneNumberingPlanRepository that parsing needs
+type phonePlanSource interface {
+	ForCountry(ctx context.Context, tenantID, countryCode string) (*repositories.PhoneNumberingPlanView, error)
+	ForCallingCodes(ctx context.Context, tenantID string, callingCodes []string) ([]repositories.PhoneNumberingPlanView, error)
+}
+
+// PhoneNumberAppService validates phone numbers and normalizes them to E.164
+// with the numbering plans of countries
+type PhoneNumberAppService struct {
+	planRepo phonePlanSource
+	tracer   tracing.Tracer
+}
+
+func NewPhoneNumberAppService(planRepo *repositories.PhoneNumberingPlanRepository, tracer tracing.Tracer) *PhoneNumberAppService {
+	return &PhoneNumberAppService{planRepo: planRepo, tracer: tracer}
+}
+
+// Parse reads a number written in international form, with + or a dialling
+// prefix, or in the national form of defaultCountry, which may be empty.
+// Spaces, dashes, dots, slashes, parentheses and a trailing extension are
+// accepted. An invalid number is not an error; its result carries a reason.
+func (s *PhoneNumberAppService) Parse(ctx context.Context, tenantID, number, defaultCountry string) (*PhoneNumberResult, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "PhoneNumberAppService.Parse", attribute.String("phone.default_country", defaultCountry))
+	defer span.End()
+
+	home, err := s.home(ctx, tenantID, defaultCountry)
+	if err != nil {
+		return nil, err
+	}
+	return s.parse(ctx, tenantID, number, home)
+}
+
+// ParseAll parses a batch of numbers sharing a default country
+func (s *PhoneNumberAppService) ParseAll(ctx context.Context, tenantID string, numbers []string, defaultCountry string) ([]*PhoneNumberResult, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "PhoneNumberAppService.ParseAll",
+		attribute.String("phone.default_country", defaultCountry), attribute.Int("phone.count", len(numbers)))
+	defer span.End()
+
+	if len(numbers) > MaxPhoneNumberBatch {
+		return nil, errors.NewValidationError("numbers", fmt.Sprintf("at most %d numbers per request", MaxPhoneNumberBatch))
+	}
+	home, err := s.home(ctx, tenantID, defaultCountry)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*PhoneNumberResult, 0, len(numbers))
+	for _, number := range numbers {
+		result, err := s.parse(ctx, tenantID, number, home)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// home returns the plan of the default country, nil when none is given
+func (s *PhoneNumberAppService) home(ctx context.Context, tenantID, defaultCountry string) (*repositories.PhoneNumberingPlanView, error) {
+	if defaultCountry == "" {
+		return nil, nil
+	}
+	plan, err := s.planRepo.ForCountry(ctx, tenantID, strings.ToUpper(defaultCountry))
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, errors.NewValidationError("default_country", fmt.Sprintf("no phone numbering plan for country %q", defaultCountry))
+	}
+	return plan, nil
+}
+
+// CountriesForCallingCode returns the plans of all countries sharing a
+// calling code such as +1
+func (s *PhoneNumberAppService) CountriesForCallingCode(ctx context.Context, tenantID, callingCode string) ([]repositories.PhoneNumberingPlanView, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "PhoneNumberAppService.CountriesForCallingCode", attribute.String("phone.calling_code", callingCode))
+	defer span.End()
+
+	code := strings.TrimPrefix(strings.TrimSpace(callingCode), "+")
+	if code == "" || len(code) > 3 || strings.Trim(code, "0123456789") != "" {
+		return nil, errors.NewValidationError("calling_code", "must be 1 to 3 digits")
+	}
+	return s.planRepo.ForCallingCodes(ctx, tenantID, []string{code})
+}
+
+func (s *PhoneNumberAppService) parse(ctx context.Context, tenantID, number string, home *repositories.PhoneNumberingPlanView) (*PhoneNumberResult, error) {
+	result := &PhoneNumberResult{Input: number}
+	text := strings.TrimSpace(number)
+	if m := extensionPattern.FindStringSubmatchIndex(text); m != nil {
+		result.Extension = text[m[2]:m[3]]
+		text = text[:m[0]]
+	}
+	if !phoneCharsPattern.MatchString(text) {
+		result.Reason = "the number contains characters other than digits, separators and a leading +"
+		return result, nil
+	}
+	digits := onlyDigits(text)
+	if digits == "" {
+		result.Reason = "the number has no digits"
+		return result, nil
+	}
+
+	international, ok := "", false
+	switch {
+	case strings.HasPrefix(text, "+"):
+		international, ok = digits, true
+	case home != nil:
+		if home.InternationalPrefix != nil && strings.HasPrefix(digits, *home.InternationalPrefix) {
+			international, ok = digits[len(*home.InternationalPrefix):], true
+		}
+	default:
+		for _, prefix := range commonInternationalPrefixes {
+			if strings.HasPrefix(digits, prefix) {
+				international, ok = digits[len(prefix):], true
+				break
+			}
+		}
+	}
+
+	if !ok {
+		if home == nil {
+			result.Reason = "a number without + or international prefix needs a default country"
+			return result, nil
+		}
+		national := stripTrunkPrefix(digits, home.TrunkPrefix, home.MinLength, false)
+		// Calling code typed without +, e.g. 44 20 7946 0958 for GB
+		if len(national) > int(home.MaxLength) && strings.HasPrefix(national, home.CallingCode) {
+			international = national
+		} else {
+			return s.resolve(ctx, tenantID, result, home.CallingCode, national)
+		}
+	}
+
+	candidates := []string{}
+	for n := 1; n <= 3 && n <= len(international); n++ {
+		candidates = append(candidates, international[:n])
+	}
+	plans, err := s.planRepo.ForCallingCodes(ctx, tenantID, candidates)
+	if err != nil {
+		return nil, err
+	}
+	callingCode := ""
+	for _, plan := range plans {
+		if len(plan.CallingCode) > len(callingCode) {
+			callingCode = plan.CallingCode
+		}
+	}
+	if callingCode == "" {
+		result.Reason = "unknown country calling code"
+		return result, nil
+	}
+	return s.resolve(ctx, tenantID, result, callingCode, international[len(callingCode):])
+}
+
+// resolve picks the country of a national number within its calling code
+// and checks the number length against the plan of that country
+func (s *PhoneNumberAppService) resolve(ctx context.Context, tenantID string, result *PhoneNumberResult, callingCode, national string) (*PhoneNumberResult, error) {
+	plans, err := s.planRepo.ForCallingCodes(ctx, tenantID, []string{callingCode})
+	if err != nil {
+		return nil, err
+	}
+	result.CallingCode = callingCode
+
+	var plan *repositories.PhoneNumberingPlanView
+	written := national
+	for i := range plans {
+		candidate := &plans[i]
+		if !candidate.IsActive {
+			continue
+		}
+		// "+44 (0)20 ..." repeats the trunk prefix after the calling code
+		number := stripTrunkPrefix(written, candidate.TrunkPrefix, candidate.MaxLength, true)
+		if candidate.LeadingDigits == nil || *candidate.LeadingDigits == "" {
+			if plan == nil {
+				plan, national = candidate, number
+			}
+			continue
+		}
+		if hasLeadingDigits(number, *candidate.LeadingDigits) {
+			plan, national = candidate, number
+			break
+		}
+	}
+	result.NationalNumber = national
+	if plan == nil {
+		result.Reason = fmt.Sprintf("the number matches no country of calling code +%s", callingCode)
+		return result, nil
+	}
+	result.CountryCode = plan.CountryCode
+
+	if len(national) < int(plan.MinLength) || len(national) > int(plan.MaxLength) {
+		if plan.MinLength == plan.MaxLength {
+			result.Reason = fmt.Sprintf("national numbers of %s have %d digits", plan.CountryCode, plan.MinLength)
+		} else {
+			result.Reason = fmt.Sprintf("national numbers of %s have %d to %d digits", plan.CountryCode, plan.MinLength, plan.MaxLength)
+		}
+		return result, nil
+	}
+	if len(callingCode)+len(national) > validation.E164MaxDigits {
+		result.Reason = "the number exceeds the 15 digits of E.164"
+		return result, nil
+	}
+	result.Valid = true
+	result.E164 = "+" + callingCode + national
+	return result, nil
+}
+
+// stripTrunkPrefix removes a leading trunk prefix. With onlyIfLong set, as
+// after a calling code, it is removed only when the number exceeds limit;
+// otherwise only when at least limit digits remain.
+func stripTrunkPrefix(number string, trunkPrefix *string, limit int16, onlyIfLong bool) string {
+	if trunkPrefix == nil || *trunkPrefix == "" || !strings.HasPrefix(number, *trunkPrefix) {
+		return number
+	}
+	rest := number[len(*trunkPrefix):]
+	if onlyIfLong {
+		if len(number) > int(limit) {
+			return rest
+		}
+		return number
+	}
+	if len(rest) >= int(limit) {
+		return rest
+	}
+	return number
+}
+
+func hasLeadingDigits(number, leadingDigits string) bool {
+	for _, prefix := range strings.Split(leadingDigits, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" && strings.HasPrefix(number, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,215 @@
+package applicationservices
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+)
+
+// noopTracer satisfies tracing.Tracer without exporting spans
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, name string, _ ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return noop.NewTracerProvider().Tracer("test").Start(ctx, name)
+}
+
+func (noopTracer) StartSQLSpan(ctx context.Context, _, operation string, _ string) (context.Context, oteltrace.Span) {
+	return noop.NewTracerProvider().Tracer("test").Start(ctx, "sql."+operation)
+}
+
+// planList serves numbering plans from memory in the order of the
+// repository, by calling code and country code
+type planList []repositories.PhoneNumberingPlanView
+
+func (l planList) ForCountry(_ context.Context, _, countryCode string) (*repositories.PhoneNumberingPlanView, error) {
+	for i := range l {
+		if l[i].CountryCode == countryCode {
+			return &l[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (l planList) ForCallingCodes(_ context.Context, _ string, callingCodes []string) ([]repositories.PhoneNumberingPlanView, error) {
+	var views []repositories.PhoneNumberingPlanView
+	for _, plan := range l {
+		for _, code := range callingCodes {
+			if plan.CallingCode == code {
+				views = append(views, plan)
+			}
+		}
+	}
+	return views, nil
+}
+
+func phonePlan(country, callingCode, trunk, international string, min, max int16, leading string) repositories.PhoneNumberingPlanView {
+	plan := repositories.PhoneNumberingPlanView{
+		PhoneNumberingPlan: models.PhoneNumberingPlan{CallingCode: callingCode, MinLength: min, MaxLength: max, IsActive: true},
+		CountryCode:        country,
+	}
+	if trunk != "" {
+		plan.TrunkPrefix = &trunk
+	}
+	if international != "" {
+		plan.InternationalPrefix = &international
+	}
+	if leading != "" {
+		plan.LeadingDigits = &leading
+	}
+	return plan
+}
+
+// testPlans is a subset of the seeded numbering plans
+var testPlans = planList{
+	phonePlan("CA", "1", "1", "011", 10, 10, "204,416,514,604"),
+	phonePlan("US", "1", "1", "011", 10, 10, ""),
+	phonePlan("FR", "33", "0", "00", 9, 9, ""),
+	phonePlan("GB", "44", "0", "00", 9, 10, ""),
+	phonePlan("DE", "49", "0", "00", 6, 13, ""),
+}
+
+func TestPhoneNumberParse(t *testing.T) {
+	service := &PhoneNumberAppService{planRepo: testPlans, tracer: noopTracer{}}
+
+	tests := []struct {
+		name           string
+		number         string
+		defaultCountry string
+		want           PhoneNumberResult
+	}{
+		{
+			name:   "international with plus",
+			number: "+44 20 7946 0958",
+			want:   PhoneNumberResult{Valid: true, E164: "+442079460958", CallingCode: "44", NationalNumber: "2079460958", CountryCode: "GB"},
+		},
+		{
+			name:   "trunk prefix repeated after the calling code",
+			number: "+44 (0)20 7946 0958",
+			want:   PhoneNumberResult{Valid: true, E164: "+442079460958", CallingCode: "44", NationalNumber: "2079460958", CountryCode: "GB"},
+		},
+		{
+			name:           "national with trunk prefix",
+			number:         "020 7946 0958",
+			defaultCountry: "gb",
+			want:           PhoneNumberResult{Valid: true, E164: "+442079460958", CallingCode: "44", NationalNumber: "2079460958", CountryCode: "GB"},
+		},
+		{
+			name:           "calling code typed without plus",
+			number:         "44 20 7946 0958",
+			defaultCountry: "GB",
+			want:           PhoneNumberResult{Valid: true, E164: "+442079460958", CallingCode: "44", NationalNumber: "2079460958", CountryCode: "GB"},
+		},
+		{
+			name:   "common international prefix without default country",
+			number: "0049 30 123456",
+			want:   PhoneNumberResult{Valid: true, E164: "+4930123456", CallingCode: "49", NationalNumber: "30123456", CountryCode: "DE"},
+		},
+		{
+			name:           "international prefix of the default country",
+			number:         "011 44 20 7946 0958",
+			defaultCountry: "US",
+			want:           PhoneNumberResult{Valid: true, E164: "+442079460958", CallingCode: "44", NationalNumber: "2079460958", CountryCode: "GB"},
+		},
+		{
+			name:   "shared calling code told apart by leading digits",
+			number: "+1 416-555-0199",
+			want:   PhoneNumberResult{Valid: true, E164: "+14165550199", CallingCode: "1", NationalNumber: "4165550199", CountryCode: "CA"},
+		},
+		{
+			name:   "main country of a shared calling code",
+			number: "+1.212.555.0199",
+			want:   PhoneNumberResult{Valid: true, E164: "+12125550199", CallingCode: "1", NationalNumber: "2125550199", CountryCode: "US"},
+		},
+		{
+			name:           "extension",
+			number:         "(212) 555-0199 ext. 12",
+			defaultCountry: "US",
+			want:           PhoneNumberResult{Valid: true, E164: "+12125550199", CallingCode: "1", NationalNumber: "2125550199", Extension: "12", CountryCode: "US"},
+		},
+		{
+			name:   "national number without default country",
+			number: "2079460958",
+			want:   PhoneNumberResult{Reason: "a number without + or international prefix needs a default country"},
+		},
+		{
+			name:   "unknown calling code",
+			number: "+999 123 456",
+			want:   PhoneNumberResult{Reason: "unknown country calling code"},
+		},
+		{
+			name:   "too short for the country",
+			number: "+33 1 23 45 67",
+			want:   PhoneNumberResult{CallingCode: "33", NationalNumber: "1234567", CountryCode: "FR", Reason: "national numbers of FR have 9 digits"},
+		},
+		{
+			name:   "length range in the reason",
+			number: "+44 20 79",
+			want:   PhoneNumberResult{CallingCode: "44", NationalNumber: "2079", CountryCode: "GB", Reason: "national numbers of GB have 9 to 10 digits"},
+		},
+		{
+			name:   "letters",
+			number: "+49 30 CALL NOW",
+			want:   PhoneNumberResult{Reason: "the number contains characters other than digits, separators and a leading +"},
+		},
+		{
+			name:   "no digits",
+			number: "( )",
+			want:   PhoneNumberResult{Reason: "the number has no digits"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.Parse(context.Background(), "default-tenant", tt.number, tt.defaultCountry)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			tt.want.Input = tt.number
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneNumberRejects(t *testing.T) {
+	service := &PhoneNumberAppService{planRepo: testPlans, tracer: noopTracer{}}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"default country without a plan", func() error {
+			_, err := service.Parse(ctx, "default-tenant", "020 7946 0958", "ZZ")
+			return err
+		}},
+		{"batch too large", func() error {
+			_, err := service.ParseAll(ctx, "default-tenant", make([]string, MaxPhoneNumberBatch+1), "")
+			return err
+		}},
+		{"calling code too long", func() error {
+			_, err := service.CountriesForCallingCode(ctx, "default-tenant", "+1234")
+			return err
+		}},
+		{"calling code not digits", func() error {
+			_, err := service.CountriesForCallingCode(ctx, "default-tenant", "+1a")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err == nil {
+				t.Error("succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// PostalCodeValidation is the outcome of validating a postal code. The
+// subdivision is set when the code belongs to exactly one subdivision with
+// postal code rules.
+type PostalCodeValidation struct {
+	CountryCode     string  `json:"country_code"`
+	PostalCode      string  `json:"postal_code"`
+	Valid           bool    `json:"valid"`
+	Required        bool    `json:"required"`
+	Normalized      string  `json:"normalized,omitempty"`
+	SubdivisionCode *string `json:"subdivision_code,omitempty"`
+	SubdivisionName *string `json:"subdivision_name,omitempty"`
+	Example         *string `json:"example,omitempty"`
+	Reason          string  `json:"reason,omitempty"`
+}
+
+// PostalCodeAppService validates postal codes against the rules stored for
+// countries and subdivisions
+type PostalCodeAppService struct {
+	formatRepo *repositories.PostalCodeFormatRepository
+	tracer     tracing.Tracer
+}
+
+func NewPostalCodeAppService(formatRepo *repositories.PostalCodeFormatRepository, tracer tracing.Tracer) *PostalCodeAppService {
+	return &PostalCodeAppService{formatRepo: formatRepo, tracer: tracer}
+}
+
+// Validate checks a postal code of a country, and against a subdivision when
+// subdivisionCode is given. An invalid code is not an error; the result
+// carries a reason.
+func (s *PostalCodeAppService) Validate(ctx context.Context, tenantID string, country *models.Country, postalCode, subdivisionCode string) (*PostalCodeValidation, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "PostalCodeAppService.Validate", attribute.String("country.code", country.CountryCode))
+	defer span.End()
+
+	formats, err := s.formatRepo.ForCountry(ctx, tenantID, country.CountryID)
+	if err != nil {
+		return nil, err
+	}
+	var active []repositories.PostalCodeFormatView
+	for _, format := range formats {
+		if format.IsActive {
+			active = append(active, format)
+		}
+	}
+	if len(active) == 0 {
+		return nil, errors.NewRepositoryError("NOT_FOUND", "no postal code format for this country", nil)
+	}
+	return validatePostalCode(country.CountryCode, active, postalCode, subdivisionCode), nil
+}
+
+func validatePostalCode(countryCode string, formats []repositories.PostalCodeFormatView, postalCode, subdivisionCode string) *PostalCodeValidation {
+	result := &PostalCodeValidation{CountryCode: countryCode, PostalCode: postalCode}
+
+	var countryRules, subdivisionRules []repositories.PostalCodeFormatView
+	for _, format := range formats {
+		if format.SubdivisionID == nil {
+			countryRules = append(countryRules, format)
+			if format.IsRequired != nil && *format.IsRequired {
+				result.Required = true
+			}
+		} else {
+			subdivisionRules = append(subdivisionRules, format)
+		}
+	}
+	// Without country-wide patterns the subdivision patterns define the codes
+	patterns := withPattern(countryRules)
+	if len(patterns) == 0 {
+		patterns = subdivisionRules
+	}
+	if len(patterns) > 0 {
+		result.Example = patterns[0].Example
+	}
+
+	compact := validation.CompactPostalCode(postalCode)
+	if compact == "" {
+		result.Valid = !result.Required
+		if result.Required {
+			result.Reason = "a postal code is required"
+		}
+		return result
+	}
+	if len(patterns) == 0 {
+		result.Reason = fmt.Sprintf("%s does not use postal codes", countryCode)
+		return result
+	}
+
+	matched := false
+	for _, rule := range patterns {
+		re, err := regexp.Compile(*rule.Pattern)
+		if err != nil || !re.MatchString(compact) {
+			continue
+		}
+		matched = true
+		result.Normalized = compact
+		if rule.Format != nil {
+			result.Normalized = re.ReplaceAllString(compact, *rule.Format)
+		}
+		break
+	}
+	if !matched {
+		result.Reason = fmt.Sprintf("does not match the postal code format of %s", countryCode)
+		return result
+	}
+
+	// Subdivisions whose rules match, keeping the most specific when a
+	// subdivision and its parent both match
+	matches := map[uuid.UUID]repositories.PostalCodeFormatView{}
+	hasRules := false
+	for _, rule := range subdivisionRules {
+		if rule.SubdivisionCode != nil && *rule.SubdivisionCode == subdivisionCode {
+			hasRules = true
+		}
+		if matchesPattern(*rule.Pattern, compact) {
+			matches[*rule.SubdivisionID] = rule
+		}
+	}
+	for _, rule := range matches {
+		if rule.ParentSubdivisionID != nil {
+			delete(matches, *rule.ParentSubdivisionID)
+		}
+	}
+
+	if subdivisionCode != "" && hasRules {
+		var claimed *repositories.PostalCodeFormatView
+		for _, rule := range subdivisionRules {
+			if rule.SubdivisionCode != nil && *rule.SubdivisionCode == subdivisionCode && matchesPattern(*rule.Pattern, compact) {
+				claimed = &rule
+				break
+			}
+		}
+		if claimed == nil {
+			result.Reason = fmt.Sprintf("the postal code is not in subdivision %s", subdivisionCode)
+			return result
+		}
+		// The given subdivision wins over its own descendants and siblings
+		matches = map[uuid.UUID]repositories.PostalCodeFormatView{*claimed.SubdivisionID: *claimed}
+	}
+
+	result.Valid = true
+	if len(matches) == 1 {
+		for _, rule := range matches {
+			result.SubdivisionCode, result.SubdivisionName = rule.SubdivisionCode, rule.SubdivisionName
+		}
+	}
+	return result
+}
+
+// matchesPattern reports whether a compact code matches a stored pattern; a
+// pattern that does not compile matches nothing
+func matchesPattern(pattern, compact string) bool {
+	re, err := regexp.Compile(pattern)
+	return err == nil && re.MatchString(compact)
+}
+
+func withPattern(formats []repositories.PostalCodeFormatView) []repositories.PostalCodeFormatView {
+	var out []repositories.PostalCodeFormatView
+	for _, format := range formats {
+		if format.Pattern != nil {
+			out = append(out, format)
+		}
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package applicationservices
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+)
+
+// countryRule is a country-wide postal code rule; an empty pattern means the
+// country has no postal codes
+func countryRule(pattern, format, example string, required bool) repositories.PostalCodeFormatView {
+	rule := repositories.PostalCodeFormatView{PostalCodeFormat: models.PostalCodeFormat{IsRequired: &required, IsActive: true}}
+	if pattern != "" {
+		rule.Pattern = &pattern
+	}
+	if format != "" {
+		rule.Format = &format
+	}
+	if example != "" {
+		rule.Example = &example
+	}
+	return rule
+}
+
+// subdivisionRule is the rule of a subdivision whose ID is derived from its
+// code, so that parents can be named by code
+func subdivisionRule(code, parent, pattern string) repositories.PostalCodeFormatView {
+	id := uuid.NewSHA1(uuid.NameSpaceOID, []byte(code))
+	name := "Subdivision " + code
+	rule := repositories.PostalCodeFormatView{
+		PostalCodeFormat: models.PostalCodeFormat{SubdivisionID: &id, Pattern: &pattern, IsActive: true},
+		SubdivisionCode:  &code,
+		SubdivisionName:  &name,
+	}
+	if parent != "" {
+		parentID := uuid.NewSHA1(uuid.NameSpaceOID, []byte(parent))
+		rule.ParentSubdivisionID = &parentID
+	}
+	return rule
+}
+
+// postalRules are taken from the seeded postal code formats
+var postalRules = map[string][]repositories.PostalCodeFormatView{
+	"US": {
+		countryRule(`^([0-9]{5})$`, "", "20500", true),
+		countryRule(`^([0-9]{5})([0-9]{4})$`, "$1-$2", "20500-0003", true),
+		subdivisionRule("US-CA", "", `^9[0-6][0-9]{3}([0-9]{4})?$`),
+		subdivisionRule("US-NY", "", `^(1[0-4][0-9]|005)[0-9]{2}([0-9]{4})?$`),
+	},
+	"CA": {
+		countryRule(`^([ABCEGHJ-NPRSTVXY][0-9][ABCEGHJ-NPRSTV-Z])([0-9][ABCEGHJ-NPRSTV-Z][0-9])$`, "$1 $2", "K1A 0B1", true),
+	},
+	"ES": {
+		countryRule(`^(0[1-9]|[1-4][0-9]|5[0-2])[0-9]{3}$`, "", "28013", true),
+		subdivisionRule("ES-CT", "", `^(08|17|25|43)[0-9]{3}$`),
+		subdivisionRule("ES-B", "ES-CT", `^08[0-9]{3}$`),
+		subdivisionRule("ES-MD", "", `^28[0-9]{3}$`),
+		subdivisionRule("ES-M", "ES-MD", `^28[0-9]{3}$`),
+	},
+	"FR": {
+		countryRule(`^[0-9]{5}$`, "", "75008", true),
+		subdivisionRule("FR-IDF", "", `^(75|77|78|91|92|93|94|95)[0-9]{3}$`),
+		subdivisionRule("FR-75C", "FR-IDF", `^75[0-9]{3}$`),
+		subdivisionRule("FR-92", "FR-IDF", `^92[0-9]{3}$`),
+	},
+	"AE": {countryRule("", "", "", false)},
+	"ZZ": {subdivisionRule("ZZ-A", "", `^1[0-9]{2}$`)},
+	"XY": {
+		countryRule(`^([0-9]$`, "", "", false),
+		countryRule(`^[0-9]{4}$`, "", "", false),
+	},
+}
+
+func TestValidatePostalCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		country     string
+		postalCode  string
+		subdivision string
+		valid       bool
+		normalized  string
+		found       string
+		reason      string
+	}{
+		{name: "five digits", country: "US", postalCode: "20500", valid: true, normalized: "20500"},
+		{name: "format rebuilds the separator", country: "US", postalCode: "205000003", valid: true, normalized: "20500-0003"},
+		{name: "compacted before matching", country: "CA", postalCode: " k1a-0b1 ", valid: true, normalized: "K1A 0B1"},
+		{name: "subdivision from the leading digits", country: "US", postalCode: "94105", valid: true, normalized: "94105", found: "US-CA"},
+		{name: "child wins over its parent", country: "ES", postalCode: "08001", valid: true, normalized: "08001", found: "ES-B"},
+		{name: "child with the same pattern as its parent", country: "ES", postalCode: "28013", valid: true, normalized: "28013", found: "ES-M"},
+		{name: "parent when no child matches", country: "FR", postalCode: "77000", valid: true, normalized: "77000", found: "FR-IDF"},
+		{name: "given subdivision wins over its children", country: "ES", postalCode: "08001", subdivision: "ES-CT", valid: true, normalized: "08001", found: "ES-CT"},
+		{name: "given subdivision without rules", country: "US", postalCode: "10001", subdivision: "US-WA", valid: true, normalized: "10001", found: "US-NY"},
+		{name: "only subdivision patterns", country: "ZZ", postalCode: "123", valid: true, normalized: "123", found: "ZZ-A"},
+		{name: "stored pattern that does not compile is skipped", country: "XY", postalCode: "1234", valid: true, normalized: "1234"},
+		{name: "optional code left out", country: "AE", postalCode: "", valid: true},
+		{name: "required code left out", country: "US", postalCode: " - ", reason: "a postal code is required"},
+		{name: "wrong format", country: "US", postalCode: "2050", reason: "does not match the postal code format of US"},
+		{name: "country without postal codes", country: "AE", postalCode: "12345", reason: "AE does not use postal codes"},
+		{name: "code outside the given subdivision", country: "US", postalCode: "10001", subdivision: "US-CA", normalized: "10001", reason: "the postal code is not in subdivision US-CA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validatePostalCode(tt.country, postalRules[tt.country], tt.postalCode, tt.subdivision)
+			found := ""
+			if got.SubdivisionCode != nil {
+				found = *got.SubdivisionCode
+			}
+			if got.Valid != tt.valid || got.Normalized != tt.normalized || found != tt.found || got.Reason != tt.reason {
+				t.Errorf("validatePostalCode() = valid %v, normalized %q, subdivision %q, reason %q; want %v, %q, %q, %q",
+					got.Valid, got.Normalized, found, got.Reason, tt.valid, tt.normalized, tt.found, tt.reason)
+			}
+		})
+	}
+}
+
+func TestValidatePostalCodeExample(t *testing.T) {
+	tests := []struct {
+		country  string
+		required bool
+		example  string
+	}{
+		{"US", true, "20500"},
+		{"CA", true, "K1A 0B1"},
+		{"AE", false, ""},
+		{"ZZ", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country, func(t *testing.T) {
+			got := validatePostalCode(tt.country, postalRules[tt.country], "", "")
+			example := ""
+			if got.Example != nil {
+				example = *got.Example
+			}
+			if got.Required != tt.required || example != tt.example {
+				t.Errorf("validatePostalCode() required %v, example %q; want %v, %q", got.Required, example, tt.required, tt.example)
+			}
+		})
+	}
+}
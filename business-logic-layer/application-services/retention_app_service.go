@@ -0,0 +1,288 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// Retention actions recorded in retention reports
+const (
+	RetentionAnonymizeAudit = "anonymize_audit"
+	RetentionPurgeDeleted   = "purge_deleted"
+)
+
+// RetentionActor is recorded as the actor of purges made by the retention job
+const RetentionActor = "retention-job"
+
+// retentionPurgeBatch bounds the rows purged per group and run
+const retentionPurgeBatch = 1000
+
+// DefaultRetentionPolicies apply to rows without a retention_policy, keyed by
+// data_classification. Unknown classifications use the PUBLIC default.
+var DefaultRetentionPolicies = map[string]string{
+	"PUBLIC":       "7y-audit",
+	"INTERNAL":     "7y-audit",
+	"CONFIDENTIAL": "2y-audit",
+	"RESTRICTED":   "1y-audit",
+}
+
+// RetentionPeriod is a calendar period such as 7y or 90d
+type RetentionPeriod struct {
+	Years, Months, Days int
+}
+
+// Before returns the instant the period ends before now
+func (p RetentionPeriod) Before(now time.Time) time.Time {
+	return now.AddDate(-p.Years, -p.Months, -p.Days)
+}
+
+// RetentionPolicy is a parsed policy name. A name joins one or more rules
+// with '+':
+//
+//	<period>-audit           anonymize IP, device and location audit data after period
+//	purge-deleted-<period>   purge rows soft deleted longer than period
+//
+// where period is a number followed by d, w, m or y, e.g. 7y-audit+purge-deleted-90d.
+type RetentionPolicy struct {
+	Name           string
+	AnonymizeAudit *RetentionPeriod
+	PurgeDeleted   *RetentionPeriod
+}
+
+// ParseRetentionPolicy parses a policy name
+func ParseRetentionPolicy(name string) (RetentionPolicy, error) {
+	policy := RetentionPolicy{Name: name}
+	for _, rule := range strings.Split(name, "+") {
+		var target **RetentionPeriod
+		var raw string
+		switch {
+		case strings.HasPrefix(rule, "purge-deleted-"):
+			target, raw = &policy.PurgeDeleted, strings.TrimPrefix(rule, "purge-deleted-")
+		case strings.HasSuffix(rule, "-audit"):
+			target, raw = &policy.AnonymizeAudit, strings.TrimSuffix(rule, "-audit")
+		default:
+			return policy, errors.NewValidationError("retention_policy", fmt.Sprintf("unknown rule %q in policy %q", rule, name))
+		}
+		if *target != nil {
+			return policy, errors.NewValidationError("retention_policy", fmt.Sprintf("policy %q repeats rule %q", name, rule))
+		}
+		period, err := parseRetentionPeriod(raw)
+		if err != nil {
+			return policy, errors.NewValidationError("retention_policy", fmt.Sprintf("invalid period %q in policy %q", raw, name))
+		}
+		*target = &period
+	}
+	return policy, nil
+}
+
+func parseRetentionPeriod(raw string) (RetentionPeriod, error) {
+	if len(raw) < 2 {
+		return RetentionPeriod{}, fmt.Errorf("period too short")
+	}
+	n, err := strconv.Atoi(raw[:len(raw)-1])
+	if err != nil || n < 1 {
+		return RetentionPeriod{}, fmt.Errorf("period must be a positive number")
+	}
+	switch raw[len(raw)-1] {
+	case 'd':
+		return RetentionPeriod{Days: n}, nil
+	case 'w':
+		return RetentionPeriod{Days: 7 * n}, nil
+	case 'm':
+		return RetentionPeriod{Months: n}, nil
+	case 'y':
+		return RetentionPeriod{Years: n}, nil
+	}
+	return RetentionPeriod{}, fmt.Errorf("unknown period unit")
+}
+
+// RetentionAppService evaluates retention policies per entity and tenant,
+// anonymizes expired audit data, purges expired deleted rows and writes a
+// retention report for every action
+type RetentionAppService struct {
+	retentionRepo *repositories.RetentionRepository
+	targets       []repositories.RetentionTarget
+	logger        logging.Logger
+	tracer        tracing.Tracer
+}
+
+// NewRetentionAppService creates a retention service for the given tables
+func NewRetentionAppService(
+	retentionRepo *repositories.RetentionRepository,
+	logger logging.Logger,
+	tracer tracing.Tracer,
+	targets ...repositories.RetentionTarget,
+) *RetentionAppService {
+	return &RetentionAppService{
+		retentionRepo: retentionRepo,
+		targets:       targets,
+		logger:        logger,
+		tracer:        tracer,
+	}
+}
+
+// Schedule runs retention every interval until ctx is cancelled
+func (s *RetentionAppService) Schedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.Run(ctx, now); err != nil {
+				s.logger.Error(ctx, "Retention run failed", err)
+			}
+		}
+	}
+}
+
+// Run applies retention as of now and returns the reports it stored. A policy
+// that fails for one group is reported and does not stop the run. An entity
+// type whose groups cannot be loaded is skipped; the reports of the others
+// are still stored before its error is returned.
+func (s *RetentionAppService) Run(ctx context.Context, now time.Time) ([]models.RetentionReport, error) {
+	runID := uuid.New()
+	ctx, span := s.tracer.StartSpan(ctx, "RetentionAppService.Run",
+		attribute.String("retention.run_id", runID.String()))
+	defer span.End()
+	ctx = logging.WithUserID(ctx, RetentionActor)
+
+	s.logger.Info(ctx, "Starting retention run",
+		logging.Field{Key: "run_id", Value: runID},
+		logging.Field{Key: "operation", Value: "retention_run"})
+
+	var (
+		reports []models.RetentionReport
+		failed  error
+	)
+	for _, target := range s.targets {
+		groups, err := s.retentionRepo.Groups(ctx, target)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to load retention groups", err,
+				logging.Field{Key: "run_id", Value: runID},
+				logging.Field{Key: "entity_type", Value: target.EntityType})
+			if failed == nil {
+				failed = err
+			}
+			continue
+		}
+		for _, group := range groups {
+			reports = append(reports, s.apply(ctx, runID, now, target, group)...)
+		}
+	}
+
+	if err := s.retentionRepo.SaveReports(ctx, reports); err != nil {
+		s.logger.Error(ctx, "Failed to save retention reports", err,
+			logging.Field{Key: "run_id", Value: runID})
+		return reports, err
+	}
+	if failed != nil {
+		return reports, failed
+	}
+
+	s.logger.Info(ctx, "Retention run completed",
+		logging.Field{Key: "run_id", Value: runID},
+		logging.Field{Key: "reports", Value: len(reports)})
+	return reports, nil
+}
+
+// Reports returns the latest retention reports of a tenant
+func (s *RetentionAppService) Reports(ctx context.Context, tenantID string, limit int) ([]models.RetentionReport, error) {
+	return s.retentionRepo.Reports(ctx, tenantID, limit)
+}
+
+// apply evaluates the policy of one group
+func (s *RetentionAppService) apply(ctx context.Context, runID uuid.UUID, now time.Time, target repositories.RetentionTarget, group repositories.RetentionGroup) []models.RetentionReport {
+	name := group.Policy
+	if name == "" {
+		var ok bool
+		if name, ok = DefaultRetentionPolicies[group.Classification]; !ok {
+			name = DefaultRetentionPolicies["PUBLIC"]
+		}
+	}
+
+	report := func(action string, cutoff time.Time) models.RetentionReport {
+		r := models.RetentionReport{
+			RunID:      runID,
+			TenantID:   group.TenantID,
+			EntityType: target.EntityType,
+			Policy:     name,
+			Action:     action,
+			Cutoff:     cutoff,
+			StartedAt:  time.Now(),
+		}
+		if group.Policy == "" {
+			r.Classification = &group.Classification
+		}
+		return r
+	}
+	finish := func(r *models.RetentionReport, err error) {
+		finished := time.Now()
+		r.FinishedAt = &finished
+		if err != nil {
+			msg := err.Error()
+			r.Error = &msg
+			s.logger.Error(ctx, "Retention action failed", err,
+				logging.Field{Key: "entity_type", Value: target.EntityType},
+				logging.Field{Key: "tenant_id", Value: group.TenantID},
+				logging.Field{Key: "policy", Value: name},
+				logging.Field{Key: "action", Value: r.Action})
+		}
+	}
+
+	policy, err := ParseRetentionPolicy(name)
+	if err != nil {
+		r := report(RetentionAnonymizeAudit, now)
+		finish(&r, err)
+		return []models.RetentionReport{r}
+	}
+
+	var reports []models.RetentionReport
+	if policy.AnonymizeAudit != nil {
+		r := report(RetentionAnonymizeAudit, policy.AnonymizeAudit.Before(now))
+		rows, err := s.retentionRepo.AnonymizeAudit(ctx, target, group, r.Cutoff)
+		r.RowsAffected = rows
+		finish(&r, err)
+		reports = append(reports, r)
+	}
+	if policy.PurgeDeleted != nil {
+		r := report(RetentionPurgeDeleted, policy.PurgeDeleted.Before(now))
+		r.RowsAffected, err = s.purgeDeleted(ctx, target, group, name, r.Cutoff)
+		finish(&r, err)
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+// purgeDeleted purges the expired deleted rows of a group one by one, so that
+// each purge is recorded in the entity history and raises an event
+func (s *RetentionAppService) purgeDeleted(ctx context.Context, target repositories.RetentionTarget, group repositories.RetentionGroup, policy string, cutoff time.Time) (int64, error) {
+	ids, err := s.retentionRepo.ExpiredDeleted(ctx, target, group, cutoff, retentionPurgeBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx = audit.WithChangeReason(ctx, "retention policy "+policy)
+	var purged int64
+	for _, id := range ids {
+		if err := target.Purge(ctx, group.TenantID, id); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
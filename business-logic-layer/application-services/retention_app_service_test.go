@@ -0,0 +1,109 @@
+package applicationservices
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+)
+
+func TestParseRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    string
+		anonymize *RetentionPeriod
+		purge     *RetentionPeriod
+		wantErr   bool
+	}{
+		{name: "audit years", policy: "7y-audit", anonymize: &RetentionPeriod{Years: 7}},
+		{name: "purge days", policy: "purge-deleted-90d", purge: &RetentionPeriod{Days: 90}},
+		{name: "weeks are days", policy: "purge-deleted-2w", purge: &RetentionPeriod{Days: 14}},
+		{name: "both rules", policy: "18m-audit+purge-deleted-1y", anonymize: &RetentionPeriod{Months: 18}, purge: &RetentionPeriod{Years: 1}},
+		{name: "rules in any order", policy: "purge-deleted-30d+2y-audit", anonymize: &RetentionPeriod{Years: 2}, purge: &RetentionPeriod{Days: 30}},
+		{name: "empty", policy: "", wantErr: true},
+		{name: "unknown rule", policy: "7y-archive", wantErr: true},
+		{name: "repeated rule", policy: "7y-audit+1y-audit", wantErr: true},
+		{name: "missing unit", policy: "7-audit", wantErr: true},
+		{name: "unknown unit", policy: "7h-audit", wantErr: true},
+		{name: "zero period", policy: "purge-deleted-0d", wantErr: true},
+		{name: "negative period", policy: "purge-deleted--1d", wantErr: true},
+		{name: "missing period", policy: "purge-deleted-", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRetentionPolicy(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRetentionPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.AnonymizeAudit, tt.anonymize) {
+				t.Errorf("AnonymizeAudit = %v, want %v", got.AnonymizeAudit, tt.anonymize)
+			}
+			if !reflect.DeepEqual(got.PurgeDeleted, tt.purge) {
+				t.Errorf("PurgeDeleted = %v, want %v", got.PurgeDeleted, tt.purge)
+			}
+		})
+	}
+}
+
+func TestDefaultRetentionPoliciesParse(t *testing.T) {
+	for classification, policy := range DefaultRetentionPolicies {
+		if _, err := ParseRetentionPolicy(policy); err != nil {
+			t.Errorf("default policy %q of %s: %v", policy, classification, err)
+		}
+	}
+}
+
+func TestRetentionPeriodBefore(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		period RetentionPeriod
+		want   time.Time
+	}{
+		{"days", RetentionPeriod{Days: 90}, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"months normalize past the end of february", RetentionPeriod{Months: 1}, time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"years", RetentionPeriod{Years: 7}, time.Date(2017, 3, 31, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Before(now); !got.Equal(tt.want) {
+				t.Errorf("Before() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyReportsInvalidPolicy covers a group whose policy cannot be parsed:
+// it is reported as failed without touching the repository
+func TestApplyReportsInvalidPolicy(t *testing.T) {
+	s := NewRetentionAppService(nil, logging.NewStructuredLogger("test"), nil)
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	target := repositories.RetentionTarget{EntityType: "country"}
+	group := repositories.RetentionGroup{TenantID: "default-tenant", Policy: "forever"}
+
+	reports := s.apply(context.Background(), uuid.New(), now, target, group)
+	if len(reports) != 1 {
+		t.Fatalf("apply() returned %d reports, want 1", len(reports))
+	}
+	r := reports[0]
+	if r.Policy != "forever" || r.Action != RetentionAnonymizeAudit || !r.Cutoff.Equal(now) {
+		t.Errorf("apply() report = %+v", r)
+	}
+	if r.Error == nil || r.FinishedAt == nil {
+		t.Errorf("apply() report has no error or finish time: %+v", r)
+	}
+	if r.Classification != nil {
+		t.Errorf("apply() classification = %v, want nil for an explicit policy", *r.Classification)
+	}
+}
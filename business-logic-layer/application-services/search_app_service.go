@@ -0,0 +1,62 @@
+package applicationservices
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// MinSearchLength is the shortest query accepted for type-ahead search
+const MinSearchLength = 2
+
+// SearchAppService orchestrates multilingual fuzzy search across reference entities
+type SearchAppService struct {
+	searchRepo *repositories.SearchRepository
+	logger     logging.Logger
+	tracer     tracing.Tracer
+}
+
+// NewSearchAppService creates a new search application service
+func NewSearchAppService(
+	searchRepo *repositories.SearchRepository,
+	logger logging.Logger,
+	tracer tracing.Tracer,
+) *SearchAppService {
+	return &SearchAppService{
+		searchRepo: searchRepo,
+		logger:     logger,
+		tracer:     tracer,
+	}
+}
+
+// Search validates the query and returns ranked hits with entity-type facets
+func (s *SearchAppService) Search(ctx context.Context, tenantID string, query repositories.SearchQuery) (*repositories.SearchResult, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "SearchAppService.Search",
+		attribute.String("search.query", query.Text))
+	defer span.End()
+
+	query.Text = strings.TrimSpace(query.Text)
+	if utf8.RuneCountInString(query.Text) < MinSearchLength {
+		return nil, errors.NewValidationError("q", "must be at least 2 characters")
+	}
+
+	result, err := s.searchRepo.Search(ctx, tenantID, query)
+	if err != nil {
+		s.logger.Error(ctx, "Search failed", err,
+			logging.Field{Key: "query", Value: query.Text})
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Search completed",
+		logging.Field{Key: "query", Value: query.Text},
+		logging.Field{Key: "hits", Value: len(result.Hits)})
+
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package applicationservices
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/tracing"
+)
+
+// transitionHorizon bounds the search for the next offset change. Zones
+// without daylight saving have none, so the search must stop somewhere.
+const transitionHorizon = 2 * 366 * 24 * time.Hour
+
+// ZoneState is the offset of a zone in effect at an instant
+type ZoneState struct {
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+	UTCOffset        string `json:"utc_offset"`
+	Abbreviation     string `json:"abbreviation"`
+	IsDST            bool   `json:"is_dst"`
+}
+
+// ZoneTransition is an offset change; the new state applies from At
+type ZoneTransition struct {
+	At time.Time `json:"at"`
+	ZoneState
+}
+
+// ZoneResolution describes a zone at an instant
+type ZoneResolution struct {
+	Zone      string    `json:"zone"`
+	At        time.Time `json:"at"`
+	LocalTime string    `json:"local_time"`
+	ZoneState
+	// NextTransition is nil when the offset does not change within two years
+	NextTransition *ZoneTransition `json:"next_transition"`
+}
+
+// TimezoneAppService resolves IANA time zones using the embedded tz database
+type TimezoneAppService struct {
+	validator *validation.TimezoneValidator
+	tracer    tracing.Tracer
+}
+
+func NewTimezoneAppService(tracer tracing.Tracer) *TimezoneAppService {
+	return &TimezoneAppService{validator: validation.NewTimezoneValidator(), tracer: tracer}
+}
+
+// Resolve returns the offset, abbreviation and daylight saving state of zone
+// at an instant, and the next transition after it
+func (s *TimezoneAppService) Resolve(ctx context.Context, zone string, at time.Time) (*ZoneResolution, error) {
+	_, span := s.tracer.StartSpan(ctx, "TimezoneAppService.Resolve", attribute.String("timezone.zone", zone))
+	defer span.End()
+
+	loc, err := s.validator.Location(zone)
+	if err != nil {
+		return nil, errors.NewValidationError("zone", fmt.Sprintf("unknown IANA time zone %q", zone))
+	}
+	at = at.Truncate(time.Second)
+	local := at.In(loc)
+	resolution := &ZoneResolution{
+		Zone:      loc.String(),
+		At:        at.UTC(),
+		LocalTime: local.Format(time.RFC3339),
+		ZoneState: zoneState(local),
+	}
+	if next, ok := nextTransition(loc, at); ok {
+		resolution.NextTransition = &ZoneTransition{At: next.UTC(), ZoneState: zoneState(next.In(loc))}
+	}
+	return resolution, nil
+}
+
+func zoneState(t time.Time) ZoneState {
+	name, offset := t.Zone()
+	return ZoneState{
+		UTCOffsetSeconds: offset,
+		UTCOffset:        t.Format("-07:00"),
+		Abbreviation:     name,
+		IsDST:            t.IsDST(),
+	}
+}
+
+// nextTransition finds the first instant after at whose zone state differs
+// from the state at at. It walks forward a day at a time, as no zone changes
+// offset twice within a day, then bisects to the second.
+func nextTransition(loc *time.Location, at time.Time) (time.Time, bool) {
+	start := zoneState(at.In(loc))
+	changed := func(t time.Time) bool { return zoneState(t.In(loc)) != start }
+
+	lo := at
+	for step := time.Duration(0); step < transitionHorizon; step += 24 * time.Hour {
+		hi := lo.Add(24 * time.Hour)
+		if !changed(hi) {
+			lo = hi
+			continue
+		}
+		// changed(lo) is false and changed(hi) is true
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+			if mid.Equal(lo) {
+				mid = lo.Add(time.Second)
+			}
+			if changed(mid) {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		return hi, true
+	}
+	return time.Time{}, false
+}
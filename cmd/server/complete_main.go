@@ -8,15 +8,21 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/bootstrap"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/config"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/geo"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/i18n"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/cli"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
 	v1 "github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/v1"
+	v2 "github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/v2"
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
 )
 
@@ -50,23 +56,155 @@ func main() {
 	defer container.Close()
 
 	// Initialize all repositories
+	countryRepo := repositories.NewCountryRepository(container.DBManager.DB)
 	regionRepo := repositories.NewRegionRepository(container.DBManager.DB)
 	languageRepo := repositories.NewLanguageRepository(container.DBManager.DB)
 	timezoneRepo := repositories.NewTimezoneRepository(container.DBManager.DB)
 	subdivisionRepo := repositories.NewSubdivisionRepository(container.DBManager.DB)
 	localeRepo := repositories.NewLocaleRepository(container.DBManager.DB)
+	currencyRepo := repositories.NewCurrencyRepository(container.DBManager.DB)
+	countryCurrencyRepo := repositories.NewCountryCurrencyRepository(container.DBManager.DB)
+	countryLanguageRepo := repositories.NewCountryLanguageRepository(container.DBManager.DB)
+	countryTimezoneRepo := repositories.NewCountryTimezoneRepository(container.DBManager.DB)
+	phonePlanRepo := repositories.NewPhoneNumberingPlanRepository(container.DBManager.DB)
+	postalCodeFormatRepo := repositories.NewPostalCodeFormatRepository(container.DBManager.DB)
+	addressFormatRepo := repositories.NewAddressFormatRepository(container.DBManager.DB)
+	countryBorderRepo := repositories.NewCountryBorderRepository(container.DBManager.DB)
+	countryExternalCodeRepo := repositories.NewCountryExternalCodeRepository(container.DBManager.DB)
+	nameTranslationRepo := repositories.NewNameTranslationRepository(container.DBManager.DB)
+	groupingRepo := repositories.NewGroupingRepository(container.DBManager.DB)
+	groupingMembershipRepo := repositories.NewGroupingMembershipRepository(container.DBManager.DB)
 
 	// Initialize all handlers
-	countriesHandler := v1.NewCountriesHandler(container.CountryAppService, container.Logger)
-	regionsHandler := v1.NewRegionsHandler(regionRepo)
-	languagesHandler := v1.NewLanguagesHandler(languageRepo)
-	timezonesHandler := v1.NewTimezonesHandler(timezoneRepo)
-	subdivisionsHandler := v1.NewSubdivisionsHandler(subdivisionRepo)
-	localesHandler := v1.NewLocalesHandler(localeRepo)
+	names := container.LocalizationAppService
+	countriesHandler := v1.NewCountriesHandler(container.CountryAppService, countryRepo, names, container.Logger)
+	regionsHandler := v1.NewRegionsHandler(regionRepo, countryRepo, names)
+	languagesHandler := v1.NewLanguagesHandler(languageRepo, countryLanguageRepo, names)
+	timezonesHandler := v1.NewTimezonesHandler(timezoneRepo, applicationservices.NewTimezoneAppService(container.Tracer))
+	subdivisionsHandler := v1.NewSubdivisionsHandler(subdivisionRepo, countryRepo, names)
+	cldr, err := i18n.DefaultCLDR()
+	if err != nil {
+		log.Fatalf("Failed to load CLDR data: %v", err)
+	}
+	localesHandler := v1.NewLocalesHandler(localeRepo, currencyRepo, applicationservices.NewLocaleFormatAppService(cldr, container.Tracer))
+	currenciesHandler := v1.NewCurrenciesHandler(currencyRepo, countryCurrencyRepo)
+	countryCurrenciesHandler := v1.NewCountryCurrenciesHandler(countryCurrencyRepo, countryRepo, currencyRepo)
+	countryLanguagesHandler := v1.NewCountryLanguagesHandler(countryLanguageRepo, countryRepo, languageRepo)
+	countryTimezonesHandler := v1.NewCountryTimezonesHandler(countryTimezoneRepo, countryRepo, timezoneRepo, subdivisionRepo)
+	phoneNumbersHandler := v1.NewPhoneNumbersHandler(applicationservices.NewPhoneNumberAppService(phonePlanRepo, container.Tracer))
+	countryPhonePlanHandler := v1.NewCountryPhonePlanHandler(phonePlanRepo, countryRepo)
+	postalCodeService := applicationservices.NewPostalCodeAppService(postalCodeFormatRepo, container.Tracer)
+	countryPostalCodesHandler := v1.NewCountryPostalCodesHandler(postalCodeFormatRepo, countryRepo, subdivisionRepo, postalCodeService)
+	countryAddressFormatHandler := v1.NewCountryAddressFormatHandler(addressFormatRepo, countryRepo)
+	addressesHandler := v1.NewAddressesHandler(countryRepo,
+		applicationservices.NewAddressAppService(addressFormatRepo, subdivisionRepo, postalCodeService, container.Tracer))
+	geoIndex, err := geo.DefaultIndex()
+	if err != nil {
+		log.Fatalf("Failed to load boundary data: %v", err)
+	}
+	geoHandler := v1.NewGeoHandler(applicationservices.NewGeoAppService(geoIndex, countryRepo, subdivisionRepo, container.Tracer))
+	countryBordersHandler := v1.NewCountryBordersHandler(countryBorderRepo, countryRepo,
+		applicationservices.NewBorderAppService(countryBorderRepo, container.Tracer))
+	countryExternalCodesHandler := v1.NewCountryExternalCodesHandler(countryExternalCodeRepo, countryRepo)
+	crosswalkHandler := v1.NewCrosswalkHandler(applicationservices.NewCrosswalkAppService(countryExternalCodeRepo, container.Tracer))
+	nameTranslationsHandler := v1.NewNameTranslationsHandler(nameTranslationRepo)
+	groupingsHandler := v1.NewGroupingsHandler(groupingRepo, groupingMembershipRepo, countryRepo)
+	searchHandler := v2.NewSearchHandler(applicationservices.NewSearchAppService(
+		repositories.NewSearchRepository(container.DBManager.DB), logger, container.Tracer))
+	historyHandler := v2.NewHistoryHandler(repositories.NewHistoryRepository(container.DBManager.DB))
+	lifecycleHandler := v2.NewLifecycleHandler("admin", "data-steward")
+	v2.AddLifecycle(lifecycleHandler, "countries", countryRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "regions", regionRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "languages", languageRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "timezones", timezoneRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "subdivisions", subdivisionRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "locales", localeRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "currencies", currencyRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "country-currencies", countryCurrencyRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "country-languages", countryLanguageRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "country-timezones", countryTimezoneRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "phone-plans", phonePlanRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "postal-code-formats", postalCodeFormatRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "address-formats", addressFormatRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "country-borders", countryBorderRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "country-external-codes", countryExternalCodeRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "name-translations", nameTranslationRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "groupings", groupingRepo.Repository)
+	v2.AddLifecycle(lifecycleHandler, "grouping-memberships", groupingMembershipRepo.Repository)
+
+	// Retention of audit data and deleted rows
+	retentionService := applicationservices.NewRetentionAppService(
+		repositories.NewRetentionRepository(container.DBManager.DB), logger, container.Tracer,
+		countryRepo.RetentionTarget(),
+		regionRepo.RetentionTarget(),
+		languageRepo.RetentionTarget(),
+		timezoneRepo.RetentionTarget(),
+		subdivisionRepo.RetentionTarget(),
+		localeRepo.RetentionTarget(),
+		currencyRepo.RetentionTarget(),
+		countryCurrencyRepo.RetentionTarget(),
+		countryLanguageRepo.RetentionTarget(),
+		countryTimezoneRepo.RetentionTarget(),
+		phonePlanRepo.RetentionTarget(),
+		postalCodeFormatRepo.RetentionTarget(),
+		addressFormatRepo.RetentionTarget(),
+		countryBorderRepo.RetentionTarget(),
+		countryExternalCodeRepo.RetentionTarget(),
+		nameTranslationRepo.RetentionTarget(),
+		groupingRepo.RetentionTarget(),
+		groupingMembershipRepo.RetentionTarget(),
+	)
+	retentionHandler := v2.NewRetentionHandler(retentionService)
+
+	// ISO 3166 bulk import, also run as "server import -file ..."
+	importService := applicationservices.NewImportAppService(
+		repositories.NewImportRepository(container.DBManager.DB), countryRepo, subdivisionRepo, logger, container.Tracer)
+	importHandler := v2.NewImportHandler(importService, "admin")
+
+	// Streaming exports and the full extract, also run as "server export ..."
+	exportService := applicationservices.NewExportAppService(logger, container.Tracer,
+		countryRepo.ExportSource("countries"),
+		regionRepo.ExportSource("regions"),
+		languageRepo.ExportSource("languages"),
+		timezoneRepo.ExportSource("timezones"),
+		subdivisionRepo.ExportSource("subdivisions"),
+		localeRepo.ExportSource("locales"),
+		currencyRepo.ExportSource("currencies"),
+		countryCurrencyRepo.ExportSource("country-currencies"),
+		countryLanguageRepo.ExportSource("country-languages"),
+		countryTimezoneRepo.ExportSource("country-timezones"),
+		phonePlanRepo.ExportSource("phone-plans"),
+		postalCodeFormatRepo.ExportSource("postal-code-formats"),
+		addressFormatRepo.ExportSource("address-formats"),
+		countryBorderRepo.ExportSource("country-borders"),
+		countryExternalCodeRepo.ExportSource("country-external-codes"),
+		nameTranslationRepo.ExportSource("name-translations"),
+		groupingRepo.ExportSource("groupings"),
+		groupingMembershipRepo.ExportSource("grouping-memberships"),
+		countryRepo.ProfileExportSource("country-profiles"),
+	)
+	exportHandler := v2.NewExportHandler(exportService)
+
+	if len(os.Args) > 1 {
+		var code int
+		switch os.Args[1] {
+		case "import":
+			code = cli.RunImport(ctx, importService, os.Args[2:], os.Stdout)
+		case "export":
+			code = cli.RunExport(ctx, exportService, os.Args[2:], os.Stdout)
+		default:
+			log.Fatalf("Unknown command %q, expected import or export", os.Args[1])
+		}
+		container.Close()
+		os.Exit(code)
+	}
 
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
+	// IANA zone names contain a slash; clients escape it as %2F, e.g.
+	// /api/v1/timezones/America%2FNew_York
+	router.UseRawPath = true
 	
 	// Global middleware
 	router.Use(gin.Logger())
@@ -80,7 +218,8 @@ func main() {
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Tenant-ID")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Tenant-ID, X-Change-Reason, X-Session-ID, If-Match")
+		c.Header("Access-Control-Expose-Headers", "ETag")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
 			return
@@ -97,6 +236,14 @@ func main() {
 		c.Set("tenant_id", tenantID)
 		c.Next()
 	})
+	
+	// Change reason, caller and request origin recorded in history and audit
+	// columns; the caller comes from a bearer token signed with JWT_SECRET
+	jwtSecret := os.Getenv("JWT_SECRET")
+	router.Use(middleware.AuditContextMiddleware(jwtSecret))
+
+	// Writes must name the version they replace
+	router.Use(middleware.RequireIfMatchMiddleware())
 
 	// Health endpoints
 	router.GET("/health", func(c *gin.Context) {
@@ -125,6 +272,45 @@ func main() {
 			countries.GET("/:code", countriesHandler.GetCountryByCode)
 			countries.PUT("/:code", countriesHandler.UpdateCountry)
 			countries.DELETE("/:code", countriesHandler.DeleteCountry)
+			countries.GET("/:code/periods", countriesHandler.Periods)
+			countries.POST("/:code/periods", countriesHandler.RecordPeriod)
+			countries.GET("/:code/subdivisions", subdivisionsHandler.ByCountryCode)
+			countries.GET("/:code/currencies", countryCurrenciesHandler.List)
+			countries.POST("/:code/currencies", countryCurrenciesHandler.Create)
+			countries.PUT("/:code/currencies/:id", countryCurrenciesHandler.Update)
+			countries.DELETE("/:code/currencies/:id", countryCurrenciesHandler.Delete)
+			countries.GET("/:code/languages", countryLanguagesHandler.List)
+			countries.POST("/:code/languages", countryLanguagesHandler.Create)
+			countries.PUT("/:code/languages/:id", countryLanguagesHandler.Update)
+			countries.DELETE("/:code/languages/:id", countryLanguagesHandler.Delete)
+			countries.GET("/:code/timezones", countryTimezonesHandler.List)
+			countries.POST("/:code/timezones", countryTimezonesHandler.Create)
+			countries.DELETE("/:code/timezones/:id", countryTimezonesHandler.Delete)
+			countries.GET("/:code/phone-plan", countryPhonePlanHandler.Get)
+			countries.POST("/:code/phone-plan", countryPhonePlanHandler.Create)
+			countries.PUT("/:code/phone-plan", countryPhonePlanHandler.Update)
+			countries.DELETE("/:code/phone-plan", countryPhonePlanHandler.Delete)
+			countries.GET("/:code/postal-code-formats", countryPostalCodesHandler.List)
+			countries.POST("/:code/postal-code-formats", countryPostalCodesHandler.Create)
+			countries.PUT("/:code/postal-code-formats/:id", countryPostalCodesHandler.Update)
+			countries.DELETE("/:code/postal-code-formats/:id", countryPostalCodesHandler.Delete)
+			// POST /countries/{code}/postal-codes:validate
+			countries.POST("/:code/postal-codes:action", countryPostalCodesHandler.Action)
+			countries.GET("/:code/address-format", countryAddressFormatHandler.Get)
+			countries.POST("/:code/address-format", countryAddressFormatHandler.Create)
+			countries.PUT("/:code/address-format", countryAddressFormatHandler.Update)
+			countries.DELETE("/:code/address-format", countryAddressFormatHandler.Delete)
+			countries.GET("/:code/neighbors", countryBordersHandler.Neighbors)
+			countries.GET("/:code/reachable", countryBordersHandler.Reachable)
+			countries.GET("/:code/land-route", countryBordersHandler.LandRoute)
+			countries.POST("/:code/borders", countryBordersHandler.Create)
+			countries.PUT("/:code/borders/:id", countryBordersHandler.Update)
+			countries.DELETE("/:code/borders/:id", countryBordersHandler.Delete)
+			countries.GET("/:code/external-codes", countryExternalCodesHandler.List)
+			countries.POST("/:code/external-codes", countryExternalCodesHandler.Create)
+			countries.PUT("/:code/external-codes/:id", countryExternalCodesHandler.Update)
+			countries.DELETE("/:code/external-codes/:id", countryExternalCodesHandler.Delete)
+			countries.GET("/:code/memberships", groupingsHandler.CountryMemberships)
 		}
 
 		// Regions CRUD
@@ -135,7 +321,13 @@ func main() {
 			regions.GET("/:code", regionsHandler.GetByCode)
 			regions.PUT("/:code", regionsHandler.Update)
 			regions.DELETE("/:code", regionsHandler.Delete)
+			regions.GET("/:code/children", regionsHandler.Children)
+			regions.GET("/:code/descendants", regionsHandler.Descendants)
+			regions.GET("/:code/ancestors", regionsHandler.Ancestors)
+			regions.GET("/:code/countries", regionsHandler.Countries)
 		}
+		// GET /regions:tree
+		v1Group.GET("/regions:action", regionsHandler.Action)
 
 		// Languages CRUD
 		languages := v1Group.Group("/languages")
@@ -145,6 +337,7 @@ func main() {
 			languages.GET("/:code", languagesHandler.GetByCode)
 			languages.PUT("/:code", languagesHandler.Update)
 			languages.DELETE("/:code", languagesHandler.Delete)
+			languages.GET("/:code/countries", languagesHandler.Countries)
 		}
 
 		// Timezones CRUD
@@ -156,6 +349,22 @@ func main() {
 			timezones.PUT("/:code", timezonesHandler.Update)
 			timezones.DELETE("/:code", timezonesHandler.Delete)
 		}
+		// GET /timezones:resolve
+		v1Group.GET("/timezones:action", timezonesHandler.Action)
+
+		// POST /phone-numbers:validate and /phone-numbers:normalize
+		v1Group.POST("/phone-numbers:action", phoneNumbersHandler.Action)
+		v1Group.GET("/calling-codes/:code/countries", phoneNumbersHandler.CallingCodeCountries)
+
+		// POST /addresses:render
+		v1Group.POST("/addresses:action", addressesHandler.Action)
+
+		v1Group.GET("/geo/lookup", geoHandler.Lookup)
+		v1Group.GET("/geo/boundaries/:code", geoHandler.Boundary)
+
+		v1Group.GET("/crosswalk", crosswalkHandler.Get)
+		// POST /crosswalk:translate
+		v1Group.POST("/crosswalk:action", crosswalkHandler.Action)
 
 		// Subdivisions CRUD
 		subdivisions := v1Group.Group("/subdivisions")
@@ -165,6 +374,10 @@ func main() {
 			subdivisions.GET("/country/:countryId", subdivisionsHandler.GetByCountry)
 			subdivisions.PUT("/:id", subdivisionsHandler.Update)
 			subdivisions.DELETE("/:id", subdivisionsHandler.Delete)
+			subdivisions.GET("/:id/descendants", subdivisionsHandler.Descendants)
+			subdivisions.GET("/:id/ancestors", subdivisionsHandler.Ancestors)
+			subdivisions.GET("/:id/periods", subdivisionsHandler.Periods)
+			subdivisions.POST("/:id/periods", subdivisionsHandler.RecordPeriod)
 		}
 
 		// Locales CRUD
@@ -175,9 +388,64 @@ func main() {
 			locales.GET("/:code", localesHandler.GetByCode)
 			locales.PUT("/:code", localesHandler.Update)
 			locales.DELETE("/:code", localesHandler.Delete)
+			locales.GET("/:code/formats", localesHandler.Formats)
+			locales.GET("/:code/format", localesHandler.Format)
+		}
+
+		// Currencies CRUD
+		currencies := v1Group.Group("/currencies")
+		{
+			currencies.GET("", currenciesHandler.GetAll)
+			currencies.POST("", currenciesHandler.Create)
+			currencies.GET("/:code", currenciesHandler.GetByCode)
+			currencies.PUT("/:code", currenciesHandler.Update)
+			currencies.DELETE("/:code", currenciesHandler.Delete)
+			currencies.GET("/:code/countries", currenciesHandler.Countries)
+		}
+
+		// Names of countries, regions, languages and subdivisions in other
+		// locales, served by the read endpoints above per Accept-Language
+		nameTranslations := v1Group.Group("/name-translations")
+		{
+			nameTranslations.GET("", nameTranslationsHandler.GetAll)
+			nameTranslations.POST("", nameTranslationsHandler.Create)
+			nameTranslations.GET("/:id", nameTranslationsHandler.GetByID)
+			nameTranslations.PUT("/:id", nameTranslationsHandler.Update)
+			nameTranslations.DELETE("/:id", nameTranslationsHandler.Delete)
+		}
+
+		// Groupings of countries such as the EU, Schengen or OECD, with
+		// dated memberships
+		groupings := v1Group.Group("/groupings")
+		{
+			groupings.GET("", groupingsHandler.GetAll)
+			groupings.POST("", groupingsHandler.Create)
+			groupings.GET("/:code", groupingsHandler.GetByCode)
+			groupings.PUT("/:code", groupingsHandler.Update)
+			groupings.DELETE("/:code", groupingsHandler.Delete)
+			groupings.GET("/:code/members", groupingsHandler.Members)
+			groupings.POST("/:code/members", groupingsHandler.AddMember)
+			groupings.PUT("/:code/members/:id", groupingsHandler.UpdateMember)
+			groupings.DELETE("/:code/members/:id", groupingsHandler.RemoveMember)
 		}
 	}
 
+	// API v2 routes - fuzzy search, entity change history, restore and purge
+	v2Group := router.Group("/api/v2")
+	v2Group.GET("/search", searchHandler.Search)
+	historyHandler.RegisterRoutes(v2Group)
+
+	// Purge and imports need the role claim of a JWT; without JWT_SECRET they
+	// stay forbidden
+	lifecycleGroup := v2Group.Group("")
+	if jwtSecret != "" {
+		lifecycleGroup.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	}
+	lifecycleHandler.RegisterRoutes(lifecycleGroup)
+	importHandler.RegisterRoutes(lifecycleGroup)
+	v2Group.GET("/retention/reports", retentionHandler.Reports)
+	exportHandler.RegisterRoutes(v2Group)
+
 	// Start server
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
@@ -190,6 +458,21 @@ func main() {
 		}
 	}()
 
+	// Scheduled retention job and full extract
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	go retentionService.Schedule(jobsCtx, getEnvDuration("RETENTION_INTERVAL", 24*time.Hour))
+
+	// Full extract for the data warehouse, at startup and every EXPORT_INTERVAL
+	if dir := os.Getenv("EXPORT_DIR"); dir != "" {
+		extract := applicationservices.ExtractConfig{
+			Dir:     dir,
+			Format:  getEnv("EXPORT_FORMAT", "csv"),
+			Tenants: strings.Split(getEnv("EXPORT_TENANTS", "default-tenant"), ","),
+		}
+		go exportService.Schedule(jobsCtx, extract, getEnvDuration("EXPORT_INTERVAL", 24*time.Hour))
+	}
+
 	logger.Info(ctx, "🚀 Complete CRUD API Server started",
 		logging.Field{Key: "port", Value: cfg.Server.Port},
 		logging.Field{Key: "endpoints", Value: "countries, regions, languages, timezones, subdivisions, locales"})
@@ -224,4 +507,13 @@ func getEnvInt(key string, defaultValue int) int {
 		}
 	}
 	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil && duration > 0 {
+			return duration
+		}
+	}
+	return defaultValue
 }
\ No newline at end of file
@@ -15,8 +15,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/bootstrap"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/config"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/query"
+	v2handlers "github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/v2"
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
 	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
 )
 
@@ -61,6 +66,27 @@ func main() {
 	}
 	defer container.Close()
 
+	// Generic repository backing paginated country listings
+	countryRepo := repositories.NewCountryRepository(container.DBManager.DB)
+
+	// Fuzzy search across countries, subdivisions and languages
+	searchService := applicationservices.NewSearchAppService(
+		repositories.NewSearchRepository(container.DBManager.DB), logger, container.Tracer)
+	searchHandler := v2handlers.NewSearchHandler(searchService)
+	
+	// Entity change history
+	historyHandler := v2handlers.NewHistoryHandler(repositories.NewHistoryRepository(container.DBManager.DB))
+
+	// Restore and purge of soft-deleted countries
+	lifecycleHandler := v2handlers.NewLifecycleHandler("admin", "data-steward")
+	v2handlers.AddLifecycle(lifecycleHandler, "countries", countryRepo.Repository)
+
+	// Retention of country audit data and deleted rows
+	retentionService := applicationservices.NewRetentionAppService(
+		repositories.NewRetentionRepository(container.DBManager.DB), logger, container.Tracer,
+		countryRepo.RetentionTarget())
+	retentionHandler := v2handlers.NewRetentionHandler(retentionService)
+
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
@@ -77,7 +103,8 @@ func main() {
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Tenant-ID")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Tenant-ID, X-Change-Reason, X-Session-ID, If-Match")
+		c.Header("Access-Control-Expose-Headers", "ETag")
 		
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -96,6 +123,14 @@ func main() {
 		c.Set("tenant_id", tenantID)
 		c.Next()
 	})
+	
+	// Change reason, caller and request origin recorded in history and audit
+	// columns; the caller comes from a bearer token signed with JWT_SECRET
+	jwtSecret := os.Getenv("JWT_SECRET")
+	router.Use(middleware.AuditContextMiddleware(jwtSecret))
+
+	// Writes must name the version they replace
+	router.Use(middleware.RequireIfMatchMiddleware())
 
 	// Health check endpoints
 	router.GET("/health", func(c *gin.Context) {
@@ -141,19 +176,35 @@ func main() {
 			countries.GET("", func(c *gin.Context) {
 				tenantID := c.GetString("tenant_id")
 				
-				countries, err := container.CountryAppService.GetAllCountries(c.Request.Context(), tenantID)
+				opts, verr := query.ListOptions(c.Request.URL.Query(), countryRepo.HasColumn)
+				if verr != nil {
+					c.JSON(verr.HTTPStatus(), gin.H{"error": verr.Error(), "code": verr.Code})
+					return
+				}
+				
+				page, err := countryRepo.List(c.Request.Context(), tenantID, opts)
 				if err != nil {
 					logger.Error(c.Request.Context(), "Failed to get countries", err)
 					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve countries"})
 					return
 				}
 				
-				c.JSON(http.StatusOK, gin.H{
-					"countries": countries,
-					"count":     len(countries),
+				response := gin.H{
+					"countries": page.Items,
+					"count":     len(page.Items),
+					"limit":     page.Limit,
+					"has_more":  page.HasMore,
+					"links":     query.Links(c.Request.URL, page),
 					"schema":    "domain_reference_master_geopolitical",
 					"version":   "2.0.0-aligned",
-				})
+				}
+				if page.Total != nil {
+					response["total"] = *page.Total
+				}
+				if opts.AsOf != nil {
+					response["as_of"] = opts.AsOf.Format(repositories.DateLayout)
+				}
+				c.JSON(http.StatusOK, response)
 			})
 			
 			countries.POST("", func(c *gin.Context) {
@@ -182,6 +233,31 @@ func main() {
 				tenantID := c.GetString("tenant_id")
 				code := c.Param("code")
 				
+				// Historical lookup: the country as it was on the as_of date
+				if raw := c.Query("as_of"); raw != "" {
+					asOf, verr := query.ParseAsOf(raw)
+					if verr != nil {
+						c.JSON(verr.HTTPStatus(), gin.H{"error": verr.Error(), "code": verr.Code})
+						return
+					}
+					country, err := countryRepo.GetByCodeAsOf(c.Request.Context(), tenantID, code, asOf)
+					if err != nil {
+						logger.Error(c.Request.Context(), "Failed to get country as of date", err)
+						c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve country"})
+						return
+					}
+					if country == nil {
+						c.JSON(http.StatusNotFound, gin.H{"error": "Country not found on " + asOf.Format(repositories.DateLayout)})
+						return
+					}
+					c.JSON(http.StatusOK, gin.H{
+						"country": country,
+						"as_of":   asOf.Format(repositories.DateLayout),
+						"schema":  "domain_reference_master_geopolitical",
+					})
+					return
+				}
+				
 				country, err := container.CountryAppService.GetCountryByCode(c.Request.Context(), tenantID, code)
 				if err != nil {
 					logger.Error(c.Request.Context(), "Failed to get country by code", err)
@@ -189,15 +265,52 @@ func main() {
 					return
 				}
 				
+				middleware.SetETag(c, country.Version)
 				c.JSON(http.StatusOK, gin.H{
 					"country": country,
 					"schema":  "domain_reference_master_geopolitical",
 				})
 			})
 			
+			countries.GET("/:code/periods", func(c *gin.Context) {
+				tenantID := c.GetString("tenant_id")
+				code := c.Param("code")
+				
+				country, err := countryRepo.GetByCode(c.Request.Context(), tenantID, code)
+				if err != nil {
+					logger.Error(c.Request.Context(), "Failed to get country by code", err)
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve country"})
+					return
+				}
+				if country == nil {
+					c.JSON(http.StatusNotFound, gin.H{"error": "Country not found"})
+					return
+				}
+				
+				periods, err := countryRepo.Periods(c.Request.Context(), tenantID, country.CountryID)
+				if err != nil {
+					logger.Error(c.Request.Context(), "Failed to get country periods", err)
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve periods"})
+					return
+				}
+				
+				c.JSON(http.StatusOK, gin.H{
+					"country_code": country.CountryCode,
+					"valid_from":   country.ValidFrom,
+					"valid_to":     country.ValidTo,
+					"periods":      periods,
+					"count":        len(periods),
+				})
+			})
+			
 			countries.PUT("/:code", func(c *gin.Context) {
 				tenantID := c.GetString("tenant_id")
 				code := c.Param("code")
+				version, verr := middleware.IfMatchVersion(c)
+				if verr != nil {
+					c.JSON(verr.HTTPStatus(), gin.H{"error": verr.Error(), "code": verr.Code})
+					return
+				}
 				
 				// Get existing country
 				country, err := container.CountryAppService.GetCountryByCode(c.Request.Context(), tenantID, code)
@@ -222,13 +335,22 @@ func main() {
 				country.ContinentCode = updateData.ContinentCode
 				country.PhonePrefix = updateData.PhonePrefix
 				country.IsActive = updateData.IsActive
+				country.ValidFrom = updateData.ValidFrom
+				country.ValidTo = updateData.ValidTo
+				country.RetentionPolicy = updateData.RetentionPolicy
+				country.Version = version
 				
 				if err := container.CountryAppService.UpdateCountry(c.Request.Context(), tenantID, country); err != nil {
 					logger.Error(c.Request.Context(), "Failed to update country", err)
+					if layerErr, ok := err.(*errors.LayerError); ok {
+						c.JSON(layerErr.HTTPStatus(), gin.H{"error": layerErr.Error(), "code": layerErr.Code})
+						return
+					}
 					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 					return
 				}
 				
+				middleware.SetETag(c, country.Version)
 				c.JSON(http.StatusOK, gin.H{
 					"message": "Country updated successfully",
 					"country": country,
@@ -239,9 +361,18 @@ func main() {
 			countries.DELETE("/:code", func(c *gin.Context) {
 				tenantID := c.GetString("tenant_id")
 				code := c.Param("code")
+				version, verr := middleware.IfMatchVersion(c)
+				if verr != nil {
+					c.JSON(verr.HTTPStatus(), gin.H{"error": verr.Error(), "code": verr.Code})
+					return
+				}
 				
-				if err := container.CountryAppService.DeleteCountry(c.Request.Context(), tenantID, code); err != nil {
+				if err := container.CountryAppService.DeleteCountry(c.Request.Context(), tenantID, code, version); err != nil {
 					logger.Error(c.Request.Context(), "Failed to delete country", err)
+					if layerErr, ok := err.(*errors.LayerError); ok {
+						c.JSON(layerErr.HTTPStatus(), gin.H{"error": layerErr.Error(), "code": layerErr.Code})
+						return
+					}
 					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 					return
 				}
@@ -254,6 +385,20 @@ func main() {
 			})
 		}
 		
+		// Multilingual fuzzy search
+		v2.GET("/search", searchHandler.Search)
+		
+		// Change history of every reference entity
+		historyHandler.RegisterRoutes(v2)
+		
+		// POST /countries/{code}:restore and :purge; purge needs a JWT role claim
+		lifecycleGroup := v2.Group("")
+		if jwtSecret != "" {
+			lifecycleGroup.Use(middleware.JWTAuthMiddleware(jwtSecret))
+		}
+		lifecycleHandler.RegisterRoutes(lifecycleGroup)
+		v2.GET("/retention/reports", retentionHandler.Reports)
+		
 		// Schema info endpoint
 		v2.GET("/schema", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{
@@ -282,6 +427,11 @@ func main() {
 		}
 	}()
 
+	// Scheduled retention job
+	retentionCtx, stopRetention := context.WithCancel(ctx)
+	defer stopRetention()
+	go retentionService.Schedule(retentionCtx, getEnvDuration("RETENTION_INTERVAL", 24*time.Hour))
+
 	logger.Info(ctx, "Aligned server started successfully",
 		logging.Field{Key: "port", Value: cfg.Server.Port},
 		logging.Field{Key: "schema", Value: "domain_reference_master_geopolitical"},
@@ -321,6 +471,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil && duration > 0 {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
 func nullStringToString(ns sql.NullString) interface{} {
 	if ns.Valid {
 		return ns.String
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/bootstrap"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/config"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
@@ -147,6 +148,8 @@ func main() {
 		// Countries endpoints
 		countriesHandler := countriesHandler.NewCountriesHandler(
 			container.CountryAppService,
+			repositories.NewCountryRepository(container.DBManager.DB),
+			container.LocalizationAppService,
 			container.Logger,
 		)
 		
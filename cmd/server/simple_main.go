@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/bootstrap"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/config"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
@@ -84,7 +85,8 @@ func main() {
 
 	v1Group := router.Group("/api/v1")
 	{
-		countriesHandler := v1.NewCountriesHandler(container.CountryAppService, container.Logger)
+		countryRepo := repositories.NewCountryRepository(container.DBManager.DB)
+		countriesHandler := v1.NewCountriesHandler(container.CountryAppService, countryRepo, container.LocalizationAppService, container.Logger)
 		
 		countries := v1Group.Group("/countries")
 		{
@@ -98,5 +98,15 @@ func GetOperationCatalogue() map[string]OperationMetadata {
 			CacheEnabled: true,
 			CacheTTL:     6 * time.Hour,
 		},
+		"Countries.Search": {
+			Name:        "Search Countries",
+			Description: "Accent-insensitive prefix and trigram search over country and official names",
+			Parameters: []Parameter{
+				{Name: "query", Type: "string", Required: true, Description: "Search text, at least 2 characters"},
+			},
+			ReturnType:   "[]SearchHit",
+			ErrorCodes:   []string{"GEO-9001"},
+			CacheEnabled: false,
+		},
 	}
 }
\ No newline at end of file
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+)
+
+// auditSuffixes are the request attributes stored per audit event, e.g.
+// created_by, created_ip, created_device, created_session, created_location
+var auditSuffixes = []string{"by", "ip", "device", "session", "location"}
+
+// RegisterAuditCallbacks stamps the created_*, updated_* and deleted_* audit
+// columns from the request captured in the statement context. Models without
+// those columns are left untouched.
+func RegisterAuditCallbacks(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("audit:stamp_create", stampCreate); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("audit:stamp_update", stampUpdate)
+}
+
+func stampCreate(db *gorm.DB) {
+	if db.Statement.Schema == nil {
+		return
+	}
+	request := audit.Request(db.Statement.Context)
+	stampColumns(db, "created", request, true)
+	stampColumns(db, "updated", request, true)
+}
+
+// stampUpdate skips UpdateColumn calls, which by contract leave the audit
+// columns alone. A soft delete also stamps the deleted_* columns.
+func stampUpdate(db *gorm.DB) {
+	if db.Statement.Schema == nil || db.Statement.SkipHooks {
+		return
+	}
+	request := audit.Request(db.Statement.Context)
+	stampColumns(db, "updated", request, false)
+	if softDeleting(db.Statement) {
+		stampColumns(db, "deleted", request, false)
+	}
+}
+
+// stampColumns sets the audit columns of one event. With keepSet, values the
+// caller already set on a single row are preserved.
+func stampColumns(db *gorm.DB, event string, request audit.RequestContext, keepSet bool) {
+	for _, suffix := range auditSuffixes {
+		field := db.Statement.Schema.LookUpField(event + "_" + suffix)
+		if field == nil {
+			continue
+		}
+		if keepSet && db.Statement.ReflectValue.Kind() == reflect.Struct {
+			if _, zero := field.ValueOf(db.Statement.Context, db.Statement.ReflectValue); !zero {
+				continue
+			}
+		}
+		if value := auditValue(field, suffix, request); value != nil {
+			db.Statement.SetColumn(field.DBName, value, true)
+		}
+	}
+}
+
+// softDeleting reports whether an update sets is_deleted
+func softDeleting(stmt *gorm.Statement) bool {
+	if dest, ok := stmt.Dest.(map[string]interface{}); ok {
+		deleted, _ := dest["is_deleted"].(bool)
+		return deleted
+	}
+
+	field := stmt.Schema.LookUpField("is_deleted")
+	dest := reflect.Indirect(reflect.ValueOf(stmt.Dest))
+	if field == nil || dest.Kind() != reflect.Struct || dest.Type() != stmt.Schema.ModelType {
+		return false
+	}
+	deleted, _ := field.ValueOf(stmt.Context, dest)
+	return deleted == true
+}
+
+// auditValue converts a request attribute to the Go type of field, or returns
+// nil when the attribute is unknown or cannot be stored in that type
+func auditValue(field *schema.Field, suffix string, request audit.RequestContext) interface{} {
+	switch suffix {
+	case "by":
+		return identifierValue(field.FieldType, request.UserID)
+	case "session":
+		return identifierValue(field.FieldType, request.SessionID)
+	case "ip":
+		if request.IP == nil {
+			return nil
+		}
+		switch field.FieldType {
+		case reflect.TypeOf(net.IP{}):
+			return request.IP
+		case reflect.TypeOf(&net.IP{}):
+			return &request.IP
+		case reflect.TypeOf(""):
+			return request.IP.String()
+		}
+	case "device":
+		if request.Device != nil {
+			return jsonValue(field.FieldType, request.Device)
+		}
+	case "location":
+		if request.Location != nil {
+			return jsonValue(field.FieldType, request.Location)
+		}
+	}
+	return nil
+}
+
+// identifierValue stores user and session IDs; UUID columns only accept IDs
+// that parse as UUIDs
+func identifierValue(fieldType reflect.Type, id string) interface{} {
+	if id == "" {
+		return nil
+	}
+	switch fieldType {
+	case reflect.TypeOf(""):
+		return id
+	case reflect.TypeOf(new(string)):
+		return &id
+	}
+
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	switch fieldType {
+	case reflect.TypeOf(uuid.UUID{}):
+		return parsed
+	case reflect.TypeOf(&uuid.UUID{}):
+		return &parsed
+	}
+	return nil
+}
+
+func jsonValue(fieldType reflect.Type, value interface{}) interface{} {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	raw := json.RawMessage(encoded)
+	switch fieldType {
+	case reflect.TypeOf(json.RawMessage{}):
+		return raw
+	case reflect.TypeOf(&json.RawMessage{}):
+		return &raw
+	case reflect.TypeOf(""):
+		return string(encoded)
+	}
+	return nil
+}
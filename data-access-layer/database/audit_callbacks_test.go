@@ -0,0 +1,78 @@
+package database
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+)
+
+func TestIdentifierValue(t *testing.T) {
+	id := uuid.MustParse("0b6c1c1e-8a55-4c59-9b8a-3f2d4c0e7a11")
+	text := id.String()
+
+	tests := []struct {
+		name      string
+		fieldType reflect.Type
+		id        string
+		want      interface{}
+	}{
+		{"empty id", reflect.TypeOf(""), "", nil},
+		{"string column keeps any id", reflect.TypeOf(""), "service-account", "service-account"},
+		{"string pointer column", reflect.TypeOf(new(string)), text, &text},
+		{"uuid column", reflect.TypeOf(uuid.UUID{}), text, id},
+		{"uuid pointer column", reflect.TypeOf(&uuid.UUID{}), text, &id},
+		{"uuid column rejects other ids", reflect.TypeOf(&uuid.UUID{}), "service-account", nil},
+		{"unsupported column", reflect.TypeOf(0), text, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identifierValue(tt.fieldType, tt.id); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("identifierValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditValue(t *testing.T) {
+	ip := net.ParseIP("203.0.113.7")
+	request := audit.RequestContext{
+		UserID:   "0b6c1c1e-8a55-4c59-9b8a-3f2d4c0e7a11",
+		IP:       ip,
+		Device:   &audit.Device{UserAgent: "curl/8.4.0", Type: "api-client"},
+		Location: &audit.Location{CountryCode: "DE"},
+	}
+	device := json.RawMessage(`{"user_agent":"curl/8.4.0","type":"api-client"}`)
+	location := json.RawMessage(`{"country_code":"DE"}`)
+
+	tests := []struct {
+		name      string
+		fieldType reflect.Type
+		suffix    string
+		request   audit.RequestContext
+		want      interface{}
+	}{
+		{"ip pointer", reflect.TypeOf(&net.IP{}), "ip", request, &ip},
+		{"ip as text", reflect.TypeOf(""), "ip", request, "203.0.113.7"},
+		{"no ip", reflect.TypeOf(&net.IP{}), "ip", audit.RequestContext{}, nil},
+		{"device as json", reflect.TypeOf(&json.RawMessage{}), "device", request, &device},
+		{"location as text", reflect.TypeOf(""), "location", request, string(location)},
+		{"no session", reflect.TypeOf(&uuid.UUID{}), "session", request, nil},
+		{"unknown suffix", reflect.TypeOf(""), "reason", request, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &schema.Field{FieldType: tt.fieldType}
+			if got := auditValue(field, tt.suffix, tt.request); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("auditValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
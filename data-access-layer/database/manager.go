@@ -24,6 +24,11 @@ func NewManager(cfg *config.DatabaseConfig) (*Manager, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Fill the LASANI audit columns from the request context
+	if err := RegisterAuditCallbacks(db); err != nil {
+		return nil, fmt.Errorf("failed to register audit callbacks: %w", err)
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -22,7 +22,7 @@ const (
 	ErrCountryNotFound     = "GEO-1001"
 	ErrCountryDuplicate    = "GEO-1002" 
 	ErrCountryInvalid      = "GEO-1003"
-	ErrCountryVersionConflict = "GEO-1004"
+	ErrCountryVersionConflict = "GEO-1009"
 	
 	// GEO-2xxx: Region errors
 	ErrRegionNotFound      = "GEO-2001"
@@ -0,0 +1,271 @@
+// Package exporters writes reference data exports as CSV, NDJSON or GeoJSON.
+//
+// Writers receive rows one at a time from an ExportSource and buffer at most
+// one row, so an export of any size streams in constant memory.
+//
+// GeoJSON features take their Point geometry from the latitude and longitude
+// columns; rows without both get a null geometry. All other columns become
+// feature properties.
+package exporters
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// Supported export formats
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatGeoJSON = "geojson"
+)
+
+// Coordinate columns used as GeoJSON geometry
+const (
+	LatitudeColumn  = "latitude"
+	LongitudeColumn = "longitude"
+)
+
+// Writer is a RowWriter for one output format. Close completes the document
+// and flushes it; it does not close the underlying writer.
+type Writer interface {
+	repositories.RowWriter
+	Close() error
+}
+
+// NewWriter returns a writer of the given format on w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	buffered := bufio.NewWriter(w)
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return &csvWriter{out: buffered, csv: csv.NewWriter(buffered)}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{out: buffered}, nil
+	case FormatGeoJSON:
+		return &geojsonWriter{out: buffered, lat: -1, lon: -1}, nil
+	}
+	return nil, errors.NewValidationError("format", fmt.Sprintf("unsupported export format %q, expected csv, ndjson or geojson", format))
+}
+
+// ContentType returns the media type of a format
+func ContentType(format string) string {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatGeoJSON:
+		return "application/geo+json"
+	}
+	return "application/x-ndjson"
+}
+
+// Extension returns the file extension of a format
+func Extension(format string) string {
+	return "." + strings.ToLower(format)
+}
+
+type csvWriter struct {
+	out    *bufio.Writer
+	csv    *csv.Writer
+	record []string
+}
+
+func (w *csvWriter) Columns(columns []string) error {
+	w.record = make([]string, len(columns))
+	return w.csv.Write(columns)
+}
+
+func (w *csvWriter) Row(values []interface{}) error {
+	for i, value := range values {
+		w.record[i] = csvValue(value)
+	}
+	return w.csv.Write(w.record)
+}
+
+func (w *csvWriter) Close() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	return w.out.Flush()
+}
+
+// csvValue formats a column value; NULL is the empty string
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case json.RawMessage:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
+
+type ndjsonWriter struct {
+	out     *bufio.Writer
+	columns [][]byte
+	line    bytes.Buffer
+}
+
+func (w *ndjsonWriter) Columns(columns []string) error {
+	keys, err := jsonKeys(columns)
+	w.columns = keys
+	return err
+}
+
+func (w *ndjsonWriter) Row(values []interface{}) error {
+	w.line.Reset()
+	if err := writeObject(&w.line, w.columns, values, nil); err != nil {
+		return err
+	}
+	w.line.WriteByte('\n')
+	_, err := w.out.Write(w.line.Bytes())
+	return err
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.out.Flush()
+}
+
+type geojsonWriter struct {
+	out      *bufio.Writer
+	columns  [][]byte
+	lat, lon int
+	skip     map[int]bool
+	feature  bytes.Buffer
+	features int
+}
+
+func (w *geojsonWriter) Columns(columns []string) error {
+	keys, err := jsonKeys(columns)
+	if err != nil {
+		return err
+	}
+	w.columns = keys
+	w.skip = make(map[int]bool, 2)
+	for i, column := range columns {
+		switch column {
+		case LatitudeColumn:
+			w.lat = i
+			w.skip[i] = true
+		case LongitudeColumn:
+			w.lon = i
+			w.skip[i] = true
+		}
+	}
+	_, err = w.out.WriteString(`{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+func (w *geojsonWriter) Row(values []interface{}) error {
+	w.feature.Reset()
+	if w.features > 0 {
+		w.feature.WriteByte(',')
+	}
+	w.feature.WriteString("\n" + `{"type":"Feature","geometry":`)
+	if lon, lat, ok := w.point(values); ok {
+		fmt.Fprintf(&w.feature, `{"type":"Point","coordinates":[%s,%s]}`,
+			strconv.FormatFloat(lon, 'f', -1, 64), strconv.FormatFloat(lat, 'f', -1, 64))
+	} else {
+		w.feature.WriteString("null")
+	}
+	w.feature.WriteString(`,"properties":`)
+	if err := writeObject(&w.feature, w.columns, values, w.skip); err != nil {
+		return err
+	}
+	w.feature.WriteByte('}')
+	w.features++
+	_, err := w.out.Write(w.feature.Bytes())
+	return err
+}
+
+// point returns the coordinates of a row
+func (w *geojsonWriter) point(values []interface{}) (lon, lat float64, ok bool) {
+	if w.lat < 0 || w.lon < 0 {
+		return 0, 0, false
+	}
+	lat, latOK := number(values[w.lat])
+	lon, lonOK := number(values[w.lon])
+	return lon, lat, latOK && lonOK
+}
+
+func (w *geojsonWriter) Close() error {
+	if w.columns == nil {
+		return w.out.Flush()
+	}
+	if _, err := w.out.WriteString("\n]}\n"); err != nil {
+		return err
+	}
+	return w.out.Flush()
+}
+
+// number converts a numeric column value to float64
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// jsonKeys encodes the column names once for all rows
+func jsonKeys(columns []string) ([][]byte, error) {
+	keys := make([][]byte, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// writeObject writes the values as a JSON object keyed by column, in column
+// order, leaving out the skipped columns
+func writeObject(buf *bytes.Buffer, keys [][]byte, values []interface{}, skip map[int]bool) error {
+	buf.WriteByte('{')
+	first := true
+	for i, value := range values {
+		if skip[i] {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(keys[i])
+		buf.WriteByte(':')
+		buf.Write(encoded)
+	}
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,122 @@
+package exporters
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWriters(t *testing.T) {
+	id := uuid.MustParse("5e4f0a4c-7a51-4f39-8a4d-0d1f4c2b9e11")
+	updated := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	columns := []string{"country_code", "country_name", "latitude", "longitude", "capital_city", "country_id", "updated_at"}
+	rows := [][]interface{}{
+		{"DE", "Germany", 51.1657, 10.4515, "Berlin", id, updated},
+		{"AQ", "Antarctica, the", nil, nil, nil, id, updated},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		columns []string
+		rows    [][]interface{}
+		want    string
+	}{
+		{
+			name:    "csv",
+			format:  FormatCSV,
+			columns: columns,
+			rows:    rows,
+			want: "country_code,country_name,latitude,longitude,capital_city,country_id,updated_at\n" +
+				"DE,Germany,51.1657,10.4515,Berlin,5e4f0a4c-7a51-4f39-8a4d-0d1f4c2b9e11,2024-03-01T09:30:00Z\n" +
+				"AQ,\"Antarctica, the\",,,,5e4f0a4c-7a51-4f39-8a4d-0d1f4c2b9e11,2024-03-01T09:30:00Z\n",
+		},
+		{
+			name:    "ndjson keeps column order and nulls",
+			format:  FormatNDJSON,
+			columns: []string{"country_code", "capital_city", "metadata"},
+			rows:    [][]interface{}{{"DE", "Berlin", json.RawMessage(`{"eu":true}`)}, {"AQ", nil, nil}},
+			want: `{"country_code":"DE","capital_city":"Berlin","metadata":{"eu":true}}` + "\n" +
+				`{"country_code":"AQ","capital_city":null,"metadata":null}` + "\n",
+		},
+		{
+			name:    "geojson points and null geometry",
+			format:  FormatGeoJSON,
+			columns: []string{"country_code", "latitude", "longitude"},
+			rows:    [][]interface{}{{"DE", 51.1657, "10.4515"}, {"AQ", nil, nil}},
+			want: `{"type":"FeatureCollection","features":[` + "\n" +
+				`{"type":"Feature","geometry":{"type":"Point","coordinates":[10.4515,51.1657]},"properties":{"country_code":"DE"}},` + "\n" +
+				`{"type":"Feature","geometry":null,"properties":{"country_code":"AQ"}}` + "\n]}\n",
+		},
+		{
+			name:    "geojson without coordinate columns",
+			format:  FormatGeoJSON,
+			columns: []string{"region_code"},
+			rows:    [][]interface{}{{"150"}},
+			want: `{"type":"FeatureCollection","features":[` + "\n" +
+				`{"type":"Feature","geometry":null,"properties":{"region_code":"150"}}` + "\n]}\n",
+		},
+		{
+			name:    "empty geojson collection",
+			format:  "GeoJSON",
+			columns: []string{"country_code"},
+			want:    `{"type":"FeatureCollection","features":[` + "\n]}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			w, err := NewWriter(tt.format, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Columns(tt.columns); err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range tt.rows {
+				if err := w.Row(row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("output =\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWriterRejectsUnknownFormat(t *testing.T) {
+	if _, err := NewWriter("xlsx", &strings.Builder{}); err == nil {
+		t.Error("NewWriter() succeeded for xlsx, want error")
+	}
+}
+
+func TestContentTypeAndExtension(t *testing.T) {
+	tests := []struct {
+		format      string
+		contentType string
+		extension   string
+	}{
+		{FormatCSV, "text/csv; charset=utf-8", ".csv"},
+		{FormatNDJSON, "application/x-ndjson", ".ndjson"},
+		{"GeoJSON", "application/geo+json", ".geojson"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := ContentType(tt.format); got != tt.contentType {
+				t.Errorf("ContentType() = %s, want %s", got, tt.contentType)
+			}
+			if got := Extension(tt.format); got != tt.extension {
+				t.Errorf("Extension() = %s, want %s", got, tt.extension)
+			}
+		})
+	}
+}
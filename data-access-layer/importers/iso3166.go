@@ -0,0 +1,241 @@
+// Package importers reads ISO 3166 reference data files for the bulk import.
+//
+// CSV files hold either countries (ISO 3166-1) or subdivisions (ISO 3166-2),
+// recognised by their header row:
+//
+//	alpha_2,alpha_3,numeric,name,official_name
+//	code,name,type,parent
+//
+// Column names follow the iso-codes project; country_code, iso3_code,
+// numeric_code, country_name, subdivision_code, subdivision_name,
+// subdivision_type and parent_code are accepted as well. JSON files hold the
+// "3166-1" and/or "3166-2" arrays of the iso-codes project, with the same
+// field names, or "countries" and "subdivisions" arrays.
+//
+// A subdivision's country is the prefix of its code. A parent may be given
+// as a full code (FR-IDF) or, as in iso-codes, without the country prefix.
+package importers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// Supported file formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Dataset is the content of one import file. Only the columns present in
+// the file are set.
+type Dataset struct {
+	Countries    []models.Country
+	Subdivisions []repositories.SubdivisionRecord
+}
+
+// Parse reads a file of the given format
+func Parse(format string, r io.Reader) (*Dataset, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatJSON:
+		return ParseJSON(r)
+	}
+	return nil, errors.NewValidationError("format", fmt.Sprintf("unsupported import format %q, expected csv or json", format))
+}
+
+// columnAliases maps accepted column names onto iso-codes field names
+var columnAliases = map[string]string{
+	"alpha_2":          "alpha_2",
+	"alpha2":           "alpha_2",
+	"country_code":     "alpha_2",
+	"alpha_3":          "alpha_3",
+	"alpha3":           "alpha_3",
+	"iso3_code":        "alpha_3",
+	"numeric":          "numeric",
+	"numeric_code":     "numeric",
+	"name":             "name",
+	"country_name":     "name",
+	"subdivision_name": "name",
+	"official_name":    "official_name",
+	"code":             "code",
+	"subdivision_code": "code",
+	"type":             "type",
+	"subdivision_type": "type",
+	"parent":           "parent",
+	"parent_code":      "parent",
+}
+
+// ParseCSV reads a countries or subdivisions CSV file
+func ParseCSV(r io.Reader) (*Dataset, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.NewValidationError("file", fmt.Sprintf("cannot read CSV header: %v", err))
+	}
+	columns := make([]string, len(header))
+	present := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[i] = columnAliases[name]
+		present[columns[i]] = true
+	}
+	isCountries := present["alpha_2"]
+	if !isCountries && !present["code"] {
+		return nil, errors.NewValidationError("file", "CSV header needs an alpha_2 column for countries or a code column for subdivisions")
+	}
+
+	dataset := &Dataset{}
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.NewValidationError("file", fmt.Sprintf("line %d: %v", line, err))
+		}
+
+		record := make(map[string]string, len(row))
+		for i, value := range row {
+			if i < len(columns) && columns[i] != "" {
+				record[columns[i]] = strings.TrimSpace(value)
+			}
+		}
+		if isCountries {
+			country, err := countryFromRecord(record)
+			if err != nil {
+				return nil, errors.NewValidationError("file", fmt.Sprintf("line %d: %s", line, err))
+			}
+			dataset.Countries = append(dataset.Countries, country)
+		} else {
+			subdivision, err := subdivisionFromRecord(record)
+			if err != nil {
+				return nil, errors.NewValidationError("file", fmt.Sprintf("line %d: %s", line, err))
+			}
+			dataset.Subdivisions = append(dataset.Subdivisions, subdivision)
+		}
+	}
+	return dataset, nil
+}
+
+// jsonRecord is one entry of an iso-codes style JSON array
+type jsonRecord struct {
+	Alpha2       string      `json:"alpha_2"`
+	Alpha3       string      `json:"alpha_3"`
+	Numeric      json.Number `json:"numeric"`
+	Name         string      `json:"name"`
+	OfficialName string      `json:"official_name"`
+	Code         string      `json:"code"`
+	Type         string      `json:"type"`
+	Parent       string      `json:"parent"`
+}
+
+func (j jsonRecord) fields() map[string]string {
+	return map[string]string{
+		"alpha_2":       j.Alpha2,
+		"alpha_3":       j.Alpha3,
+		"numeric":       j.Numeric.String(),
+		"name":          j.Name,
+		"official_name": j.OfficialName,
+		"code":          j.Code,
+		"type":          j.Type,
+		"parent":        j.Parent,
+	}
+}
+
+// ParseJSON reads an iso-codes style JSON file
+func ParseJSON(r io.Reader) (*Dataset, error) {
+	var file struct {
+		ISO3166_1    []jsonRecord `json:"3166-1"`
+		ISO3166_2    []jsonRecord `json:"3166-2"`
+		Countries    []jsonRecord `json:"countries"`
+		Subdivisions []jsonRecord `json:"subdivisions"`
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, errors.NewValidationError("file", fmt.Sprintf("invalid JSON: %v", err))
+	}
+
+	dataset := &Dataset{}
+	for i, record := range append(file.ISO3166_1, file.Countries...) {
+		country, err := countryFromRecord(record.fields())
+		if err != nil {
+			return nil, errors.NewValidationError("file", fmt.Sprintf("country %d: %s", i+1, err))
+		}
+		dataset.Countries = append(dataset.Countries, country)
+	}
+	for i, record := range append(file.ISO3166_2, file.Subdivisions...) {
+		subdivision, err := subdivisionFromRecord(record.fields())
+		if err != nil {
+			return nil, errors.NewValidationError("file", fmt.Sprintf("subdivision %d: %s", i+1, err))
+		}
+		dataset.Subdivisions = append(dataset.Subdivisions, subdivision)
+	}
+	if len(dataset.Countries) == 0 && len(dataset.Subdivisions) == 0 {
+		return nil, errors.NewValidationError("file", "JSON holds no 3166-1, 3166-2, countries or subdivisions entries")
+	}
+	return dataset, nil
+}
+
+func countryFromRecord(record map[string]string) (models.Country, error) {
+	country := models.Country{
+		CountryCode: strings.ToUpper(record["alpha_2"]),
+		CountryName: record["name"],
+	}
+	if country.CountryCode == "" || country.CountryName == "" {
+		return country, fmt.Errorf("alpha_2 and name are required")
+	}
+	if alpha3 := strings.ToUpper(record["alpha_3"]); alpha3 != "" {
+		country.ISO3Code = &alpha3
+	}
+	if numeric := record["numeric"]; numeric != "" {
+		n, err := strconv.ParseInt(numeric, 10, 16)
+		if err != nil {
+			return country, fmt.Errorf("%s: invalid numeric code %q", country.CountryCode, numeric)
+		}
+		code := int16(n)
+		country.NumericCode = &code
+	}
+	if official := record["official_name"]; official != "" {
+		country.OfficialName = &official
+	}
+	return country, nil
+}
+
+func subdivisionFromRecord(record map[string]string) (repositories.SubdivisionRecord, error) {
+	code := strings.ToUpper(record["code"])
+	subdivision := repositories.SubdivisionRecord{
+		CountrySubdivision: models.CountrySubdivision{
+			SubdivisionCode: code,
+			SubdivisionName: record["name"],
+			SubdivisionType: strings.ToUpper(strings.ReplaceAll(record["type"], " ", "_")),
+		},
+	}
+	if code == "" || subdivision.SubdivisionName == "" {
+		return subdivision, fmt.Errorf("code and name are required")
+	}
+
+	countryCode, _, ok := strings.Cut(code, "-")
+	if !ok {
+		return subdivision, fmt.Errorf("%s: code must start with the country code and '-'", code)
+	}
+	subdivision.CountryCode = countryCode
+
+	if parent := strings.ToUpper(record["parent"]); parent != "" {
+		if !strings.Contains(parent, "-") {
+			parent = countryCode + "-" + parent
+		}
+		subdivision.ParentCode = parent
+	}
+	return subdivision, nil
+}
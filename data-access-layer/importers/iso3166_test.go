@@ -0,0 +1,109 @@
+package importers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// summary renders a dataset as one line per entry
+func summary(d *Dataset) []string {
+	var out []string
+	for _, c := range d.Countries {
+		line := c.CountryCode + " " + c.CountryName
+		if c.ISO3Code != nil {
+			line += " " + *c.ISO3Code
+		}
+		if c.NumericCode != nil {
+			line += fmt.Sprintf(" %03d", *c.NumericCode)
+		}
+		if c.OfficialName != nil {
+			line += " (" + *c.OfficialName + ")"
+		}
+		out = append(out, line)
+	}
+	for _, s := range d.Subdivisions {
+		line := s.SubdivisionCode + " " + s.SubdivisionName + " " + s.SubdivisionType + " in " + s.CountryCode
+		if s.ParentCode != "" {
+			line += " under " + s.ParentCode
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "iso-codes countries csv",
+			format: FormatCSV,
+			file:   "\ufeffalpha_2,alpha_3,numeric,name,official_name\nde,deu,276,Germany,Federal Republic of Germany\nAD, AND, 020, Andorra,\n",
+			want:   []string{"DE Germany DEU 276 (Federal Republic of Germany)", "AD Andorra AND 020"},
+		},
+		{
+			name:   "countries csv with model column names",
+			format: FormatCSV,
+			file:   "country_code,country_name,population\nFR,France,68000000\n",
+			want:   []string{"FR France"},
+		},
+		{
+			name:   "subdivisions csv with short and full parents",
+			format: FormatCSV,
+			file:   "code,name,type,parent\nFR-IDF,Île-de-France,Metropolitan region,\nFR-75C,Paris,Metropolitan collectivity with special status,IDF\nES-M,Madrid,Province,ES-MD\n",
+			want: []string{
+				"FR-IDF Île-de-France METROPOLITAN_REGION in FR",
+				"FR-75C Paris METROPOLITAN_COLLECTIVITY_WITH_SPECIAL_STATUS in FR under FR-IDF",
+				"ES-M Madrid PROVINCE in ES under ES-MD",
+			},
+		},
+		{
+			name:   "format is case insensitive",
+			format: "CSV",
+			file:   "alpha_2,name\nIT,Italy\n",
+			want:   []string{"IT Italy"},
+		},
+		{
+			name:   "iso-codes json",
+			format: FormatJSON,
+			file:   `{"3166-1":[{"alpha_2":"DE","alpha_3":"DEU","numeric":"276","name":"Germany"}],"3166-2":[{"code":"DE-BY","name":"Bayern","type":"Land"}]}`,
+			want:   []string{"DE Germany DEU 276", "DE-BY Bayern LAND in DE"},
+		},
+		{
+			name:   "json with countries and subdivisions arrays",
+			format: FormatJSON,
+			file:   `{"countries":[{"alpha_2":"es","name":"Spain","numeric":724}],"subdivisions":[{"code":"ES-MD","name":"Madrid, Comunidad de","type":"Autonomous community"}]}`,
+			want:   []string{"ES Spain 724", "ES-MD Madrid, Comunidad de AUTONOMOUS_COMMUNITY in ES"},
+		},
+		{name: "unsupported format", format: "xml", file: "<countries/>", wantErr: true},
+		{name: "empty csv", format: FormatCSV, file: "", wantErr: true},
+		{name: "csv without a key column", format: FormatCSV, file: "name,official_name\nGermany,\n", wantErr: true},
+		{name: "country without a name", format: FormatCSV, file: "alpha_2,name\nDE,\n", wantErr: true},
+		{name: "numeric code out of range", format: FormatCSV, file: "alpha_2,name,numeric\nDE,Germany,99999\n", wantErr: true},
+		{name: "subdivision code without a country", format: FormatCSV, file: "code,name\nENG,England\n", wantErr: true},
+		{name: "ragged csv row", format: FormatCSV, file: "alpha_2,name\nDE,Germany,extra\n", wantErr: true},
+		{name: "malformed json", format: FormatJSON, file: `{"3166-1":[`, wantErr: true},
+		{name: "json without entries", format: FormatJSON, file: `{"3166-3":[]}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.format, strings.NewReader(tt.file))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if lines := summary(got); !reflect.DeepEqual(lines, tt.want) {
+				t.Errorf("Parse() = %q, want %q", lines, tt.want)
+			}
+		})
+	}
+}
@@ -24,11 +24,18 @@ type Country struct {
 	PrimaryLanguageID *uuid.UUID `json:"primary_language_id,omitempty" gorm:"type:uuid"`
 	CurrencyID        *uuid.UUID `json:"currency_id,omitempty" gorm:"type:uuid"`
 	PhonePrefix       *string    `json:"phone_prefix,omitempty" gorm:"type:varchar(10)"`
+	// Centroid of the simplified boundary, WGS84 degrees
+	Latitude          *float64   `json:"latitude,omitempty" gorm:"type:double precision"`
+	Longitude         *float64   `json:"longitude,omitempty" gorm:"type:double precision"`
 	
 	// Status Fields
 	IsActive          bool       `json:"is_active" gorm:"default:true;not null"`
 	IsDeleted         bool       `json:"is_deleted" gorm:"default:false;not null"`
 	
+	// Valid-time period [valid_from, valid_to); NULL bounds are open
+	ValidFrom         *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo           *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
+	
 	// LASANI Audit Fields (27 fields) - COMPLETE IMPLEMENTATION
 	TenantID          string     `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
 	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
@@ -73,7 +80,23 @@ type Region struct {
 	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
 	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
 	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
 	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
 	Version           int       `json:"version" gorm:"default:1;not null"`
 }
 
@@ -93,7 +116,23 @@ type Language struct {
 	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
 	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
 	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
 	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
 	Version           int       `json:"version" gorm:"default:1;not null"`
 }
 
@@ -101,10 +140,12 @@ func (Language) TableName() string {
 	return "domain_reference_master_geopolitical.languages"
 }
 
-// Timezone represents timezone information
+// Timezone represents an IANA time zone. TimezoneCode is the zone name, e.g.
+// America/New_York; the offset columns describe its current standard and
+// daylight saving offsets and are derived from the tz database on write.
 type Timezone struct {
 	TimezoneID        uuid.UUID `json:"timezone_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	TimezoneCode      string    `json:"timezone_code" gorm:"type:varchar(10);uniqueIndex;not null"`
+	TimezoneCode      string    `json:"timezone_code" gorm:"type:varchar(64);uniqueIndex;not null"`
 	TimezoneName      string    `json:"timezone_name" gorm:"type:varchar(100);not null"`
 	UTCOffsetHours    int       `json:"utc_offset_hours" gorm:"not null"`
 	UTCOffsetMinutes  int       `json:"utc_offset_minutes" gorm:"default:0;not null"`
@@ -114,7 +155,23 @@ type Timezone struct {
 	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
 	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
 	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
 	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
 	Version           int       `json:"version" gorm:"default:1;not null"`
 }
 
@@ -127,13 +184,35 @@ type CountrySubdivision struct {
 	SubdivisionID     uuid.UUID `json:"subdivision_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	SubdivisionCode   string    `json:"subdivision_code" gorm:"type:varchar(10);not null"`
 	SubdivisionName   string    `json:"subdivision_name" gorm:"type:varchar(100);not null"`
-	SubdivisionType   string    `json:"subdivision_type" gorm:"type:varchar(20);not null"`
+	SubdivisionType   string    `json:"subdivision_type" gorm:"type:varchar(50);not null"`
 	CountryID         uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	ParentSubdivisionID *uuid.UUID `json:"parent_subdivision_id,omitempty" gorm:"type:uuid"`
+	// Centroid of the simplified boundary, WGS84 degrees
+	Latitude          *float64  `json:"latitude,omitempty" gorm:"type:double precision"`
+	Longitude         *float64  `json:"longitude,omitempty" gorm:"type:double precision"`
 	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
 	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	ValidFrom         *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo           *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
 	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
 	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
 	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
 	Version           int       `json:"version" gorm:"default:1;not null"`
 }
 
@@ -152,10 +231,551 @@ type Locales struct {
 	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
 	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
 	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
 	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
 	Version           int       `json:"version" gorm:"default:1;not null"`
 }
 
 func (Locales) TableName() string {
 	return "domain_reference_master_geopolitical.locales"
-}
\ No newline at end of file
+}
+
+// Currency represents an ISO 4217 currency
+type Currency struct {
+	CurrencyID        uuid.UUID `json:"currency_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CurrencyCode      string    `json:"currency_code" gorm:"type:char(3);uniqueIndex;not null"`
+	CurrencyName      string    `json:"currency_name" gorm:"type:varchar(100);not null"`
+	NumericCode       *int16    `json:"numeric_code,omitempty" gorm:"type:smallint"`
+	// MinorUnits is the number of decimals; nil where ISO 4217 defines none (e.g. XAU)
+	MinorUnits        *int16    `json:"minor_units,omitempty" gorm:"type:smallint"`
+	Symbol            *string   `json:"symbol,omitempty" gorm:"type:varchar(10)"`
+	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (Currency) TableName() string {
+	return "domain_reference_master_geopolitical.currencies"
+}
+
+// CountryCurrency links a country to a currency it uses during the valid-time
+// period [valid_from, valid_to). Periods of the same pair never overlap.
+type CountryCurrency struct {
+	CountryCurrencyID uuid.UUID `json:"country_currency_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID         uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	CurrencyID        uuid.UUID `json:"currency_id" gorm:"type:uuid;not null"`
+	IsLegalTender     *bool     `json:"is_legal_tender,omitempty" gorm:"default:true;not null"`
+	ValidFrom         *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo           *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
+	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (CountryCurrency) TableName() string {
+	return "domain_reference_master_geopolitical.country_currencies"
+}
+
+// Country language statuses
+const (
+	LanguageStatusOfficial   = "OFFICIAL"
+	LanguageStatusRegional   = "REGIONAL"
+	LanguageStatusRecognized = "RECOGNIZED"
+)
+
+// CountryLanguage links a country to a language spoken there. At most one
+// language per country is primary; it is the default for locale selection.
+type CountryLanguage struct {
+	CountryLanguageID uuid.UUID `json:"country_language_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID         uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	LanguageID        uuid.UUID `json:"language_id" gorm:"type:uuid;not null"`
+	Status            string    `json:"status" gorm:"type:varchar(20);not null"`
+	// SpeakerPercentage is the share of the population speaking the language, 0-100
+	SpeakerPercentage *float64  `json:"speaker_percentage,omitempty" gorm:"type:numeric(5,2)"`
+	IsPrimary         *bool     `json:"is_primary,omitempty" gorm:"default:false;not null"`
+	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (CountryLanguage) TableName() string {
+	return "domain_reference_master_geopolitical.country_languages"
+}
+
+// CountryTimezone links a time zone to a country, or to one subdivision of
+// it when SubdivisionID is set
+type CountryTimezone struct {
+	CountryTimezoneID uuid.UUID  `json:"country_timezone_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID         uuid.UUID  `json:"country_id" gorm:"type:uuid;not null"`
+	SubdivisionID     *uuid.UUID `json:"subdivision_id,omitempty" gorm:"type:uuid"`
+	TimezoneID        uuid.UUID  `json:"timezone_id" gorm:"type:uuid;not null"`
+	IsActive          bool       `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool       `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string     `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int        `json:"version" gorm:"default:1;not null"`
+}
+
+func (CountryTimezone) TableName() string {
+	return "domain_reference_master_geopolitical.country_timezones"
+}
+
+// PhoneNumberingPlan holds the E.164 numbering rules of a country. Several
+// countries share a calling code (+1, +7); LeadingDigits tells them apart.
+type PhoneNumberingPlan struct {
+	PhoneNumberingPlanID uuid.UUID `json:"phone_numbering_plan_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID            uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	// CallingCode is the ITU country calling code without "+", e.g. 44
+	CallingCode          string    `json:"calling_code" gorm:"type:varchar(3);not null"`
+	// TrunkPrefix is dialled before national numbers, e.g. 0 in the UK
+	TrunkPrefix          *string   `json:"trunk_prefix,omitempty" gorm:"type:varchar(4)"`
+	// InternationalPrefix is dialled before a calling code, e.g. 00 or 011
+	InternationalPrefix  *string   `json:"international_prefix,omitempty" gorm:"type:varchar(6)"`
+	// Lengths of the national significant number, without trunk prefix
+	MinLength            int16     `json:"min_length" gorm:"type:smallint;not null"`
+	MaxLength            int16     `json:"max_length" gorm:"type:smallint;not null"`
+	// LeadingDigits lists comma-separated prefixes of the national number
+	// that belong to this country within a shared calling code; empty for the
+	// main country of the code
+	LeadingDigits        *string   `json:"leading_digits,omitempty" gorm:"type:text"`
+	IsActive             bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted            bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID             string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt            *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy            *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP            *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice        *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession       *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation      *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt            *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy            *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP            *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice        *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession       *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation      *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt            *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy            *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP            *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice        *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession       *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation      *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version              int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (PhoneNumberingPlan) TableName() string {
+	return "domain_reference_master_geopolitical.phone_numbering_plans"
+}
+
+// PostalCodeFormat is a postal code rule of a country, or of one subdivision
+// when SubdivisionID is set. Patterns apply to the compact code: uppercase
+// letters and digits only, so "sw1a 1aa" is matched as SW1A1AA.
+type PostalCodeFormat struct {
+	PostalCodeFormatID uuid.UUID  `json:"postal_code_format_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID          uuid.UUID  `json:"country_id" gorm:"type:uuid;not null"`
+	SubdivisionID      *uuid.UUID `json:"subdivision_id,omitempty" gorm:"type:uuid"`
+	// Pattern is an anchored RE2 expression; nil for a country without postal codes
+	Pattern            *string    `json:"pattern,omitempty" gorm:"type:varchar(200)"`
+	// Format rebuilds the display form from the pattern groups, e.g. "$1 $2"
+	Format             *string    `json:"format,omitempty" gorm:"type:varchar(50)"`
+	Example            *string    `json:"example,omitempty" gorm:"type:varchar(20)"`
+	// IsRequired tells whether addresses in the country need a postal code
+	IsRequired         *bool      `json:"is_required,omitempty" gorm:"default:false;not null"`
+	IsActive           bool       `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted          bool       `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID           string     `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt          *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy          *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP          *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice      *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession     *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation    *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy          *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP          *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice      *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession     *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation    *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy          *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP          *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice      *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession     *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation    *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version            int        `json:"version" gorm:"default:1;not null"`
+}
+
+func (PostalCodeFormat) TableName() string {
+	return "domain_reference_master_geopolitical.postal_code_formats"
+}
+
+// Subdivision styles of an address format
+const (
+	SubdivisionStyleName = "name"
+	SubdivisionStyleCode = "code"
+)
+
+// AddressFormat describes how addresses of a country are laid out. Templates
+// use the libaddressinput field tokens: %N recipient, %O organization, %A
+// address lines, %D dependent locality, %C locality, %S subdivision, %Z
+// postal code, %X sorting code and %n for a line break.
+type AddressFormat struct {
+	AddressFormatID   uuid.UUID `json:"address_format_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID         uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	Template          string    `json:"template" gorm:"type:varchar(200);not null"`
+	// LatinTemplate is the layout for romanized addresses where it differs, e.g. JP
+	LatinTemplate     *string   `json:"latin_template,omitempty" gorm:"type:varchar(200)"`
+	// RequiredFields and UppercaseFields are field letters, e.g. ACSZ
+	RequiredFields    *string   `json:"required_fields,omitempty" gorm:"type:varchar(10)"`
+	UppercaseFields   *string   `json:"uppercase_fields,omitempty" gorm:"type:varchar(10)"`
+	SubdivisionLabel  *string   `json:"subdivision_label,omitempty" gorm:"type:varchar(20)"`
+	LocalityLabel     *string   `json:"locality_label,omitempty" gorm:"type:varchar(20)"`
+	PostalCodeLabel   *string   `json:"postal_code_label,omitempty" gorm:"type:varchar(20)"`
+	// SubdivisionStyle renders the subdivision by name or by ISO code suffix (CA for US-CA)
+	SubdivisionStyle  string    `json:"subdivision_style" gorm:"type:varchar(10);default:'name';not null"`
+	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (AddressFormat) TableName() string {
+	return "domain_reference_master_geopolitical.address_formats"
+}
+
+// Border types
+const (
+	BorderTypeLand     = "LAND"
+	BorderTypeMaritime = "MARITIME"
+)
+
+// CountryBorder is a border between two countries. Borders are undirected:
+// a pair is stored once per border type, whichever country comes first.
+type CountryBorder struct {
+	CountryBorderID   uuid.UUID `json:"country_border_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID         uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	NeighborCountryID uuid.UUID `json:"neighbor_country_id" gorm:"type:uuid;not null"`
+	BorderType        string    `json:"border_type" gorm:"type:varchar(10);not null"`
+	// LengthKm is the length of a land border; maritime borders usually have none
+	LengthKm          *float64  `json:"length_km,omitempty" gorm:"type:numeric(10,2)"`
+	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (CountryBorder) TableName() string {
+	return "domain_reference_master_geopolitical.country_borders"
+}
+
+// Country code systems of the crosswalk. The ISO 3166-1 codes are held on
+// the country; other systems are external codes.
+const (
+	CodeSystemISO2      = "iso2"
+	CodeSystemISO3      = "iso3"
+	CodeSystemNumeric   = "numeric"
+	CodeSystemFIPS      = "fips"
+	CodeSystemIOC       = "ioc"
+	CodeSystemITU       = "itu"
+	CodeSystemGENC      = "genc"
+	CodeSystemWorldBank = "wb"
+)
+
+// CountryExternalCode is the code of a country in an external coding system
+// such as FIPS 10-4 or IOC. A country has one code per system at a time.
+type CountryExternalCode struct {
+	CountryExternalCodeID uuid.UUID `json:"country_external_code_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID             uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	CodeSystem            string    `json:"code_system" gorm:"type:varchar(20);not null"`
+	Code                  string    `json:"code" gorm:"type:varchar(10);not null"`
+	// Valid-time period [valid_from, valid_to); NULL bounds are open
+	ValidFrom             *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo               *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
+	IsActive              bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted             bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID              string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt             *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy             *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP             *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice         *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession        *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation       *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt             *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy             *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP             *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice         *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession        *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation       *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt             *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy             *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP             *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice         *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession        *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation       *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version               int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (CountryExternalCode) TableName() string {
+	return "domain_reference_master_geopolitical.country_external_codes"
+}
+
+// NameTranslation is the name of a country, region, language or subdivision
+// in one locale. EntityType is the entity name used in history, e.g.
+// country; LocaleCode is a canonical BCP 47 tag such as ja or pt-BR.
+type NameTranslation struct {
+	NameTranslationID uuid.UUID `json:"name_translation_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EntityType        string    `json:"entity_type" gorm:"type:varchar(30);not null"`
+	EntityID          uuid.UUID `json:"entity_id" gorm:"type:uuid;not null"`
+	LocaleCode        string    `json:"locale_code" gorm:"type:varchar(20);not null"`
+	Name              string    `json:"name" gorm:"type:varchar(200);not null"`
+	ShortName         *string   `json:"short_name,omitempty" gorm:"type:varchar(100)"`
+	OfficialName      *string   `json:"official_name,omitempty" gorm:"type:varchar(300)"`
+	// SortName orders names where the written form does not, e.g. the kana
+	// reading of a Japanese name
+	SortName          *string   `json:"sort_name,omitempty" gorm:"type:varchar(200)"`
+	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (NameTranslation) TableName() string {
+	return "domain_reference_master_geopolitical.name_translations"
+}
+
+// Grouping types
+const (
+	GroupingTypePolitical     = "POLITICAL"
+	GroupingTypeCustomsUnion  = "CUSTOMS_UNION"
+	GroupingTypeMonetaryUnion = "MONETARY_UNION"
+	GroupingTypeFreeMovement  = "FREE_MOVEMENT"
+	GroupingTypeEconomic      = "ECONOMIC"
+	GroupingTypeCustom        = "CUSTOM"
+)
+
+// Grouping membership types
+const (
+	MembershipTypeMember    = "MEMBER"
+	MembershipTypeAssociate = "ASSOCIATE"
+	MembershipTypeObserver  = "OBSERVER"
+	MembershipTypeCandidate = "CANDIDATE"
+)
+
+// Grouping is a group of countries such as the EU, the Schengen Area, the
+// euro area or OECD, or a grouping of the tenant's own. Blocs with distinct
+// membership, such as the EU and the EU customs union, are separate
+// groupings.
+type Grouping struct {
+	GroupingID        uuid.UUID `json:"grouping_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	GroupingCode      string    `json:"grouping_code" gorm:"type:varchar(20);not null"`
+	GroupingName      string    `json:"grouping_name" gorm:"type:varchar(100);not null"`
+	GroupingType      string    `json:"grouping_type" gorm:"type:varchar(20);not null"`
+	Description       *string   `json:"description,omitempty" gorm:"type:text"`
+	IsActive          bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted         bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID          string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy         *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP         *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice     *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession    *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation   *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy         *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP         *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice     *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession    *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation   *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy         *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP         *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice     *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession    *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation   *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version           int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (Grouping) TableName() string {
+	return "domain_reference_master_geopolitical.groupings"
+}
+
+// GroupingMembership is the membership of a country in a grouping. The
+// country joined on valid_from and left on valid_to; a country holds one
+// membership of a grouping at a time.
+type GroupingMembership struct {
+	GroupingMembershipID uuid.UUID `json:"grouping_membership_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	GroupingID           uuid.UUID `json:"grouping_id" gorm:"type:uuid;not null"`
+	CountryID            uuid.UUID `json:"country_id" gorm:"type:uuid;not null"`
+	MembershipType       string    `json:"membership_type" gorm:"type:varchar(20);not null"`
+	// Valid-time period [valid_from, valid_to); NULL bounds are open
+	ValidFrom            *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo              *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
+	IsActive             bool      `json:"is_active" gorm:"default:true;not null"`
+	IsDeleted            bool      `json:"is_deleted" gorm:"default:false;not null"`
+	TenantID             string    `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt            *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy            *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	CreatedIP            *net.IP    `json:"created_ip,omitempty" gorm:"type:inet"`
+	CreatedDevice        *json.RawMessage `json:"created_device,omitempty" gorm:"type:jsonb"`
+	CreatedSession       *uuid.UUID `json:"created_session,omitempty" gorm:"type:uuid"`
+	CreatedLocation      *json.RawMessage `json:"created_location,omitempty" gorm:"type:jsonb"`
+	UpdatedAt            *time.Time `json:"updated_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	UpdatedBy            *uuid.UUID `json:"updated_by,omitempty" gorm:"type:uuid"`
+	UpdatedIP            *net.IP    `json:"updated_ip,omitempty" gorm:"type:inet"`
+	UpdatedDevice        *json.RawMessage `json:"updated_device,omitempty" gorm:"type:jsonb"`
+	UpdatedSession       *uuid.UUID `json:"updated_session,omitempty" gorm:"type:uuid"`
+	UpdatedLocation      *json.RawMessage `json:"updated_location,omitempty" gorm:"type:jsonb"`
+	DeletedAt            *time.Time `json:"deleted_at,omitempty" gorm:"type:timestamptz"`
+	DeletedBy            *uuid.UUID `json:"deleted_by,omitempty" gorm:"type:uuid"`
+	DeletedIP            *net.IP    `json:"deleted_ip,omitempty" gorm:"type:inet"`
+	DeletedDevice        *json.RawMessage `json:"deleted_device,omitempty" gorm:"type:jsonb"`
+	DeletedSession       *uuid.UUID `json:"deleted_session,omitempty" gorm:"type:uuid"`
+	DeletedLocation      *json.RawMessage `json:"deleted_location,omitempty" gorm:"type:jsonb"`
+	Version              int       `json:"version" gorm:"default:1;not null"`
+}
+
+func (GroupingMembership) TableName() string {
+	return "domain_reference_master_geopolitical.grouping_memberships"
+}
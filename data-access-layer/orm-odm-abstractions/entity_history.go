@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EntityHistory is an immutable record of one create, update, delete, restore
+// or purge of a reference entity. Changes maps each changed column to its
+// before/after value.
+type EntityHistory struct {
+	HistoryID     uuid.UUID       `json:"history_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID      string          `json:"tenant_id" gorm:"type:varchar(100);not null;index"`
+	EntityType    string          `json:"entity_type" gorm:"type:varchar(30);not null"`
+	EntityID      uuid.UUID       `json:"entity_id" gorm:"type:uuid;not null"`
+	EntityCode    *string         `json:"entity_code,omitempty" gorm:"type:varchar(64)"`
+	Operation     string          `json:"operation" gorm:"type:varchar(10);not null"`
+	Version       int             `json:"version" gorm:"not null"`
+	Changes       json.RawMessage `json:"changes" gorm:"type:jsonb;not null"`
+	Actor         *string         `json:"actor,omitempty" gorm:"type:varchar(100)"`
+	ChangeReason  *string         `json:"change_reason,omitempty" gorm:"type:text"`
+	CorrelationID *string         `json:"correlation_id,omitempty" gorm:"type:varchar(100)"`
+	ChangedAt     time.Time       `json:"changed_at" gorm:"type:timestamptz;default:now();not null"`
+}
+
+func (EntityHistory) TableName() string {
+	return "domain_reference_master_geopolitical.entity_history"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change
+// that raised it. A relay publishes pending events and sets PublishedAt.
+type OutboxEvent struct {
+	EventID       uuid.UUID       `json:"event_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantID      string          `json:"tenant_id" gorm:"type:varchar(100);not null;index"`
+	EventType     string          `json:"event_type" gorm:"type:varchar(100);not null"`
+	AggregateType string          `json:"aggregate_type" gorm:"type:varchar(30);not null"`
+	AggregateID   uuid.UUID       `json:"aggregate_id" gorm:"type:uuid;not null"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb;not null"`
+	CorrelationID *string         `json:"correlation_id,omitempty" gorm:"type:varchar(100)"`
+	OccurredAt    time.Time       `json:"occurred_at" gorm:"type:timestamptz;default:now();not null"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" gorm:"type:timestamptz"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "domain_reference_master_geopolitical.outbox_events"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionReport records one action of a retention run: the rows of one
+// entity and tenant governed by one policy that were anonymized or purged
+type RetentionReport struct {
+	ReportID       uuid.UUID  `json:"report_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RunID          uuid.UUID  `json:"run_id" gorm:"type:uuid;not null;index"`
+	TenantID       string     `json:"tenant_id" gorm:"type:varchar(100);not null"`
+	EntityType     string     `json:"entity_type" gorm:"type:varchar(30);not null"`
+	Policy         string     `json:"policy" gorm:"type:varchar(50);not null"`
+	Classification *string    `json:"classification,omitempty" gorm:"type:varchar(20)"`
+	Action         string     `json:"action" gorm:"type:varchar(20);not null"`
+	Cutoff         time.Time  `json:"cutoff" gorm:"type:timestamptz;not null"`
+	RowsAffected   int64      `json:"rows_affected" gorm:"not null"`
+	Error          *string    `json:"error,omitempty" gorm:"type:text"`
+	StartedAt      time.Time  `json:"started_at" gorm:"type:timestamptz;not null"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty" gorm:"type:timestamptz"`
+}
+
+func (RetentionReport) TableName() string {
+	return "domain_reference_master_geopolitical.retention_reports"
+}
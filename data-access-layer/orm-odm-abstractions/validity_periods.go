@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CountryPeriod records the names a country carried during a valid-time
+// period [valid_from, valid_to). Periods of one country never overlap.
+type CountryPeriod struct {
+	PeriodID     uuid.UUID  `json:"period_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CountryID    uuid.UUID  `json:"country_id" gorm:"type:uuid;not null;index"`
+	CountryName  string     `json:"country_name" gorm:"type:varchar(100);not null"`
+	OfficialName *string    `json:"official_name,omitempty" gorm:"type:varchar(200)"`
+	CapitalCity  *string    `json:"capital_city,omitempty" gorm:"type:varchar(100)"`
+	ValidFrom    time.Time  `json:"valid_from" gorm:"type:date;not null"`
+	ValidTo      *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
+	TenantID     string     `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt    *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	ChangeReason *string    `json:"change_reason,omitempty" gorm:"type:text"`
+}
+
+func (CountryPeriod) TableName() string {
+	return "domain_reference_master_geopolitical.country_periods"
+}
+
+// SubdivisionPeriod records the name and type a subdivision carried during a
+// valid-time period [valid_from, valid_to)
+type SubdivisionPeriod struct {
+	PeriodID        uuid.UUID  `json:"period_id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SubdivisionID   uuid.UUID  `json:"subdivision_id" gorm:"type:uuid;not null;index"`
+	SubdivisionName string     `json:"subdivision_name" gorm:"type:varchar(100);not null"`
+	SubdivisionType *string    `json:"subdivision_type,omitempty" gorm:"type:varchar(20)"`
+	ValidFrom       time.Time  `json:"valid_from" gorm:"type:date;not null"`
+	ValidTo         *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
+	TenantID        string     `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index;not null"`
+	CreatedAt       *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
+	CreatedBy       *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
+	ChangeReason    *string    `json:"change_reason,omitempty" gorm:"type:text"`
+}
+
+func (SubdivisionPeriod) TableName() string {
+	return "domain_reference_master_geopolitical.subdivision_periods"
+}
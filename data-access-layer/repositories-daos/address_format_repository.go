@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var addressFormatValidator = validation.NewAddressFormatValidator()
+
+// AddressFormatView is an address format with the code and name of its
+// country
+type AddressFormatView struct {
+	models.AddressFormat
+	CountryCode string `json:"country_code"`
+	CountryName string `json:"country_name"`
+}
+
+// checkAddressFormat validates an address format and checks that its country
+// exists and has no other format
+func checkAddressFormat(ctx context.Context, r *Repository[models.AddressFormat], tenantID string, id uuid.UUID, format *models.AddressFormat) error {
+	if err := addressFormatValidator.ValidateAddressFormat(format).Err(); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", format.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+	if err := db.Model(new(models.AddressFormat)).
+		Where("tenant_id = ? AND country_id = ? AND is_deleted = ? AND address_format_id <> ?", tenantID, format.CountryID, false, id).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check address formats", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the country already has an address format", nil)
+	}
+	return nil
+}
+
+// ForCountry returns the address format of a country by ISO code, or nil
+// when it has none
+func (r *AddressFormatRepository) ForCountry(ctx context.Context, tenantID, countryCode string) (*AddressFormatView, error) {
+	var views []AddressFormatView
+	err := r.db.WithContext(ctx).
+		Table(r.schema.Table+" af").
+		Select("af.*, c.country_code, c.country_name").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = af.country_id AND c.is_deleted = false").
+		Where("af.tenant_id = ? AND af.is_deleted = ? AND c.country_code = ?", tenantID, false, countryCode).
+		Limit(1).
+		Scan(&views).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve address format", err)
+	}
+	if len(views) == 0 {
+		return nil, nil
+	}
+	return &views[0], nil
+}
@@ -101,10 +101,8 @@ func (r *AlignedCountryRepository) Create(ctx context.Context, tenantID string,
 	entity.Version = 1
 	entity.SourceSystem = "reference_master_geopolitical"
 	
-	// Set creation audit fields if available from context
-	// entity.CreatedBy = getUserFromContext(ctx)
-	// entity.CreatedIP = getIPFromContext(ctx)
-	// entity.CreatedSession = getSessionFromContext(ctx)
+	// created_* and updated_* audit fields are stamped from the request
+	// context by the database audit callbacks
 	
 	if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
 		return fmt.Errorf("failed to create Country: %w", err)
@@ -122,10 +120,7 @@ func (r *AlignedCountryRepository) Update(ctx context.Context, tenantID string,
 	entity.UpdatedAt = &now
 	entity.Version++
 	
-	// Set update audit fields if available from context
-	// entity.UpdatedBy = getUserFromContext(ctx)
-	// entity.UpdatedIP = getIPFromContext(ctx)
-	// entity.UpdatedSession = getSessionFromContext(ctx)
+	// updated_* audit fields are stamped by the database audit callbacks
 	
 	result := r.db.WithContext(ctx).
 		Where("country_code = ? AND version = ?", entity.CountryCode, oldVersion).
@@ -153,10 +148,7 @@ func (r *AlignedCountryRepository) Delete(ctx context.Context, tenantID string,
 			"deleted_at":  now,
 			"updated_at":  now,
 			"version":     gorm.Expr("version + 1"),
-			// "deleted_by": getUserFromContext(ctx),
-			// "deleted_ip": getIPFromContext(ctx),
-			// "deleted_session": getSessionFromContext(ctx),
-		})
+		}) // deleted_* audit fields are stamped by the database audit callbacks
 	
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete Country: %w", result.Error)
@@ -2,242 +2,276 @@ package repositories
 
 import (
 	"context"
-	"time"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
 )
 
+// Entity specs for the reference entities served by the generic repository
+var (
+	CountrySpec = EntitySpec{
+		Name:        "country",
+		CodeColumn:  "country_code",
+		DefaultSort: []SortField{Asc("country_name")},
+	}
+	RegionSpec = EntitySpec{
+		Name:        "region",
+		CodeColumn:  "region_code",
+		DefaultSort: []SortField{Asc("region_name")},
+	}
+	LanguageSpec = EntitySpec{
+		Name:        "language",
+		CodeColumn:  "language_code",
+		DefaultSort: []SortField{Asc("language_name")},
+	}
+	TimezoneSpec = EntitySpec{
+		Name:        "timezone",
+		CodeColumn:  "timezone_code",
+		DefaultSort: []SortField{Asc("timezone_code")},
+	}
+	// Subdivision codes are only unique within a country, so subdivisions
+	// are addressed by ID rather than by natural key
+	SubdivisionSpec = EntitySpec{
+		Name:        "subdivision",
+		DefaultSort: []SortField{Asc("subdivision_name")},
+	}
+	LocaleSpec = EntitySpec{
+		Name:        "locale",
+		CodeColumn:  "locale_code",
+		DefaultSort: []SortField{Asc("locale_code")},
+	}
+	CurrencySpec = EntitySpec{
+		Name:        "currency",
+		CodeColumn:  "currency_code",
+		DefaultSort: []SortField{Asc("currency_code")},
+	}
+	// A country may use the same currency in several periods, so links are
+	// addressed by ID
+	CountryCurrencySpec = EntitySpec{
+		Name:        "country_currency",
+		DefaultSort: []SortField{Asc("valid_from")},
+	}
+	CountryLanguageSpec = EntitySpec{
+		Name:        "country_language",
+		DefaultSort: []SortField{Desc("is_primary")},
+	}
+	CountryTimezoneSpec = EntitySpec{
+		Name:        "country_timezone",
+		DefaultSort: []SortField{Asc("country_id")},
+	}
+	// A country has one numbering plan, reached through the country
+	PhoneNumberingPlanSpec = EntitySpec{
+		Name:        "phone_numbering_plan",
+		DefaultSort: []SortField{Asc("calling_code")},
+	}
+	PostalCodeFormatSpec = EntitySpec{
+		Name:        "postal_code_format",
+		DefaultSort: []SortField{Asc("country_id")},
+	}
+	// A country has one address format, reached through the country
+	AddressFormatSpec = EntitySpec{
+		Name:        "address_format",
+		DefaultSort: []SortField{Asc("country_id")},
+	}
+	// A pair of countries may share a land and a maritime border, so
+	// borders are addressed by ID
+	CountryBorderSpec = EntitySpec{
+		Name:        "country_border",
+		DefaultSort: []SortField{Asc("country_id")},
+	}
+	// A country holds a code per external system and period, so codes are
+	// addressed by ID
+	CountryExternalCodeSpec = EntitySpec{
+		Name:        "country_external_code",
+		DefaultSort: []SortField{Asc("code_system"), Asc("valid_from")},
+	}
+	// An entity has a name per locale, so translations are addressed by ID
+	NameTranslationSpec = EntitySpec{
+		Name:        "name_translation",
+		DefaultSort: []SortField{Asc("entity_type"), Asc("locale_code")},
+	}
+	GroupingSpec = EntitySpec{
+		Name:        "grouping",
+		CodeColumn:  "grouping_code",
+		DefaultSort: []SortField{Asc("grouping_code")},
+	}
+	// A country may leave a grouping and join it again, so memberships are
+	// addressed by ID
+	GroupingMembershipSpec = EntitySpec{
+		Name:        "grouping_membership",
+		DefaultSort: []SortField{Asc("valid_from")},
+	}
+)
+
+// CountryRepository handles tenant-scoped country queries
+type CountryRepository struct {
+	*Repository[models.Country]
+}
+
+func NewCountryRepository(db *gorm.DB) *CountryRepository {
+	return &CountryRepository{NewRepository[models.Country](db, CountrySpec)}
+}
+
 // RegionRepository handles region CRUD operations
 type RegionRepository struct {
-	db *gorm.DB
+	*Repository[models.Region]
 }
 
 func NewRegionRepository(db *gorm.DB) *RegionRepository {
-	return &RegionRepository{db: db}
+	return &RegionRepository{NewRepository[models.Region](db, RegionSpec).WithCheck(checkRegion)}
 }
 
-func (r *RegionRepository) GetAll(ctx context.Context, tenantID string) ([]models.Region, error) {
-	var regions []models.Region
-	err := r.db.WithContext(ctx).Where("tenant_id = ? AND is_deleted = ?", tenantID, false).Find(&regions).Error
-	return regions, err
+// LanguageRepository handles language CRUD operations
+type LanguageRepository struct {
+	*Repository[models.Language]
 }
 
-func (r *RegionRepository) GetByCode(ctx context.Context, tenantID, code string) (*models.Region, error) {
-	var region models.Region
-	err := r.db.WithContext(ctx).Where("region_code = ? AND tenant_id = ? AND is_deleted = ?", code, tenantID, false).First(&region).Error
-	if err == gorm.ErrRecordNotFound { return nil, nil }
-	return &region, err
+func NewLanguageRepository(db *gorm.DB) *LanguageRepository {
+	return &LanguageRepository{NewRepository[models.Language](db, LanguageSpec)}
 }
 
-func (r *RegionRepository) Create(ctx context.Context, tenantID string, region *models.Region) error {
-	now := time.Now()
-	region.RegionID = uuid.New()
-	region.TenantID = tenantID
-	region.CreatedAt = &now
-	region.UpdatedAt = &now
-	region.IsActive = true
-	region.IsDeleted = false
-	region.Version = 1
-	return r.db.WithContext(ctx).Create(region).Error
+// TimezoneRepository handles timezone CRUD operations
+type TimezoneRepository struct {
+	*Repository[models.Timezone]
 }
 
-func (r *RegionRepository) Update(ctx context.Context, tenantID string, region *models.Region) error {
-	now := time.Now()
-	region.UpdatedAt = &now
-	return r.db.WithContext(ctx).Where("region_code = ? AND tenant_id = ?", region.RegionCode, tenantID).Updates(region).Error
+func NewTimezoneRepository(db *gorm.DB) *TimezoneRepository {
+	return &TimezoneRepository{NewRepository[models.Timezone](db, TimezoneSpec).WithCheck(checkTimezone)}
 }
 
-func (r *RegionRepository) Delete(ctx context.Context, tenantID, code string) error {
-	now := time.Now()
-	return r.db.WithContext(ctx).Where("region_code = ? AND tenant_id = ?", code, tenantID).Updates(map[string]interface{}{
-		"is_deleted": true, "deleted_at": now, "updated_at": now,
-	}).Error
+// SubdivisionRepository handles subdivision CRUD operations
+type SubdivisionRepository struct {
+	*Repository[models.CountrySubdivision]
 }
 
-// LanguageRepository handles language CRUD operations
-type LanguageRepository struct {
-	db *gorm.DB
+func NewSubdivisionRepository(db *gorm.DB) *SubdivisionRepository {
+	return &SubdivisionRepository{NewRepository[models.CountrySubdivision](db, SubdivisionSpec).WithCheck(checkSubdivision)}
 }
 
-func NewLanguageRepository(db *gorm.DB) *LanguageRepository {
-	return &LanguageRepository{db: db}
+// GetByCountry returns a page of subdivisions belonging to a country
+func (r *SubdivisionRepository) GetByCountry(ctx context.Context, tenantID string, countryID uuid.UUID, opts ListOptions) (*Page[models.CountrySubdivision], error) {
+	opts.Filters = append(opts.Filters, Eq("country_id", countryID))
+	return r.List(ctx, tenantID, opts)
 }
 
-func (r *LanguageRepository) GetAll(ctx context.Context, tenantID string) ([]models.Language, error) {
-	var languages []models.Language
-	err := r.db.WithContext(ctx).Where("tenant_id = ? AND is_deleted = ?", tenantID, false).Find(&languages).Error
-	return languages, err
+// LocaleRepository handles locale CRUD operations
+type LocaleRepository struct {
+	*Repository[models.Locales]
 }
 
-func (r *LanguageRepository) GetByCode(ctx context.Context, tenantID, code string) (*models.Language, error) {
-	var language models.Language
-	err := r.db.WithContext(ctx).Where("language_code = ? AND tenant_id = ? AND is_deleted = ?", code, tenantID, false).First(&language).Error
-	if err == gorm.ErrRecordNotFound { return nil, nil }
-	return &language, err
+func NewLocaleRepository(db *gorm.DB) *LocaleRepository {
+	return &LocaleRepository{NewRepository[models.Locales](db, LocaleSpec)}
 }
 
-func (r *LanguageRepository) Create(ctx context.Context, tenantID string, language *models.Language) error {
-	now := time.Now()
-	language.LanguageID = uuid.New()
-	language.TenantID = tenantID
-	language.CreatedAt = &now
-	language.UpdatedAt = &now
-	language.IsActive = true
-	language.IsDeleted = false
-	language.Version = 1
-	return r.db.WithContext(ctx).Create(language).Error
+// CurrencyRepository handles currency CRUD operations
+type CurrencyRepository struct {
+	*Repository[models.Currency]
 }
 
-func (r *LanguageRepository) Update(ctx context.Context, tenantID string, language *models.Language) error {
-	now := time.Now()
-	language.UpdatedAt = &now
-	return r.db.WithContext(ctx).Where("language_code = ? AND tenant_id = ?", language.LanguageCode, tenantID).Updates(language).Error
+func NewCurrencyRepository(db *gorm.DB) *CurrencyRepository {
+	return &CurrencyRepository{NewRepository[models.Currency](db, CurrencySpec).WithCheck(checkCurrency)}
 }
 
-func (r *LanguageRepository) Delete(ctx context.Context, tenantID, code string) error {
-	now := time.Now()
-	return r.db.WithContext(ctx).Where("language_code = ? AND tenant_id = ?", code, tenantID).Updates(map[string]interface{}{
-		"is_deleted": true, "deleted_at": now, "updated_at": now,
-	}).Error
+// CountryCurrencyRepository handles the currencies used by countries
+type CountryCurrencyRepository struct {
+	*Repository[models.CountryCurrency]
 }
 
-// TimezoneRepository handles timezone CRUD operations
-type TimezoneRepository struct {
-	db *gorm.DB
+func NewCountryCurrencyRepository(db *gorm.DB) *CountryCurrencyRepository {
+	return &CountryCurrencyRepository{NewRepository[models.CountryCurrency](db, CountryCurrencySpec).WithCheck(checkCountryCurrency)}
 }
 
-func NewTimezoneRepository(db *gorm.DB) *TimezoneRepository {
-	return &TimezoneRepository{db: db}
+// CountryLanguageRepository handles the languages spoken in countries
+type CountryLanguageRepository struct {
+	*Repository[models.CountryLanguage]
 }
 
-func (r *TimezoneRepository) GetAll(ctx context.Context, tenantID string) ([]models.Timezone, error) {
-	var timezones []models.Timezone
-	err := r.db.WithContext(ctx).Where("tenant_id = ? AND is_deleted = ?", tenantID, false).Find(&timezones).Error
-	return timezones, err
+func NewCountryLanguageRepository(db *gorm.DB) *CountryLanguageRepository {
+	return &CountryLanguageRepository{NewRepository[models.CountryLanguage](db, CountryLanguageSpec).WithCheck(checkCountryLanguage)}
 }
 
-func (r *TimezoneRepository) GetByCode(ctx context.Context, tenantID, code string) (*models.Timezone, error) {
-	var timezone models.Timezone
-	err := r.db.WithContext(ctx).Where("timezone_code = ? AND tenant_id = ? AND is_deleted = ?", code, tenantID, false).First(&timezone).Error
-	if err == gorm.ErrRecordNotFound { return nil, nil }
-	return &timezone, err
+// CountryTimezoneRepository handles the time zones of countries and
+// subdivisions
+type CountryTimezoneRepository struct {
+	*Repository[models.CountryTimezone]
 }
 
-func (r *TimezoneRepository) Create(ctx context.Context, tenantID string, timezone *models.Timezone) error {
-	now := time.Now()
-	timezone.TimezoneID = uuid.New()
-	timezone.TenantID = tenantID
-	timezone.CreatedAt = &now
-	timezone.UpdatedAt = &now
-	timezone.IsActive = true
-	timezone.IsDeleted = false
-	timezone.Version = 1
-	return r.db.WithContext(ctx).Create(timezone).Error
+func NewCountryTimezoneRepository(db *gorm.DB) *CountryTimezoneRepository {
+	return &CountryTimezoneRepository{NewRepository[models.CountryTimezone](db, CountryTimezoneSpec).WithCheck(checkCountryTimezone)}
 }
 
-func (r *TimezoneRepository) Update(ctx context.Context, tenantID string, timezone *models.Timezone) error {
-	now := time.Now()
-	timezone.UpdatedAt = &now
-	return r.db.WithContext(ctx).Where("timezone_code = ? AND tenant_id = ?", timezone.TimezoneCode, tenantID).Updates(timezone).Error
+// PhoneNumberingPlanRepository handles the phone numbering plans of
+// countries
+type PhoneNumberingPlanRepository struct {
+	*Repository[models.PhoneNumberingPlan]
 }
 
-func (r *TimezoneRepository) Delete(ctx context.Context, tenantID, code string) error {
-	now := time.Now()
-	return r.db.WithContext(ctx).Where("timezone_code = ? AND tenant_id = ?", code, tenantID).Updates(map[string]interface{}{
-		"is_deleted": true, "deleted_at": now, "updated_at": now,
-	}).Error
+func NewPhoneNumberingPlanRepository(db *gorm.DB) *PhoneNumberingPlanRepository {
+	return &PhoneNumberingPlanRepository{NewRepository[models.PhoneNumberingPlan](db, PhoneNumberingPlanSpec).WithCheck(checkPhonePlan)}
 }
 
-// SubdivisionRepository handles subdivision CRUD operations
-type SubdivisionRepository struct {
-	db *gorm.DB
+// PostalCodeFormatRepository handles the postal code rules of countries and
+// subdivisions
+type PostalCodeFormatRepository struct {
+	*Repository[models.PostalCodeFormat]
 }
 
-func NewSubdivisionRepository(db *gorm.DB) *SubdivisionRepository {
-	return &SubdivisionRepository{db: db}
+func NewPostalCodeFormatRepository(db *gorm.DB) *PostalCodeFormatRepository {
+	return &PostalCodeFormatRepository{NewRepository[models.PostalCodeFormat](db, PostalCodeFormatSpec).WithCheck(checkPostalCodeFormat)}
 }
 
-func (r *SubdivisionRepository) GetAll(ctx context.Context, tenantID string) ([]models.CountrySubdivision, error) {
-	var subdivisions []models.CountrySubdivision
-	err := r.db.WithContext(ctx).Where("tenant_id = ? AND is_deleted = ?", tenantID, false).Find(&subdivisions).Error
-	return subdivisions, err
+// AddressFormatRepository handles the address formats of countries
+type AddressFormatRepository struct {
+	*Repository[models.AddressFormat]
 }
 
-func (r *SubdivisionRepository) GetByCountry(ctx context.Context, tenantID string, countryID uuid.UUID) ([]models.CountrySubdivision, error) {
-	var subdivisions []models.CountrySubdivision
-	err := r.db.WithContext(ctx).Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", countryID, tenantID, false).Find(&subdivisions).Error
-	return subdivisions, err
+func NewAddressFormatRepository(db *gorm.DB) *AddressFormatRepository {
+	return &AddressFormatRepository{NewRepository[models.AddressFormat](db, AddressFormatSpec).WithCheck(checkAddressFormat)}
 }
 
-func (r *SubdivisionRepository) Create(ctx context.Context, tenantID string, subdivision *models.CountrySubdivision) error {
-	now := time.Now()
-	subdivision.SubdivisionID = uuid.New()
-	subdivision.TenantID = tenantID
-	subdivision.CreatedAt = &now
-	subdivision.UpdatedAt = &now
-	subdivision.IsActive = true
-	subdivision.IsDeleted = false
-	subdivision.Version = 1
-	return r.db.WithContext(ctx).Create(subdivision).Error
+// CountryBorderRepository handles the borders between countries
+type CountryBorderRepository struct {
+	*Repository[models.CountryBorder]
 }
 
-func (r *SubdivisionRepository) Update(ctx context.Context, tenantID string, subdivision *models.CountrySubdivision) error {
-	now := time.Now()
-	subdivision.UpdatedAt = &now
-	return r.db.WithContext(ctx).Where("subdivision_id = ? AND tenant_id = ?", subdivision.SubdivisionID, tenantID).Updates(subdivision).Error
+func NewCountryBorderRepository(db *gorm.DB) *CountryBorderRepository {
+	return &CountryBorderRepository{NewRepository[models.CountryBorder](db, CountryBorderSpec).WithCheck(checkCountryBorder)}
 }
 
-func (r *SubdivisionRepository) Delete(ctx context.Context, tenantID string, id uuid.UUID) error {
-	now := time.Now()
-	return r.db.WithContext(ctx).Where("subdivision_id = ? AND tenant_id = ?", id, tenantID).Updates(map[string]interface{}{
-		"is_deleted": true, "deleted_at": now, "updated_at": now,
-	}).Error
+// CountryExternalCodeRepository handles the codes of countries in external
+// code systems
+type CountryExternalCodeRepository struct {
+	*Repository[models.CountryExternalCode]
 }
 
-// LocaleRepository handles locale CRUD operations
-type LocaleRepository struct {
-	db *gorm.DB
+func NewCountryExternalCodeRepository(db *gorm.DB) *CountryExternalCodeRepository {
+	return &CountryExternalCodeRepository{NewRepository[models.CountryExternalCode](db, CountryExternalCodeSpec).WithCheck(checkCountryExternalCode)}
 }
 
-func NewLocaleRepository(db *gorm.DB) *LocaleRepository {
-	return &LocaleRepository{db: db}
+// NameTranslationRepository handles the names of entities in other locales
+type NameTranslationRepository struct {
+	*Repository[models.NameTranslation]
 }
 
-func (r *LocaleRepository) GetAll(ctx context.Context, tenantID string) ([]models.Locales, error) {
-	var locales []models.Locales
-	err := r.db.WithContext(ctx).Where("tenant_id = ? AND is_deleted = ?", tenantID, false).Find(&locales).Error
-	return locales, err
+func NewNameTranslationRepository(db *gorm.DB) *NameTranslationRepository {
+	return &NameTranslationRepository{NewRepository[models.NameTranslation](db, NameTranslationSpec).WithCheck(checkNameTranslation)}
 }
 
-func (r *LocaleRepository) GetByCode(ctx context.Context, tenantID, code string) (*models.Locales, error) {
-	var locale models.Locales
-	err := r.db.WithContext(ctx).Where("locale_code = ? AND tenant_id = ? AND is_deleted = ?", code, tenantID, false).First(&locale).Error
-	if err == gorm.ErrRecordNotFound { return nil, nil }
-	return &locale, err
+// GroupingRepository handles groupings of countries such as the EU
+type GroupingRepository struct {
+	*Repository[models.Grouping]
 }
 
-func (r *LocaleRepository) Create(ctx context.Context, tenantID string, locale *models.Locales) error {
-	now := time.Now()
-	locale.LocaleID = uuid.New()
-	locale.TenantID = tenantID
-	locale.CreatedAt = &now
-	locale.UpdatedAt = &now
-	locale.IsActive = true
-	locale.IsDeleted = false
-	locale.Version = 1
-	return r.db.WithContext(ctx).Create(locale).Error
+func NewGroupingRepository(db *gorm.DB) *GroupingRepository {
+	return &GroupingRepository{NewRepository[models.Grouping](db, GroupingSpec).WithCheck(checkGrouping)}
 }
 
-func (r *LocaleRepository) Update(ctx context.Context, tenantID string, locale *models.Locales) error {
-	now := time.Now()
-	locale.UpdatedAt = &now
-	return r.db.WithContext(ctx).Where("locale_code = ? AND tenant_id = ?", locale.LocaleCode, tenantID).Updates(locale).Error
+// GroupingMembershipRepository handles the memberships of countries in
+// groupings
+type GroupingMembershipRepository struct {
+	*Repository[models.GroupingMembership]
 }
 
-func (r *LocaleRepository) Delete(ctx context.Context, tenantID, code string) error {
-	now := time.Now()
-	return r.db.WithContext(ctx).Where("locale_code = ? AND tenant_id = ?", code, tenantID).Updates(map[string]interface{}{
-		"is_deleted": true, "deleted_at": now, "updated_at": now,
-	}).Error
-}
\ No newline at end of file
+func NewGroupingMembershipRepository(db *gorm.DB) *GroupingMembershipRepository {
+	return &GroupingMembershipRepository{NewRepository[models.GroupingMembership](db, GroupingMembershipSpec).WithCheck(checkMembership)}
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var countryBorderValidator = validation.NewCountryBorderValidator()
+
+// CountryBorderView is a border with the codes and names of both countries
+type CountryBorderView struct {
+	models.CountryBorder
+	CountryCode  string `json:"country_code"`
+	CountryName  string `json:"country_name"`
+	NeighborCode string `json:"neighbor_code"`
+	NeighborName string `json:"neighbor_name"`
+}
+
+// checkCountryBorder validates a border and checks that both countries exist
+// and share no other border of the same type
+func checkCountryBorder(ctx context.Context, r *Repository[models.CountryBorder], tenantID string, id uuid.UUID, border *models.CountryBorder) error {
+	if err := countryBorderValidator.ValidateCountryBorder(border).Err(); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	for field, countryID := range map[string]uuid.UUID{"country_id": border.CountryID, "neighbor_country_id": border.NeighborCountryID} {
+		if err := db.Model(&models.Country{}).
+			Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", countryID, tenantID, false).
+			Count(&found).Error; err != nil {
+			return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+		}
+		if found == 0 {
+			return errors.NewValidationError(field, "country not found")
+		}
+	}
+	// The same pair in either order is the same border
+	if err := db.Model(new(models.CountryBorder)).
+		Where("tenant_id = ? AND border_type = ? AND is_deleted = ? AND country_border_id <> ?", tenantID, border.BorderType, false, id).
+		Where("(country_id = ? AND neighbor_country_id = ?) OR (country_id = ? AND neighbor_country_id = ?)",
+			border.CountryID, border.NeighborCountryID, border.NeighborCountryID, border.CountryID).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check country borders", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the countries already share a border of this type", nil)
+	}
+	return nil
+}
+
+// ForCountry returns the borders of a country by neighbor code, each seen
+// from that country so that the neighbor is the other side. borderType may
+// be empty for all types.
+func (r *CountryBorderRepository) ForCountry(ctx context.Context, tenantID string, countryID uuid.UUID, borderType string) ([]CountryBorderView, error) {
+	views, err := r.views(ctx, tenantID, func(q *gorm.DB) *gorm.DB {
+		q = q.Where("(cb.country_id = ? OR cb.neighbor_country_id = ?)", countryID, countryID)
+		if borderType != "" {
+			q = q.Where("cb.border_type = ?", borderType)
+		}
+		return q
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range views {
+		if views[i].NeighborCountryID == countryID {
+			views[i].reverse()
+		}
+	}
+	sort.SliceStable(views, func(i, j int) bool { return views[i].NeighborCode < views[j].NeighborCode })
+	return views, nil
+}
+
+// Graph returns the active borders of a tenant between live countries, the
+// edges of the border graph. borderType may be empty for all types.
+func (r *CountryBorderRepository) Graph(ctx context.Context, tenantID, borderType string) ([]CountryBorderView, error) {
+	return r.views(ctx, tenantID, func(q *gorm.DB) *gorm.DB {
+		q = q.Where("cb.is_active = ?", true)
+		if borderType != "" {
+			q = q.Where("cb.border_type = ?", borderType)
+		}
+		return q
+	})
+}
+
+func (r *CountryBorderRepository) views(ctx context.Context, tenantID string, scope func(*gorm.DB) *gorm.DB) ([]CountryBorderView, error) {
+	var views []CountryBorderView
+	err := r.db.WithContext(ctx).
+		Table(r.schema.Table+" cb").
+		Select("cb.*, c.country_code, c.country_name, n.country_code AS neighbor_code, n.country_name AS neighbor_name").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = cb.country_id AND c.is_deleted = false").
+		Joins("JOIN "+models.Country{}.TableName()+" n ON n.country_id = cb.neighbor_country_id AND n.is_deleted = false").
+		Where("cb.tenant_id = ? AND cb.is_deleted = ?", tenantID, false).
+		Scopes(scope).
+		Order("c.country_code, n.country_code, cb.border_type").
+		Scan(&views).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country borders", err)
+	}
+	return views, nil
+}
+
+// reverse swaps the two sides of the border
+func (v *CountryBorderView) reverse() {
+	v.CountryID, v.NeighborCountryID = v.NeighborCountryID, v.CountryID
+	v.CountryCode, v.NeighborCode = v.NeighborCode, v.CountryCode
+	v.CountryName, v.NeighborName = v.NeighborName, v.CountryName
+}
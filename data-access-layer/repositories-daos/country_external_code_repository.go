@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var countryCodeValidator = validation.NewCountryCodeValidator()
+
+// isoCodeColumns are the country columns holding the ISO 3166-1 codes, as
+// text in the form they are exchanged
+var isoCodeColumns = map[string]string{
+	models.CodeSystemISO2:    "c.country_code",
+	models.CodeSystemISO3:    "c.iso3_code",
+	models.CodeSystemNumeric: "lpad(c.numeric_code::text, 3, '0')",
+}
+
+// CountryExternalCodeView is an external code with the code and name of its
+// country
+type CountryExternalCodeView struct {
+	models.CountryExternalCode
+	CountryCode string `json:"country_code"`
+	CountryName string `json:"country_name"`
+}
+
+// CrosswalkRow is a code of one system translated to another. Target is nil
+// when the country has no code in the target system.
+type CrosswalkRow struct {
+	Source      string
+	Target      *string
+	CountryCode string
+	CountryName string
+}
+
+// checkCountryExternalCode validates an external code and checks that its
+// country exists and that its period overlaps neither another code of the
+// country in the system nor the same code of another country
+func checkCountryExternalCode(ctx context.Context, r *Repository[models.CountryExternalCode], tenantID string, id uuid.UUID, code *models.CountryExternalCode) error {
+	if err := countryCodeValidator.ValidateExternalCode(code.CodeSystem, code.Code).Err(); err != nil {
+		return err
+	}
+	if err := checkPeriod(code.ValidFrom, code.ValidTo); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", code.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+
+	overlapping := func() *gorm.DB {
+		return r.overlapping(ctx, tenantID, id, code.ValidFrom, code.ValidTo).Where("code_system = ?", code.CodeSystem)
+	}
+	if err := overlapping().Where("country_id = ?", code.CountryID).Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check country external codes", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "the country already has a code in this system during an overlapping period", nil)
+	}
+	if err := overlapping().Where("code = ?", code.Code).Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check country external codes", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the code belongs to another country during an overlapping period", nil)
+	}
+	return nil
+}
+
+// ForCountry returns the external codes of a country valid on asOf, or of
+// any period when asOf is nil, ordered by system and period
+func (r *CountryExternalCodeRepository) ForCountry(ctx context.Context, tenantID string, countryID uuid.UUID, asOf *time.Time) ([]CountryExternalCodeView, error) {
+	query := r.db.WithContext(ctx).
+		Table(r.schema.Table+" x").
+		Select("x.*, c.country_code, c.country_name").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = x.country_id AND c.is_deleted = false").
+		Where("x.tenant_id = ? AND x.is_deleted = ? AND x.country_id = ?", tenantID, false, countryID)
+	if asOf != nil {
+		date := asOf.Format(DateLayout)
+		query = query.Where("(x.valid_from IS NULL OR x.valid_from <= ?::date) AND (x.valid_to IS NULL OR x.valid_to > ?::date)", date, date)
+	}
+
+	var views []CountryExternalCodeView
+	if err := query.Order("x.code_system, x.valid_from NULLS FIRST").Scan(&views).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country external codes", err)
+	}
+	return views, nil
+}
+
+// Translate maps codes of system from to system to, as both stood on asOf.
+// Either system may be an ISO 3166-1 system held on the country. Codes no
+// country had on asOf are missing from the result.
+func (r *CountryExternalCodeRepository) Translate(ctx context.Context, tenantID, from, to string, codes []string, asOf time.Time) ([]CrosswalkRow, error) {
+	date := asOf.Format(DateLayout)
+	query := r.db.WithContext(ctx).
+		Table(models.Country{}.TableName()+" c").
+		Where("c.tenant_id = ? AND c.is_deleted = ?", tenantID, false).
+		Where("(c.valid_from IS NULL OR c.valid_from <= ?::date) AND (c.valid_to IS NULL OR c.valid_to > ?::date)", date, date)
+
+	var source, target string
+	query, source = r.codeColumn(query, "JOIN", "s", from, date)
+	query, target = r.codeColumn(query, "LEFT JOIN", "t", to, date)
+
+	var rows []CrosswalkRow
+	err := query.
+		Select(source+" AS source, "+target+" AS target, c.country_code, c.country_name").
+		Where(source+" IN ?", codes).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to translate country codes", err)
+	}
+	return rows, nil
+}
+
+// codeColumn returns the expression of the codes of system, joining the
+// external codes valid on date under alias unless system is an ISO system
+func (r *CountryExternalCodeRepository) codeColumn(query *gorm.DB, join, alias, system, date string) (*gorm.DB, string) {
+	if column, ok := isoCodeColumns[system]; ok {
+		return query, column
+	}
+	query = query.Joins(fmt.Sprintf(`%[1]s %[2]s %[3]s ON %[3]s.country_id = c.country_id AND %[3]s.tenant_id = c.tenant_id
+		AND %[3]s.is_deleted = false AND %[3]s.is_active = true AND %[3]s.code_system = ?
+		AND (%[3]s.valid_from IS NULL OR %[3]s.valid_from <= ?::date) AND (%[3]s.valid_to IS NULL OR %[3]s.valid_to > ?::date)`,
+		join, r.schema.Table, alias), system, date, date)
+	return query, alias + ".code"
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrosswalkTranslateQuery(t *testing.T) {
+	db, sql := capturingDB(t)
+	repo := NewCountryExternalCodeRepository(db)
+	asOf := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		codes  []string
+		prefix string
+		joins  []string
+		where  string
+	}{
+		{
+			name:   "between ISO systems",
+			from:   "iso2",
+			to:     "iso3",
+			codes:  []string{"DE"},
+			prefix: "SELECT c.country_code AS source, c.iso3_code AS target,",
+			where:  "c.country_code IN ('DE')",
+		},
+		{
+			name:   "external to ISO",
+			from:   "fips",
+			to:     "iso3",
+			codes:  []string{"GM", "SP"},
+			prefix: "SELECT s.code AS source, c.iso3_code AS target,",
+			joins:  []string{"JOIN domain_reference_master_geopolitical.country_external_codes s ON", "s.code_system = 'fips'"},
+			where:  "s.code IN ('GM','SP')",
+		},
+		{
+			name:   "numeric to external",
+			from:   "numeric",
+			to:     "ioc",
+			codes:  []string{"276"},
+			prefix: "SELECT lpad(c.numeric_code::text, 3, '0') AS source, t.code AS target,",
+			joins:  []string{"LEFT JOIN domain_reference_master_geopolitical.country_external_codes t ON", "t.code_system = 'ioc'"},
+			where:  "lpad(c.numeric_code::text, 3, '0') IN ('276')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.Translate(context.Background(), "default-tenant", tt.from, tt.to, tt.codes, asOf)
+			if !strings.HasPrefix(*sql, tt.prefix) {
+				t.Errorf("query %s does not start with %s", *sql, tt.prefix)
+			}
+			for _, join := range tt.joins {
+				if !strings.Contains(*sql, join) {
+					t.Errorf("query %s does not contain %s", *sql, join)
+				}
+			}
+			if len(tt.joins) == 0 && strings.Contains(*sql, "JOIN") {
+				t.Errorf("query %s joins external codes between ISO systems", *sql)
+			}
+			if !strings.Contains(*sql, "(c.valid_to IS NULL OR c.valid_to > '2020-01-02'::date)") {
+				t.Errorf("query %s does not select countries valid on the date", *sql)
+			}
+			if !strings.HasSuffix(*sql, tt.where) {
+				t.Errorf("query %s does not end in %s", *sql, tt.where)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var countryLanguageValidator = validation.NewCountryLanguageValidator()
+
+// CountryLanguageView is a country–language link with the codes of both
+// sides
+type CountryLanguageView struct {
+	models.CountryLanguage
+	CountryCode  string `json:"country_code"`
+	CountryName  string `json:"country_name"`
+	LanguageCode string `json:"language_code"`
+	LanguageName string `json:"language_name"`
+}
+
+// checkCountryLanguage validates a link and checks that both sides exist,
+// that the pair is not linked twice and that the country has at most one
+// primary language
+func checkCountryLanguage(ctx context.Context, r *Repository[models.CountryLanguage], tenantID string, id uuid.UUID, link *models.CountryLanguage) error {
+	if err := countryLanguageValidator.ValidateCountryLanguage(link.Status, link.SpeakerPercentage).Err(); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", link.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+	if err := db.Model(&models.Language{}).
+		Where("language_id = ? AND tenant_id = ? AND is_deleted = ?", link.LanguageID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve language", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("language_id", "language not found")
+	}
+
+	siblings := func() *gorm.DB {
+		return db.Model(new(models.CountryLanguage)).
+			Where("tenant_id = ? AND country_id = ? AND is_deleted = ? AND country_language_id <> ?",
+				tenantID, link.CountryID, false, id)
+	}
+	if err := siblings().Where("language_id = ?", link.LanguageID).Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check country languages", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the language is already linked to this country", nil)
+	}
+	if link.IsPrimary != nil && *link.IsPrimary {
+		if err := siblings().Where("is_primary = ?", true).Count(&found).Error; err != nil {
+			return errors.NewRepositoryError("QUERY_FAILED", "Failed to check country languages", err)
+		}
+		if found > 0 {
+			return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "the country already has a primary language", nil)
+		}
+	}
+	return nil
+}
+
+// ForCountry returns the languages of a country, primary first, then by
+// speaker share
+func (r *CountryLanguageRepository) ForCountry(ctx context.Context, tenantID string, countryID uuid.UUID) ([]CountryLanguageView, error) {
+	return r.views(ctx, tenantID, "cl.country_id", countryID, "cl.is_primary DESC, cl.speaker_percentage DESC NULLS LAST, l.language_code")
+}
+
+// ForLanguage returns the countries where a language is spoken, by speaker
+// share
+func (r *CountryLanguageRepository) ForLanguage(ctx context.Context, tenantID string, languageID uuid.UUID) ([]CountryLanguageView, error) {
+	return r.views(ctx, tenantID, "cl.language_id", languageID, "cl.speaker_percentage DESC NULLS LAST, c.country_code")
+}
+
+func (r *CountryLanguageRepository) views(ctx context.Context, tenantID, column string, id uuid.UUID, order string) ([]CountryLanguageView, error) {
+	var views []CountryLanguageView
+	err := r.db.WithContext(ctx).
+		Table(r.schema.Table+" cl").
+		Select("cl.*, c.country_code, c.country_name, l.language_code, l.language_name").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = cl.country_id AND c.is_deleted = false").
+		Joins("JOIN "+models.Language{}.TableName()+" l ON l.language_id = cl.language_id AND l.is_deleted = false").
+		Where("cl.tenant_id = ? AND cl.is_deleted = ? AND "+column+" = ?", tenantID, false, id).
+		Order(order).
+		Scan(&views).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country languages", err)
+	}
+	return views, nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// capturingDB is a dry-run session that records the last raw or scanned
+// query it renders. Scans on it fail, as there are no rows to read.
+func capturingDB(t *testing.T) (*gorm.DB, *string) {
+	t.Helper()
+	db := dryRunDB(t)
+	db.Logger = logger.Discard
+	var sql string
+	if err := db.Callback().Row().After("gorm:row").Register("test:capture", func(tx *gorm.DB) {
+		sql = tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return db, &sql
+}
+
+func TestCountryLanguageViewOrder(t *testing.T) {
+	db, sql := capturingDB(t)
+	repo := NewCountryLanguageRepository(db)
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		query     func()
+		wantWhere string
+		wantOrder string
+	}{
+		{
+			name:      "languages of a country, primary first",
+			query:     func() { repo.ForCountry(context.Background(), "default-tenant", id) },
+			wantWhere: "cl.country_id = '" + id.String() + "'",
+			wantOrder: "ORDER BY cl.is_primary DESC, cl.speaker_percentage DESC NULLS LAST, l.language_code",
+		},
+		{
+			name:      "countries of a language, by speaker share",
+			query:     func() { repo.ForLanguage(context.Background(), "default-tenant", id) },
+			wantWhere: "cl.language_id = '" + id.String() + "'",
+			wantOrder: "ORDER BY cl.speaker_percentage DESC NULLS LAST, c.country_code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query()
+			if !strings.Contains(*sql, "cl.tenant_id = 'default-tenant' AND cl.is_deleted = false AND "+tt.wantWhere) {
+				t.Errorf("query %s does not select %s", *sql, tt.wantWhere)
+			}
+			if !strings.HasSuffix(*sql, tt.wantOrder) {
+				t.Errorf("query %s does not end in %s", *sql, tt.wantOrder)
+			}
+			if !strings.Contains(*sql, "c.is_deleted = false") || !strings.Contains(*sql, "l.is_deleted = false") {
+				t.Errorf("query %s joins deleted countries or languages", *sql)
+			}
+		})
+	}
+}
@@ -20,7 +20,7 @@ type CountryRepositoryInterface interface {
 	GetByCode(ctx context.Context, tenantID, code string) (*models.Country, error)
 	Create(ctx context.Context, tenantID string, country *models.Country) error
 	Update(ctx context.Context, tenantID string, country *models.Country) error
-	Delete(ctx context.Context, tenantID, code string) error
+	Delete(ctx context.Context, tenantID, code string, expectedVersion int) error
 	BulkCreate(ctx context.Context, tenantID string, countries []models.Country) error
 }
 
@@ -117,12 +117,16 @@ func (r *CountryRepositoryGORM) Update(ctx context.Context, tenantID string, cou
 	return nil
 }
 
-// Delete soft deletes a country
-func (r *CountryRepositoryGORM) Delete(ctx context.Context, tenantID, code string) error {
+// Delete soft deletes a country; a positive expectedVersion must match
+func (r *CountryRepositoryGORM) Delete(ctx context.Context, tenantID, code string, expectedVersion int) error {
 	now := time.Now()
 
-	result := r.db.WithContext(ctx).
-		Where("country_code = ? AND is_deleted = ?", code, false).
+	query := r.db.WithContext(ctx).
+		Where("country_code = ? AND is_deleted = ?", code, false)
+	if expectedVersion > 0 {
+		query = query.Where("version = ?", expectedVersion)
+	}
+	result := query.
 		Updates(map[string]interface{}{
 			"is_deleted":  true,
 			"deleted_at":  now,
@@ -135,7 +139,7 @@ func (r *CountryRepositoryGORM) Delete(ctx context.Context, tenantID, code strin
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("country not found")
+		return fmt.Errorf("country not found or version conflict")
 	}
 
 	return nil
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var currencyValidator = validation.NewCurrencyValidator()
+
+// checkCurrency validates the ISO 4217 codes and minor units of a currency
+func checkCurrency(_ context.Context, _ *Repository[models.Currency], _ string, _ uuid.UUID, currency *models.Currency) error {
+	result := currencyValidator.ValidateCurrency(currency.CurrencyCode, currency.CurrencyName, currency.NumericCode, currency.MinorUnits)
+	if err := result.Err(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountryCurrencyView is a country–currency link with the codes of both
+// sides
+type CountryCurrencyView struct {
+	models.CountryCurrency
+	CountryCode  string `json:"country_code"`
+	CountryName  string `json:"country_name"`
+	CurrencyCode string `json:"currency_code"`
+	CurrencyName string `json:"currency_name"`
+	MinorUnits   *int16 `json:"minor_units,omitempty"`
+}
+
+// checkCountryCurrency checks that both sides of a link exist and that its
+// period does not overlap another period of the same pair
+func checkCountryCurrency(ctx context.Context, r *Repository[models.CountryCurrency], tenantID string, id uuid.UUID, link *models.CountryCurrency) error {
+	if err := checkPeriod(link.ValidFrom, link.ValidTo); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", link.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+	if err := db.Model(&models.Currency{}).
+		Where("currency_id = ? AND tenant_id = ? AND is_deleted = ?", link.CurrencyID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve currency", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("currency_id", "currency not found")
+	}
+
+	if err := r.overlapping(ctx, tenantID, id, link.ValidFrom, link.ValidTo).
+		Where("country_id = ? AND currency_id = ?", link.CountryID, link.CurrencyID).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check country currency periods", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "the country already uses this currency during an overlapping period", nil)
+	}
+	return nil
+}
+
+// ForCountry returns the currencies of a country used on asOf, or during any
+// period when asOf is nil, ordered by currency code and period
+func (r *CountryCurrencyRepository) ForCountry(ctx context.Context, tenantID string, countryID uuid.UUID, asOf *time.Time) ([]CountryCurrencyView, error) {
+	return r.views(ctx, tenantID, "cc.country_id", countryID, asOf, "cu.currency_code, cc.valid_from")
+}
+
+// ForCurrency returns the countries using a currency on asOf, or during any
+// period when asOf is nil, ordered by country code and period
+func (r *CountryCurrencyRepository) ForCurrency(ctx context.Context, tenantID string, currencyID uuid.UUID, asOf *time.Time) ([]CountryCurrencyView, error) {
+	return r.views(ctx, tenantID, "cc.currency_id", currencyID, asOf, "c.country_code, cc.valid_from")
+}
+
+func (r *CountryCurrencyRepository) views(ctx context.Context, tenantID, column string, id uuid.UUID, asOf *time.Time, order string) ([]CountryCurrencyView, error) {
+	query := r.db.WithContext(ctx).
+		Table(r.schema.Table+" cc").
+		Select("cc.*, c.country_code, c.country_name, cu.currency_code, cu.currency_name, cu.minor_units").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = cc.country_id AND c.is_deleted = false").
+		Joins("JOIN "+models.Currency{}.TableName()+" cu ON cu.currency_id = cc.currency_id AND cu.is_deleted = false").
+		Where("cc.tenant_id = ? AND cc.is_deleted = ? AND "+column+" = ?", tenantID, false, id)
+	if asOf != nil {
+		date := asOf.Format(DateLayout)
+		query = query.Where("(cc.valid_from IS NULL OR cc.valid_from <= ?::date) AND (cc.valid_to IS NULL OR cc.valid_to > ?::date)", date, date)
+	}
+
+	var views []CountryCurrencyView
+	if err := query.Order(order).Scan(&views).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country currencies", err)
+	}
+	return views, nil
+}
+
+// dateArg formats an optional date as a query argument; nil is an open bound
+func dateArg(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(DateLayout)
+}
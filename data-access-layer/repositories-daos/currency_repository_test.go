@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestCheckCurrency(t *testing.T) {
+	two, eleven := int16(2), int16(11)
+	euro := int16(978)
+
+	tests := []struct {
+		name     string
+		currency models.Currency
+		wantErr  bool
+	}{
+		{"valid", models.Currency{CurrencyCode: "EUR", CurrencyName: "Euro", NumericCode: &euro, MinorUnits: &two}, false},
+		{"invalid code", models.Currency{CurrencyCode: "EURO", CurrencyName: "Euro"}, true},
+		{"invalid minor units", models.Currency{CurrencyCode: "EUR", CurrencyName: "Euro", MinorUnits: &eleven}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCurrency(context.Background(), nil, "default-tenant", uuid.Nil, &tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkCurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDateArg(t *testing.T) {
+	day := time.Date(2002, 1, 1, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    *time.Time
+		want interface{}
+	}{
+		{"open bound", nil, nil},
+		{"date", &day, "2002-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dateArg(tt.t); got != tt.want {
+				t.Errorf("dateArg() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
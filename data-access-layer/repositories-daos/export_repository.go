@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// RowWriter receives an export: the column names once, then the values of
+// every row in column order. The values slice is reused between rows.
+type RowWriter interface {
+	Columns(columns []string) error
+	Row(values []interface{}) error
+}
+
+// ExportOptions selects what an export contains
+type ExportOptions struct {
+	// AsOf restricts entities with a validity period to the rows valid on
+	// that day; nil means today
+	AsOf *time.Time
+	// Columns are the exported columns in output order; empty exports all
+	Columns []string
+}
+
+// ExportSource is a dataset that can be exported. Export streams the live
+// rows of a tenant one at a time, so memory does not grow with the table.
+type ExportSource struct {
+	Name string
+	// Columns are the exportable columns in their default order. Request
+	// metadata (IP, device, session and location of changes) is never
+	// exported.
+	Columns     []string
+	HasValidity bool
+	Export      func(ctx context.Context, tenantID string, opts ExportOptions, w RowWriter) error
+}
+
+// HasColumn reports whether column can be exported
+func (s ExportSource) HasColumn(column string) bool {
+	for _, c := range s.Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// selectColumns validates the requested columns, defaulting to all
+func (s ExportSource) selectColumns(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return s.Columns, nil
+	}
+	for _, column := range requested {
+		if !s.HasColumn(column) {
+			return nil, errors.NewValidationError("fields", fmt.Sprintf("unknown %s column %q", s.Name, column))
+		}
+	}
+	return requested, nil
+}
+
+// ExportSource exports the rows of model T as dataset name, in the default
+// sort order
+func (r *Repository[T]) ExportSource(name string) ExportSource {
+	source := ExportSource{Name: name, Columns: exportColumns(r.schema), HasValidity: r.HasValidity()}
+	source.Export = func(ctx context.Context, tenantID string, opts ExportOptions, w RowWriter) error {
+		columns, err := source.selectColumns(opts.Columns)
+		if err != nil {
+			return err
+		}
+		query, err := r.filtered(ctx, tenantID, nil, opts.AsOf, DeletedExclude)
+		if err != nil {
+			return err
+		}
+		order, err := r.effectiveSort(nil)
+		if err != nil {
+			return err
+		}
+		for _, s := range order {
+			query = query.Order(clauseOrder(s))
+		}
+		return streamRows[T](ctx, query, r.schema, name, columns, w)
+	}
+	return source
+}
+
+// CountryProfile is a country joined with its region, primary language and
+// the codes of its subdivisions and locales
+type CountryProfile struct {
+	models.Country
+	RegionCode          *string `json:"region_code,omitempty"`
+	RegionName          *string `json:"region_name,omitempty"`
+	PrimaryLanguageCode *string `json:"primary_language_code,omitempty"`
+	PrimaryLanguageName *string `json:"primary_language_name,omitempty"`
+	SubdivisionCount    int     `json:"subdivision_count"`
+	SubdivisionCodes    *string `json:"subdivision_codes,omitempty"`
+	LocaleCodes         *string `json:"locale_codes,omitempty"`
+}
+
+// countryProfileSQL selects the country profiles of a tenant valid on a day.
+// Subdivision and locale codes are comma separated.
+var countryProfileSQL = fmt.Sprintf(`
+	SELECT c.*,
+		r.region_code, r.region_name,
+		l.language_code AS primary_language_code, l.language_name AS primary_language_name,
+		COALESCE(s.subdivision_count, 0) AS subdivision_count, s.subdivision_codes,
+		lc.locale_codes
+	FROM %[1]s c
+	LEFT JOIN %[2]s r ON r.region_id = c.region_id AND r.is_deleted = false
+	LEFT JOIN %[3]s l ON l.language_id = c.primary_language_id AND l.is_deleted = false
+	LEFT JOIN LATERAL (
+		SELECT count(*) AS subdivision_count, string_agg(subdivision_code, ',' ORDER BY subdivision_code) AS subdivision_codes
+		FROM %[4]s
+		WHERE country_id = c.country_id AND tenant_id = c.tenant_id AND is_deleted = false
+		  AND (valid_from IS NULL OR valid_from <= @day::date) AND (valid_to IS NULL OR valid_to > @day::date)
+	) s ON true
+	LEFT JOIN LATERAL (
+		SELECT string_agg(locale_code, ',' ORDER BY locale_code) AS locale_codes
+		FROM %[5]s
+		WHERE country_id = c.country_id AND tenant_id = c.tenant_id AND is_deleted = false
+	) lc ON true
+	WHERE c.tenant_id = @tenant AND c.is_deleted = false
+	  AND (c.valid_from IS NULL OR c.valid_from <= @day::date) AND (c.valid_to IS NULL OR c.valid_to > @day::date)
+	ORDER BY c.country_code`,
+	models.Country{}.TableName(), models.Region{}.TableName(), models.Language{}.TableName(),
+	models.CountrySubdivision{}.TableName(), models.Locales{}.TableName())
+
+// ProfileExportSource exports the country profiles as dataset name
+func (r *CountryRepository) ProfileExportSource(name string) ExportSource {
+	s, err := parseSchema[CountryProfile](r.db)
+	if err != nil {
+		panic(fmt.Sprintf("repositories: cannot parse country profile: %v", err))
+	}
+	source := ExportSource{Name: name, Columns: exportColumns(s), HasValidity: true}
+	source.Export = func(ctx context.Context, tenantID string, opts ExportOptions, w RowWriter) error {
+		columns, err := source.selectColumns(opts.Columns)
+		if err != nil {
+			return err
+		}
+		day := time.Now()
+		if opts.AsOf != nil {
+			day = *opts.AsOf
+		}
+		query := r.db.WithContext(ctx).Raw(countryProfileSQL, map[string]interface{}{
+			"tenant": tenantID,
+			"day":    day.Format(DateLayout),
+		})
+		return streamRows[CountryProfile](ctx, query, s, name, columns, w)
+	}
+	return source
+}
+
+// exportColumns returns the columns of s in declaration order, leaving out
+// request metadata
+func exportColumns(s *schema.Schema) []string {
+	columns := make([]string, 0, len(s.DBNames))
+	for _, column := range s.DBNames {
+		if !requestMetadataColumn(column) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// requestMetadataColumn reports whether column records where a change came
+// from rather than reference data
+func requestMetadataColumn(column string) bool {
+	for _, suffix := range []string{"_ip", "_device", "_session", "_location"} {
+		if strings.HasSuffix(column, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// streamRows scans the rows of query into T one at a time and passes the
+// values of columns to w
+func streamRows[T any](ctx context.Context, query *gorm.DB, s *schema.Schema, name string, columns []string, w RowWriter) error {
+	fields := make([]*schema.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = s.FieldsByDBName[column]
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to export %s", name), err)
+	}
+	defer rows.Close()
+
+	if err := w.Columns(columns); err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	for rows.Next() {
+		var row T
+		if err := query.ScanRows(rows, &row); err != nil {
+			return errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to read %s export row", name), err)
+		}
+		rv := reflect.ValueOf(&row).Elem()
+		for i, field := range fields {
+			value, _ := field.ValueOf(ctx, rv)
+			values[i] = plainValue(value)
+		}
+		if err := w.Row(values); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to export %s", name), err)
+	}
+	return nil
+}
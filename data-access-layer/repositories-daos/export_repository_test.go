@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"reflect"
+	"testing"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestRegionExportColumns(t *testing.T) {
+	source := NewRepository[models.Region](nil, RegionSpec).ExportSource("regions")
+	want := []string{
+		"region_id", "region_code", "region_name", "region_type", "parent_region_id",
+		"is_active", "is_deleted", "tenant_id",
+		"created_at", "created_by", "updated_at", "updated_by", "deleted_at", "deleted_by",
+		"version",
+	}
+	if !reflect.DeepEqual(source.Columns, want) {
+		t.Errorf("Columns = %v, want %v", source.Columns, want)
+	}
+	if source.HasValidity {
+		t.Error("HasValidity = true for regions, want false")
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	source := ExportSource{Name: "regions", Columns: []string{"region_code", "region_name", "region_type"}}
+
+	tests := []struct {
+		name      string
+		requested []string
+		want      []string
+		wantErr   bool
+	}{
+		{"all by default", nil, []string{"region_code", "region_name", "region_type"}, false},
+		{"requested order is kept", []string{"region_name", "region_code"}, []string{"region_name", "region_code"}, false},
+		{"request metadata is not exportable", []string{"region_code", "created_ip"}, nil, true},
+		{"unknown column", []string{"population"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := source.selectColumns(tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
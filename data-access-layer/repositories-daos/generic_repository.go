@@ -0,0 +1,705 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// EntitySpec describes how a reference entity plugs into the generic repository
+type EntitySpec struct {
+	// Name is the singular entity name used in error messages, e.g. "region"
+	Name string
+	// CodeColumn is the natural key column used by GetByCode and Delete
+	CodeColumn string
+	// DefaultSort is applied when a list query does not specify an order
+	DefaultSort []SortField
+}
+
+// CheckFunc validates a row before Create or Update writes it and may set its
+// derived columns. repo runs on the write's transaction; id is uuid.Nil for
+// a row that does not exist yet.
+type CheckFunc[T any] func(ctx context.Context, repo *Repository[T], tenantID string, id uuid.UUID, entity *T) error
+
+// Repository is a tenant-scoped GORM repository shared by all reference entities.
+// Column names used in filters and sorts are validated against the model schema.
+// Every write is recorded in the entity history within the same transaction.
+type Repository[T any] struct {
+	db      *gorm.DB
+	spec    EntitySpec
+	schema  *schema.Schema
+	history *historyRecorder[T]
+	check   CheckFunc[T]
+}
+
+var schemaCache = &sync.Map{}
+
+// NewRepository creates a generic repository for model T.
+// It panics if T cannot be parsed as a GORM model.
+func NewRepository[T any](db *gorm.DB, spec EntitySpec) *Repository[T] {
+	s, err := parseSchema[T](db)
+	if err != nil {
+		panic(fmt.Sprintf("repositories: cannot parse %s model: %v", spec.Name, err))
+	}
+	if spec.CodeColumn != "" && s.LookUpField(spec.CodeColumn) == nil {
+		panic(fmt.Sprintf("repositories: %s has no column %q", spec.Name, spec.CodeColumn))
+	}
+
+	return &Repository[T]{
+		db:      db,
+		spec:    spec,
+		schema:  s,
+		history: newHistoryRecorder[T](s, spec.Name, spec.CodeColumn),
+	}
+}
+
+// WithCheck makes every Create and Update run check on the row it is about to
+// write. It returns r for use in constructors.
+func (r *Repository[T]) WithCheck(check CheckFunc[T]) *Repository[T] {
+	r.check = check
+	return r
+}
+
+// parseSchema parses model T with the naming strategy of db
+func parseSchema[T any](db *gorm.DB) (*schema.Schema, error) {
+	var namer schema.Namer = schema.NamingStrategy{}
+	if db != nil && db.Config != nil && db.NamingStrategy != nil {
+		namer = db.NamingStrategy
+	}
+	return schema.Parse(new(T), schemaCache, namer)
+}
+
+// WithTx returns a copy of the repository whose statements run on tx, so that
+// several writes commit or roll back together. Each write becomes a savepoint.
+func (r *Repository[T]) WithTx(tx *gorm.DB) *Repository[T] {
+	clone := *r
+	clone.db = tx
+	return &clone
+}
+
+// Spec returns the entity spec the repository was built with
+func (r *Repository[T]) Spec() EntitySpec {
+	return r.spec
+}
+
+// Columns returns the sorted database column names of the model
+func (r *Repository[T]) Columns() []string {
+	columns := append([]string(nil), r.schema.DBNames...)
+	sort.Strings(columns)
+	return columns
+}
+
+// HasColumn reports whether the model maps the given database column
+func (r *Repository[T]) HasColumn(column string) bool {
+	_, ok := r.schema.FieldsByDBName[column]
+	return ok
+}
+
+// HasValidity reports whether the model carries a valid_from/valid_to period
+func (r *Repository[T]) HasValidity() bool {
+	return r.HasColumn("valid_from") && r.HasColumn("valid_to")
+}
+
+// List returns a filtered, sorted page of rows for the tenant. Soft-deleted
+// rows are only included when opts.Deleted asks for them.
+func (r *Repository[T]) List(ctx context.Context, tenantID string, opts ListOptions) (*Page[T], error) {
+	query, err := r.filtered(ctx, tenantID, opts.Filters, opts.AsOf, opts.Deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{Limit: opts.pageSize(), Offset: opts.Offset}
+
+	if opts.IncludeTotal {
+		var total int64
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to count %ss", r.spec.Name), err)
+		}
+		page.Total = &total
+	}
+
+	page.Sort, err = r.effectiveSort(opts.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	backward := len(opts.Before) > 0
+	order := page.Sort
+	if backward {
+		order = reversed(page.Sort)
+	}
+	for _, s := range order {
+		query = query.Order(clauseOrder(s))
+	}
+
+	switch {
+	case backward:
+		cond, args, err := keysetCondition(order, opts.Before)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(cond, args...)
+		page.Offset = 0
+	case len(opts.After) > 0:
+		cond, args, err := keysetCondition(order, opts.After)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(cond, args...)
+		page.Offset = 0
+	case opts.Offset > 0:
+		query = query.Offset(opts.Offset)
+	}
+
+	var items []T
+	if err := query.Limit(page.Limit + 1).Find(&items).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to list %ss", r.spec.Name), err)
+	}
+
+	more := len(items) > page.Limit
+	if more {
+		items = items[:page.Limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+		page.HasPrev = more
+		page.HasMore = true
+	} else {
+		page.HasMore = more
+		page.HasPrev = len(opts.After) > 0 || opts.Offset > 0
+	}
+	page.Items = items
+
+	if len(items) > 0 {
+		if page.HasMore {
+			page.NextKey = r.keyOf(ctx, &items[len(items)-1], page.Sort)
+		}
+		if page.HasPrev {
+			page.PrevKey = r.keyOf(ctx, &items[0], page.Sort)
+		}
+	}
+
+	return page, nil
+}
+
+// Count returns the number of active rows for the tenant matching the filters
+func (r *Repository[T]) Count(ctx context.Context, tenantID string, filters []Filter) (int64, error) {
+	query, err := r.filtered(ctx, tenantID, filters, nil, DeletedExclude)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to count %ss", r.spec.Name), err)
+	}
+	return total, nil
+}
+
+// GetByCode retrieves a row by its natural key, or nil if it does not exist
+func (r *Repository[T]) GetByCode(ctx context.Context, tenantID, code string) (*T, error) {
+	if r.spec.CodeColumn == "" {
+		return nil, errors.NewValidationError("code", fmt.Sprintf("%s has no natural key", r.spec.Name))
+	}
+	return r.first(ctx, tenantID, r.spec.CodeColumn, code)
+}
+
+// GetByID retrieves a row by its primary key, or nil if it does not exist
+func (r *Repository[T]) GetByID(ctx context.Context, tenantID string, id uuid.UUID) (*T, error) {
+	return r.first(ctx, tenantID, r.primaryColumn(), id)
+}
+
+// GetByCodeAsOf retrieves a row by its natural key if it was valid on asOf
+func (r *Repository[T]) GetByCodeAsOf(ctx context.Context, tenantID, code string, asOf time.Time) (*T, error) {
+	if r.spec.CodeColumn == "" {
+		return nil, errors.NewValidationError("code", fmt.Sprintf("%s has no natural key", r.spec.Name))
+	}
+	return r.firstAsOf(ctx, tenantID, r.spec.CodeColumn, code, asOf)
+}
+
+// Create inserts a new row, stamping the primary key, tenant and audit fields,
+// after the repository's check accepts it
+func (r *Repository[T]) Create(ctx context.Context, tenantID string, entity *T) error {
+	now := time.Now()
+	if err := r.stamp(ctx, entity, map[string]interface{}{
+		r.primaryColumn(): uuid.New(),
+		"tenant_id":       tenantID,
+		"created_at":      now,
+		"updated_at":      now,
+		"is_active":       true,
+		"is_deleted":      false,
+		"version":         1,
+	}); err != nil {
+		return err
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.validate(ctx, tx, tenantID, uuid.Nil, entity); err != nil {
+			return err
+		}
+		if err := tx.Create(entity).Error; err != nil {
+			if isDuplicateKeyError(err) {
+				return errors.NewRepositoryError("DUPLICATE_KEY", fmt.Sprintf("%s already exists", r.spec.Name), err)
+			}
+			return errors.NewRepositoryError("CREATE_FAILED", fmt.Sprintf("Failed to create %s", r.spec.Name), err)
+		}
+		return r.history.record(ctx, tx, tenantID, HistoryCreate, nil, entity)
+	})
+	return err
+}
+
+// Update replaces the row whose natural key is code with entity and bumps its
+// version. Every column is written, zero values and NULLs included, except the
+// primary key, tenant, creation and deletion columns, so callers changing a
+// few fields start from the stored row. A positive expectedVersion must equal
+// the stored version. On success entity holds the updated row.
+func (r *Repository[T]) Update(ctx context.Context, tenantID, code string, entity *T, expectedVersion int) error {
+	if r.spec.CodeColumn == "" {
+		return errors.NewValidationError("code", fmt.Sprintf("%s has no natural key", r.spec.Name))
+	}
+	return r.update(ctx, tenantID, r.spec.CodeColumn, code, entity, expectedVersion)
+}
+
+// UpdateByID is Update for the row whose primary key is id
+func (r *Repository[T]) UpdateByID(ctx context.Context, tenantID string, id uuid.UUID, entity *T, expectedVersion int) error {
+	return r.update(ctx, tenantID, r.primaryColumn(), id, entity, expectedVersion)
+}
+
+func (r *Repository[T]) update(ctx context.Context, tenantID, column string, key interface{}, entity *T, expectedVersion int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := r.lockCurrent(ctx, tx, tenantID, column, key, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		// The row keeps its identity whatever primary key entity carries
+		id := r.idOf(ctx, before)
+		if err := r.stamp(ctx, entity, map[string]interface{}{r.primaryColumn(): id}); err != nil {
+			return err
+		}
+		if err := r.validate(ctx, tx, tenantID, id, entity); err != nil {
+			return err
+		}
+
+		stamps := map[string]interface{}{
+			"updated_at": time.Now(),
+			"version":    r.VersionOf(ctx, before) + 1,
+		}
+		// Cleared so that the audit callback records this request, not
+		// whoever wrote the row last
+		for _, suffix := range []string{"by", "ip", "device", "session", "location"} {
+			stamps["updated_"+suffix] = nil
+		}
+		if err := r.stamp(ctx, entity, stamps); err != nil {
+			return err
+		}
+
+		if err := tx.Model(new(T)).
+			Where(r.primaryColumn()+" = ? AND tenant_id = ?", id, tenantID).
+			Select("*").Omit(r.fixedColumns()...).
+			Updates(entity).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", fmt.Sprintf("Failed to update %s", r.spec.Name), err)
+		}
+
+		after, err := r.reload(tx, before)
+		if err != nil {
+			return err
+		}
+		*entity = *after
+		return r.history.record(ctx, tx, tenantID, HistoryUpdate, before, after)
+	})
+}
+
+// fixedColumns are the columns Update never writes: the primary key, the
+// tenant and the creation and deletion columns
+func (r *Repository[T]) fixedColumns() []string {
+	columns := []string{r.primaryColumn()}
+	for _, column := range r.schema.DBNames {
+		if column == "tenant_id" || column == "is_deleted" ||
+			strings.HasPrefix(column, "created_") || strings.HasPrefix(column, "deleted_") {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Delete soft deletes a row by its natural key. A positive expectedVersion
+// must equal the stored version.
+func (r *Repository[T]) Delete(ctx context.Context, tenantID, code string, expectedVersion int) error {
+	if r.spec.CodeColumn == "" {
+		return errors.NewValidationError("code", fmt.Sprintf("%s has no natural key", r.spec.Name))
+	}
+	return r.softDelete(ctx, tenantID, r.spec.CodeColumn, code, expectedVersion)
+}
+
+// DeleteByID soft deletes a row by its primary key. A positive
+// expectedVersion must equal the stored version.
+func (r *Repository[T]) DeleteByID(ctx context.Context, tenantID string, id uuid.UUID, expectedVersion int) error {
+	return r.softDelete(ctx, tenantID, r.primaryColumn(), id, expectedVersion)
+}
+
+func (r *Repository[T]) softDelete(ctx context.Context, tenantID, column string, key interface{}, expectedVersion int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := r.lockCurrent(ctx, tx, tenantID, column, key, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(new(T)).
+			Where(column+" = ? AND tenant_id = ?", key, tenantID).
+			Updates(map[string]interface{}{
+				"is_deleted": true,
+				"deleted_at": now,
+				"updated_at": now,
+				"version":    r.VersionOf(ctx, before) + 1,
+			}).Error; err != nil {
+			return errors.NewRepositoryError("DELETE_FAILED", fmt.Sprintf("Failed to delete %s", r.spec.Name), err)
+		}
+
+		after, err := r.reload(tx, before)
+		if err != nil {
+			return err
+		}
+		return r.history.record(ctx, tx, tenantID, HistoryDelete, before, after)
+	})
+}
+
+// validate runs the repository's check, if any, on tx
+func (r *Repository[T]) validate(ctx context.Context, tx *gorm.DB, tenantID string, id uuid.UUID, entity *T) error {
+	if r.check == nil {
+		return nil
+	}
+	return r.check(ctx, r.WithTx(tx), tenantID, id, entity)
+}
+
+// lockCurrent reads the live row for a write, locks it until the transaction
+// ends and checks it against the version the caller last saw
+func (r *Repository[T]) lockCurrent(ctx context.Context, tx *gorm.DB, tenantID, column string, key interface{}, expectedVersion int) (*T, error) {
+	return r.lockRow(ctx, tx, tenantID, column, key, false, expectedVersion)
+}
+
+// lockRow is lockCurrent for either live or soft-deleted rows
+func (r *Repository[T]) lockRow(ctx context.Context, tx *gorm.DB, tenantID, column string, key interface{}, deleted bool, expectedVersion int) (*T, error) {
+	entity, err := r.find(tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(column+" = ? AND tenant_id = ? AND is_deleted = ?", key, tenantID, deleted))
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		name := r.spec.Name
+		if deleted {
+			name = "deleted " + name
+		}
+		return nil, errors.NewRepositoryError("NOT_FOUND", fmt.Sprintf("%s not found", name), nil)
+	}
+	if version := r.VersionOf(ctx, entity); expectedVersion > 0 && version != expectedVersion {
+		return nil, errors.NewVersionConflictError(r.spec.Name, expectedVersion, version)
+	}
+	return entity, nil
+}
+
+// reload reads entity back by primary key after a write
+func (r *Repository[T]) reload(tx *gorm.DB, entity *T) (*T, error) {
+	id, _ := r.schema.PrioritizedPrimaryField.ValueOf(tx.Statement.Context, reflect.ValueOf(entity).Elem())
+	var fresh T
+	if err := tx.Where(r.primaryColumn()+" = ?", id).First(&fresh).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to reload %s", r.spec.Name), err)
+	}
+	return &fresh, nil
+}
+
+// VersionOf returns the optimistic-locking version of entity
+func (r *Repository[T]) VersionOf(ctx context.Context, entity *T) int {
+	version, _ := r.history.value(ctx, reflect.ValueOf(entity).Elem(), "version").(int)
+	return version
+}
+
+// Changes returns the tracked columns that differ between before and after.
+// before is nil for a row that does not exist yet.
+func (r *Repository[T]) Changes(ctx context.Context, before, after *T) map[string]FieldChange {
+	var beforeValue reflect.Value
+	if before != nil {
+		beforeValue = reflect.ValueOf(before).Elem()
+	}
+	return r.history.diff(ctx, beforeValue, reflect.ValueOf(after).Elem())
+}
+
+// Merge returns a copy of current with the non-zero columns of patch applied,
+// i.e. the row Update would store for patch
+func (r *Repository[T]) Merge(ctx context.Context, current, patch *T) *T {
+	merged := *current
+	mergedValue, patchValue := reflect.ValueOf(&merged).Elem(), reflect.ValueOf(patch).Elem()
+	for _, field := range r.schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if value, zero := field.ValueOf(ctx, patchValue); !zero {
+			_ = field.Set(ctx, mergedValue, value)
+		}
+	}
+	return &merged
+}
+
+func (r *Repository[T]) first(ctx context.Context, tenantID, column string, key interface{}) (*T, error) {
+	return r.find(r.db.WithContext(ctx).
+		Where(column+" = ? AND tenant_id = ? AND is_deleted = ?", key, tenantID, false))
+}
+
+func (r *Repository[T]) firstAsOf(ctx context.Context, tenantID, column string, key interface{}, asOf time.Time) (*T, error) {
+	query := r.db.WithContext(ctx).
+		Where(column+" = ? AND tenant_id = ? AND is_deleted = ?", key, tenantID, false)
+	query, err := r.validAt(query, &asOf)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(query)
+}
+
+func (r *Repository[T]) find(query *gorm.DB) (*T, error) {
+	var entity T
+	if err := query.First(&entity).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to retrieve %s", r.spec.Name), err)
+	}
+	return &entity, nil
+}
+
+// filtered builds the tenant-scoped base query with all filters, the
+// soft-delete scope and the valid-time restriction applied
+func (r *Repository[T]) filtered(ctx context.Context, tenantID string, filters []Filter, asOf *time.Time, deleted DeletedScope) (*gorm.DB, error) {
+	query := r.db.WithContext(ctx).Model(new(T)).Where("tenant_id = ?", tenantID)
+	switch deleted {
+	case DeletedExclude:
+		query = query.Where("is_deleted = ?", false)
+	case DeletedOnly:
+		query = query.Where("is_deleted = ?", true)
+	case DeletedInclude:
+	default:
+		return nil, errors.NewValidationError("include_deleted", "must be true, false or only")
+	}
+
+	query, err := r.validAt(query, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range filters {
+		if !r.HasColumn(f.Column) {
+			return nil, errors.NewValidationError(f.Column, fmt.Sprintf("unknown %s column", r.spec.Name))
+		}
+		cond, args, err := filterCondition(f)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(cond, args...)
+	}
+	return query, nil
+}
+
+// validAt restricts query to rows whose [valid_from, valid_to) period covers
+// asOf, or today when asOf is nil. Models without a period only accept nil.
+func (r *Repository[T]) validAt(query *gorm.DB, asOf *time.Time) (*gorm.DB, error) {
+	if !r.HasValidity() {
+		if asOf != nil {
+			return nil, errors.NewValidationError("as_of", fmt.Sprintf("%ss have no validity period", r.spec.Name))
+		}
+		return query, nil
+	}
+
+	day := time.Now()
+	if asOf != nil {
+		day = *asOf
+	}
+	date := day.Format(DateLayout)
+	return query.Where("(valid_from IS NULL OR valid_from <= ?::date) AND (valid_to IS NULL OR valid_to > ?::date)", date, date), nil
+}
+
+// overlapping counts the live rows other than id whose [valid_from, valid_to)
+// period overlaps [from, to). Checks narrow the query to the rows that must
+// not overlap, e.g. those of the same country and currency.
+func (r *Repository[T]) overlapping(ctx context.Context, tenantID string, id uuid.UUID, from, to *time.Time) *gorm.DB {
+	return r.db.WithContext(ctx).Model(new(T)).
+		Where("tenant_id = ? AND is_deleted = ? AND "+r.primaryColumn()+" <> ?", tenantID, false, id).
+		Where("daterange(valid_from, valid_to) && daterange(?::date, ?::date)", dateArg(from), dateArg(to))
+}
+
+// checkPeriod rejects a period that ends before it starts
+func checkPeriod(from, to *time.Time) error {
+	if from != nil && to != nil && !to.After(*from) {
+		return errors.NewValidationError("valid_to", "must be after valid_from")
+	}
+	return nil
+}
+
+// effectiveSort validates the requested order and appends the primary key as
+// a tie-breaker so that offset and keyset pagination are deterministic
+func (r *Repository[T]) effectiveSort(requested []SortField) ([]SortField, error) {
+	fields := requested
+	if len(fields) == 0 {
+		fields = r.spec.DefaultSort
+	}
+
+	pk := r.primaryColumn()
+	result := make([]SortField, 0, len(fields)+1)
+	hasPK := false
+	for _, s := range fields {
+		if !r.HasColumn(s.Column) {
+			return nil, errors.NewValidationError(s.Column, fmt.Sprintf("unknown %s column", r.spec.Name))
+		}
+		if s.Column == pk {
+			hasPK = true
+		}
+		result = append(result, s)
+	}
+	if !hasPK {
+		result = append(result, Asc(pk))
+	}
+	return result, nil
+}
+
+func (r *Repository[T]) primaryColumn() string {
+	return r.schema.PrioritizedPrimaryField.DBName
+}
+
+// keyOf extracts the keyset values of the given sort columns from entity
+func (r *Repository[T]) keyOf(ctx context.Context, entity *T, order []SortField) []interface{} {
+	rv := reflect.ValueOf(entity).Elem()
+	key := make([]interface{}, len(order))
+	for i, s := range order {
+		value, _ := r.schema.FieldsByDBName[s.Column].ValueOf(ctx, rv)
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				value = nil
+			} else {
+				value = v.Elem().Interface()
+			}
+		}
+		key[i] = value
+	}
+	return key
+}
+
+// stamp sets the given columns on entity, skipping columns the model lacks
+func (r *Repository[T]) stamp(ctx context.Context, entity *T, values map[string]interface{}) error {
+	rv := reflect.ValueOf(entity).Elem()
+	for column, value := range values {
+		field, ok := r.schema.FieldsByDBName[column]
+		if !ok {
+			continue
+		}
+		if err := field.Set(ctx, rv, value); err != nil {
+			return errors.NewRepositoryError("STAMP_FAILED", fmt.Sprintf("Failed to set %s.%s", r.spec.Name, column), err)
+		}
+	}
+	return nil
+}
+
+func filterCondition(f Filter) (string, []interface{}, error) {
+	switch f.Op {
+	case OpEq, "":
+		return f.Column + " = ?", []interface{}{f.Value}, nil
+	case OpNe:
+		return f.Column + " <> ?", []interface{}{f.Value}, nil
+	case OpGt:
+		return f.Column + " > ?", []interface{}{f.Value}, nil
+	case OpGte:
+		return f.Column + " >= ?", []interface{}{f.Value}, nil
+	case OpLt:
+		return f.Column + " < ?", []interface{}{f.Value}, nil
+	case OpLte:
+		return f.Column + " <= ?", []interface{}{f.Value}, nil
+	case OpIn:
+		if v := reflect.ValueOf(f.Value); v.Kind() != reflect.Slice || v.Len() == 0 {
+			return "", nil, errors.NewValidationError(f.Column, "'in' filter requires a non-empty list")
+		}
+		return f.Column + " IN ?", []interface{}{f.Value}, nil
+	case OpLike:
+		return f.Column + "::text ILIKE ?", []interface{}{f.Value}, nil
+	case OpIsNull:
+		isNull, ok := f.Value.(bool)
+		if !ok {
+			return "", nil, errors.NewValidationError(f.Column, "'null' filter requires a boolean")
+		}
+		if isNull {
+			return f.Column + " IS NULL", nil, nil
+		}
+		return f.Column + " IS NOT NULL", nil, nil
+	default:
+		return "", nil, errors.NewValidationError(f.Column, fmt.Sprintf("unsupported operator %q", f.Op))
+	}
+}
+
+// keysetCondition expands (c1, c2, ...) > (v1, v2, ...) honouring the
+// direction of every sort column. NULL keys follow PostgreSQL's default
+// ordering: last in ascending columns and first in descending ones.
+func keysetCondition(order []SortField, after []interface{}) (string, []interface{}, error) {
+	if len(after) != len(order) {
+		return "", nil, errors.NewValidationError("after", fmt.Sprintf("expected %d key values, got %d", len(order), len(after)))
+	}
+
+	var (
+		terms []string
+		args  []interface{}
+	)
+	for i, s := range order {
+		beyond, beyondArgs := keysetBeyond(s, after[i])
+		if beyond == "" {
+			continue
+		}
+		parts := make([]string, 0, i+1)
+		var partArgs []interface{}
+		for j := 0; j < i; j++ {
+			if after[j] == nil {
+				parts = append(parts, order[j].Column+" IS NULL")
+				continue
+			}
+			parts = append(parts, order[j].Column+" = ?")
+			partArgs = append(partArgs, after[j])
+		}
+		parts = append(parts, beyond)
+		args = append(append(args, partArgs...), beyondArgs...)
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+	if len(terms) == 0 {
+		return "FALSE", nil, nil
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", args, nil
+}
+
+// keysetBeyond returns the predicate selecting values of s that sort strictly
+// after value, or "" when nothing can (a NULL in an ascending column)
+func keysetBeyond(s SortField, value interface{}) (string, []interface{}) {
+	switch {
+	case value == nil && s.Desc:
+		return s.Column + " IS NOT NULL", nil
+	case value == nil:
+		return "", nil
+	case s.Desc:
+		return s.Column + " < ?", []interface{}{value}
+	default:
+		return "(" + s.Column + " > ? OR " + s.Column + " IS NULL)", []interface{}{value}
+	}
+}
+
+func clauseOrder(s SortField) string {
+	if s.Desc {
+		return s.Column + " DESC"
+	}
+	return s.Column + " ASC"
+}
@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestFilterCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		want    string
+		args    []interface{}
+		wantErr bool
+	}{
+		{"eq", Eq("region_code", "EU"), "region_code = ?", []interface{}{"EU"}, false},
+		{"empty op is eq", Filter{Column: "region_code", Value: "EU"}, "region_code = ?", []interface{}{"EU"}, false},
+		{"ne", Filter{Column: "region_type", Op: OpNe, Value: "CONTINENT"}, "region_type <> ?", []interface{}{"CONTINENT"}, false},
+		{"ge", Filter{Column: "version", Op: OpGte, Value: 2}, "version >= ?", []interface{}{2}, false},
+		{"in", Filter{Column: "region_code", Op: OpIn, Value: []string{"EU", "AS"}}, "region_code IN ?", []interface{}{[]string{"EU", "AS"}}, false},
+		{"in without values", Filter{Column: "region_code", Op: OpIn, Value: []string{}}, "", nil, true},
+		{"in with a scalar", Filter{Column: "region_code", Op: OpIn, Value: "EU"}, "", nil, true},
+		{"like", Filter{Column: "region_name", Op: OpLike, Value: "%eur%"}, "region_name::text ILIKE ?", []interface{}{"%eur%"}, false},
+		{"is null", Filter{Column: "parent_region_id", Op: OpIsNull, Value: true}, "parent_region_id IS NULL", nil, false},
+		{"is not null", Filter{Column: "parent_region_id", Op: OpIsNull, Value: false}, "parent_region_id IS NOT NULL", nil, false},
+		{"null without a boolean", Filter{Column: "parent_region_id", Op: OpIsNull, Value: "yes"}, "", nil, true},
+		{"unknown operator", Filter{Column: "region_code", Op: "regex", Value: "E."}, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := filterCondition(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filterCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("filterCondition() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("filterCondition() args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestEffectiveSort(t *testing.T) {
+	repo := NewRepository[models.Region](nil, RegionSpec)
+
+	tests := []struct {
+		name      string
+		requested []SortField
+		want      []SortField
+		wantErr   bool
+	}{
+		{"default sort gets the key as tie-breaker", nil, []SortField{Asc("region_name"), Asc("region_id")}, false},
+		{"requested sort gets the key as tie-breaker", []SortField{Desc("region_type")}, []SortField{Desc("region_type"), Asc("region_id")}, false},
+		{"sort on the key is kept as is", []SortField{Desc("region_id")}, []SortField{Desc("region_id")}, false},
+		{"unknown column", []SortField{Asc("population")}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.effectiveSort(tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("effectiveSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("effectiveSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	repo := NewRepository[models.Region](nil, RegionSpec)
+	parent := uuid.New()
+	current := &models.Region{
+		RegionID:       uuid.New(),
+		RegionCode:     "EU",
+		RegionName:     "Europe",
+		RegionType:     "CONTINENT",
+		ParentRegionID: &parent,
+		IsActive:       true,
+		Version:        3,
+	}
+
+	tests := []struct {
+		name  string
+		patch models.Region
+		want  func(r models.Region) models.Region
+	}{
+		{
+			"set columns replace stored values",
+			models.Region{RegionName: "European Union", RegionType: "UNION"},
+			func(r models.Region) models.Region {
+				r.RegionName, r.RegionType = "European Union", "UNION"
+				return r
+			},
+		},
+		{
+			"zero columns keep stored values",
+			models.Region{IsActive: false},
+			func(r models.Region) models.Region { return r },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := repo.Merge(context.Background(), current, &tt.patch)
+			if want := tt.want(*current); !reflect.DeepEqual(*got, want) {
+				t.Errorf("Merge() = %+v, want %+v", *got, want)
+			}
+			if current.RegionName != "Europe" {
+				t.Errorf("Merge() modified current")
+			}
+		})
+	}
+}
+
+func TestFixedColumns(t *testing.T) {
+	repo := NewRepository[models.Region](nil, RegionSpec)
+	fixed := make(map[string]bool)
+	for _, column := range repo.fixedColumns() {
+		fixed[column] = true
+	}
+
+	tests := []struct {
+		column string
+		fixed  bool
+	}{
+		{"region_id", true},
+		{"tenant_id", true},
+		{"is_deleted", true},
+		{"created_at", true},
+		{"created_by", true},
+		{"deleted_at", true},
+		{"deleted_location", true},
+		{"region_name", false},
+		{"parent_region_id", false},
+		{"is_active", false},
+		{"updated_at", false},
+		{"updated_by", false},
+		{"version", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			if fixed[tt.column] != tt.fixed {
+				t.Errorf("fixedColumns() contains %s = %v, want %v", tt.column, fixed[tt.column], tt.fixed)
+			}
+		})
+	}
+}
+
+func TestUpdateRequiresNaturalKey(t *testing.T) {
+	repo := NewRepository[models.CountryCurrency](nil, CountryCurrencySpec)
+	link := &models.CountryCurrency{}
+	if err := repo.Update(context.Background(), "default-tenant", "USD", link, 1); err == nil {
+		t.Error("Update() on an entity without natural key succeeded, want error")
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   []SortField
+		after   []interface{}
+		want    string
+		args    []interface{}
+		wantErr bool
+	}{
+		{
+			"ascending key",
+			[]SortField{Asc("region_name"), Asc("region_id")},
+			[]interface{}{"Europe", "r1"},
+			"(((region_name > ? OR region_name IS NULL)) OR (region_name = ? AND (region_id > ? OR region_id IS NULL)))",
+			[]interface{}{"Europe", "Europe", "r1"},
+			false,
+		},
+		{
+			"descending key",
+			[]SortField{Desc("region_name"), Asc("region_id")},
+			[]interface{}{"Europe", "r1"},
+			"((region_name < ?) OR (region_name = ? AND (region_id > ? OR region_id IS NULL)))",
+			[]interface{}{"Europe", "Europe", "r1"},
+			false,
+		},
+		{
+			"null in an ascending column sorts last",
+			[]SortField{Asc("region_name"), Asc("region_id")},
+			[]interface{}{nil, "r1"},
+			"((region_name IS NULL AND (region_id > ? OR region_id IS NULL)))",
+			[]interface{}{"r1"},
+			false,
+		},
+		{
+			"null in a descending column sorts first",
+			[]SortField{Desc("region_name")},
+			[]interface{}{nil},
+			"((region_name IS NOT NULL))",
+			nil,
+			false,
+		},
+		{
+			"nothing beyond a trailing null",
+			[]SortField{Asc("region_name")},
+			[]interface{}{nil},
+			"FALSE",
+			nil,
+			false,
+		},
+		{"key length mismatch", []SortField{Asc("region_name"), Asc("region_id")}, []interface{}{"Europe"}, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := keysetCondition(tt.order, tt.after)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("keysetCondition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("keysetCondition() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("keysetCondition() args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var groupingValidator = validation.NewGroupingValidator()
+
+// checkGrouping validates the code, name and type of a grouping
+func checkGrouping(_ context.Context, _ *Repository[models.Grouping], _ string, _ uuid.UUID, grouping *models.Grouping) error {
+	if err := groupingValidator.ValidateGrouping(grouping).Err(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GroupingMembershipView is a membership with the codes and names of the
+// grouping and the country
+type GroupingMembershipView struct {
+	models.GroupingMembership
+	GroupingCode string `json:"grouping_code"`
+	GroupingName string `json:"grouping_name"`
+	GroupingType string `json:"grouping_type"`
+	CountryCode  string `json:"country_code"`
+	CountryName  string `json:"country_name"`
+}
+
+// checkMembership validates a membership and checks that the grouping and
+// the country exist and that the period does not overlap another membership
+// of the country in the grouping
+func checkMembership(ctx context.Context, r *Repository[models.GroupingMembership], tenantID string, id uuid.UUID, membership *models.GroupingMembership) error {
+	if err := groupingValidator.ValidateMembership(membership).Err(); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Grouping{}).
+		Where("grouping_id = ? AND tenant_id = ? AND is_deleted = ?", membership.GroupingID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve grouping", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("grouping_id", "grouping not found")
+	}
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", membership.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+
+	if err := r.overlapping(ctx, tenantID, id, membership.ValidFrom, membership.ValidTo).
+		Where("grouping_id = ? AND country_id = ?", membership.GroupingID, membership.CountryID).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check grouping memberships", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "the country already belongs to the grouping during an overlapping period", nil)
+	}
+	return nil
+}
+
+// ForGrouping returns the memberships of a grouping held on asOf, or during
+// any period when asOf is nil, ordered by country code and period.
+// membershipType may be empty for memberships of all types.
+func (r *GroupingMembershipRepository) ForGrouping(ctx context.Context, tenantID string, groupingID uuid.UUID, asOf *time.Time, membershipType string) ([]GroupingMembershipView, error) {
+	if membershipType != "" && !groupingValidator.ValidMembershipType(membershipType) {
+		return nil, errors.NewValidationError("type", "must be MEMBER, ASSOCIATE, OBSERVER or CANDIDATE")
+	}
+	return r.views(ctx, tenantID, "gm.grouping_id", groupingID, asOf, membershipType, "c.country_code, gm.valid_from NULLS FIRST")
+}
+
+// ForCountry returns the memberships of a country held on asOf, or during
+// any period when asOf is nil, ordered by grouping code and period
+func (r *GroupingMembershipRepository) ForCountry(ctx context.Context, tenantID string, countryID uuid.UUID, asOf *time.Time) ([]GroupingMembershipView, error) {
+	return r.views(ctx, tenantID, "gm.country_id", countryID, asOf, "", "g.grouping_code, gm.valid_from NULLS FIRST")
+}
+
+func (r *GroupingMembershipRepository) views(ctx context.Context, tenantID, column string, id uuid.UUID, asOf *time.Time, membershipType, order string) ([]GroupingMembershipView, error) {
+	query := r.db.WithContext(ctx).
+		Table(r.schema.Table+" gm").
+		Select("gm.*, g.grouping_code, g.grouping_name, g.grouping_type, c.country_code, c.country_name").
+		Joins("JOIN "+models.Grouping{}.TableName()+" g ON g.grouping_id = gm.grouping_id AND g.is_deleted = false").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = gm.country_id AND c.is_deleted = false").
+		Where("gm.tenant_id = ? AND gm.is_deleted = ? AND "+column+" = ?", tenantID, false, id)
+	if asOf != nil {
+		date := asOf.Format(DateLayout)
+		query = query.Where("(gm.valid_from IS NULL OR gm.valid_from <= ?::date) AND (gm.valid_to IS NULL OR gm.valid_to > ?::date)", date, date)
+	}
+	if membershipType != "" {
+		query = query.Where("gm.membership_type = ?", membershipType)
+	}
+
+	var views []GroupingMembershipView
+	if err := query.Order(order).Scan(&views).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve grouping memberships", err)
+	}
+	return views, nil
+}
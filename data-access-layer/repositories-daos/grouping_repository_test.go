@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestGroupingMembershipViews(t *testing.T) {
+	db, sql := capturingDB(t)
+	repo := NewGroupingMembershipRepository(db)
+	id := uuid.New()
+	asOf := time.Date(2021, 6, 30, 23, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		query     func()
+		wantWhere []string
+		wantOrder string
+	}{
+		{
+			name:      "members of a grouping at any time",
+			query:     func() { repo.ForGrouping(ctx, "default-tenant", id, nil, "") },
+			wantWhere: []string{"gm.grouping_id = '" + id.String() + "'"},
+			wantOrder: "ORDER BY c.country_code, gm.valid_from NULLS FIRST",
+		},
+		{
+			name:  "members of one type on a date",
+			query: func() { repo.ForGrouping(ctx, "default-tenant", id, &asOf, models.MembershipTypeCandidate) },
+			wantWhere: []string{
+				"(gm.valid_to IS NULL OR gm.valid_to > '2021-06-30'::date)",
+				"gm.membership_type = 'CANDIDATE'",
+			},
+			wantOrder: "ORDER BY c.country_code, gm.valid_from NULLS FIRST",
+		},
+		{
+			name:      "groupings of a country",
+			query:     func() { repo.ForCountry(ctx, "default-tenant", id, &asOf) },
+			wantWhere: []string{"gm.country_id = '" + id.String() + "'", "gm.valid_from <= '2021-06-30'::date"},
+			wantOrder: "ORDER BY g.grouping_code, gm.valid_from NULLS FIRST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query()
+			for _, where := range tt.wantWhere {
+				if !strings.Contains(*sql, where) {
+					t.Errorf("query %s does not select %s", *sql, where)
+				}
+			}
+			if !strings.HasSuffix(*sql, tt.wantOrder) {
+				t.Errorf("query %s does not end in %s", *sql, tt.wantOrder)
+			}
+			if !strings.Contains(*sql, "g.is_deleted = false") || !strings.Contains(*sql, "c.is_deleted = false") {
+				t.Errorf("query %s joins deleted groupings or countries", *sql)
+			}
+		})
+	}
+}
+
+func TestGroupingChecksRejectBeforeQuerying(t *testing.T) {
+	joined := time.Date(2004, 5, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"invalid grouping", func() error {
+			return checkGrouping(ctx, nil, "default-tenant", uuid.New(), &models.Grouping{GroupingCode: "eu"})
+		}},
+		{"invalid membership", func() error {
+			return checkMembership(ctx, nil, "default-tenant", uuid.New(), &models.GroupingMembership{MembershipType: models.MembershipTypeMember, ValidFrom: &joined, ValidTo: &joined})
+		}},
+		{"unknown membership type filter", func() error {
+			_, err := NewGroupingMembershipRepository(nil).ForGrouping(ctx, "default-tenant", uuid.New(), nil, "PARTNER")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err == nil {
+				t.Error("succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+)
+
+// History operations
+const (
+	HistoryCreate  = "CREATE"
+	HistoryUpdate  = "UPDATE"
+	HistoryDelete  = "DELETE"
+	HistoryRestore = "RESTORE"
+	HistoryPurge   = "PURGE"
+)
+
+// FieldChange is the value of one column before and after a change
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// HistoryRepository reads the append-only entity history. Rows are written by
+// the entity repositories in the same transaction as the change itself.
+type HistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewHistoryRepository(db *gorm.DB) *HistoryRepository {
+	return &HistoryRepository{db: db}
+}
+
+// List returns the history of one entity, newest first. key matches either
+// the entity code or the entity ID, so deleted entities remain reachable.
+func (r *HistoryRepository) List(ctx context.Context, tenantID, entityType, key string, opts ListOptions) (*Page[models.EntityHistory], error) {
+	page := &Page[models.EntityHistory]{Limit: opts.pageSize(), Offset: opts.Offset}
+
+	query := r.db.WithContext(ctx).Model(&models.EntityHistory{}).
+		Where("tenant_id = ? AND entity_type = ?", tenantID, entityType)
+	if id, err := uuid.Parse(key); err == nil {
+		query = query.Where("entity_id = ?", id)
+	} else {
+		query = query.Where("entity_code = ?", key)
+	}
+
+	if opts.IncludeTotal {
+		var total int64
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to count history", err)
+		}
+		page.Total = &total
+	}
+
+	var items []models.EntityHistory
+	err := query.Order("changed_at DESC, version DESC").
+		Offset(opts.Offset).
+		Limit(page.Limit + 1).
+		Find(&items).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve history", err)
+	}
+
+	page.HasMore = len(items) > page.Limit
+	if page.HasMore {
+		items = items[:page.Limit]
+	}
+	page.HasPrev = opts.Offset > 0
+	page.Items = items
+	return page, nil
+}
+
+// historyRecorder writes history rows for model T
+type historyRecorder[T any] struct {
+	entityType string
+	codeColumn string
+	schema     *schema.Schema
+}
+
+func newHistoryRecorder[T any](s *schema.Schema, entityType, codeColumn string) *historyRecorder[T] {
+	return &historyRecorder[T]{entityType: entityType, codeColumn: codeColumn, schema: s}
+}
+
+// record stores one history row on tx. before is nil for creates; after is
+// the row as it is once the change is applied, or nil for purges.
+func (h *historyRecorder[T]) record(ctx context.Context, tx *gorm.DB, tenantID, operation string, before, after *T) error {
+	var beforeValue, afterValue reflect.Value
+	if before != nil {
+		beforeValue = reflect.ValueOf(before).Elem()
+	}
+	if after != nil {
+		afterValue = reflect.ValueOf(after).Elem()
+	}
+
+	diff := h.diff(ctx, beforeValue, afterValue)
+	if operation == HistoryRestore {
+		// Restoring clears the deletion audit; keep what it said
+		for column, value := range h.deletion(ctx, beforeValue) {
+			diff[column] = FieldChange{Before: value}
+		}
+	}
+	changes, err := json.Marshal(diff)
+	if err != nil {
+		return errors.NewRepositoryError("HISTORY_FAILED", fmt.Sprintf("Failed to encode %s changes", h.entityType), err)
+	}
+
+	entry := &models.EntityHistory{
+		TenantID:   tenantID,
+		EntityType: h.entityType,
+		Operation:  operation,
+		Changes:    changes,
+		ChangedAt:  time.Now(),
+	}
+
+	// A purged row is identified by its last state
+	current := afterValue
+	if !current.IsValid() {
+		current = beforeValue
+	}
+	if id, ok := h.value(ctx, current, h.schema.PrioritizedPrimaryField.DBName).(uuid.UUID); ok {
+		entry.EntityID = id
+	}
+	if code, ok := h.value(ctx, current, h.codeColumn).(string); ok && code != "" {
+		entry.EntityCode = &code
+	}
+	if version, ok := h.value(ctx, current, "version").(int); ok {
+		entry.Version = version
+	}
+	entry.ChangeReason = h.changeReason(ctx, current)
+	if actor := audit.Actor(ctx); actor != "" {
+		entry.Actor = &actor
+	}
+	if correlationID := logging.GetCorrelationID(ctx); correlationID != "" {
+		entry.CorrelationID = &correlationID
+	}
+
+	if err := tx.Create(entry).Error; err != nil {
+		return errors.NewRepositoryError("HISTORY_FAILED", fmt.Sprintf("Failed to record %s history", h.entityType), err)
+	}
+	return nil
+}
+
+// diff compares the tracked columns of two rows. An invalid before value
+// records every non-zero column of after as created; an invalid after value
+// records every non-zero column of before as removed.
+func (h *historyRecorder[T]) diff(ctx context.Context, before, after reflect.Value) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	for _, field := range h.schema.Fields {
+		if field.DBName == "" || !trackedColumn(field.DBName) {
+			continue
+		}
+
+		if !after.IsValid() {
+			if oldValue, zero := field.ValueOf(ctx, before); !zero {
+				changes[field.DBName] = FieldChange{Before: plainValue(oldValue)}
+			}
+			continue
+		}
+		newValue, zero := field.ValueOf(ctx, after)
+		if !before.IsValid() {
+			if !zero {
+				changes[field.DBName] = FieldChange{After: plainValue(newValue)}
+			}
+			continue
+		}
+
+		oldValue, _ := field.ValueOf(ctx, before)
+		if o, n := plainValue(oldValue), plainValue(newValue); !sameValue(o, n) {
+			changes[field.DBName] = FieldChange{Before: o, After: n}
+		}
+	}
+	return changes
+}
+
+// changeReason prefers the reason set on the entity over the request's
+func (h *historyRecorder[T]) changeReason(ctx context.Context, row reflect.Value) *string {
+	if reason, ok := h.value(ctx, row, "change_reason").(string); ok && reason != "" {
+		return &reason
+	}
+	if reason := audit.ChangeReason(ctx); reason != "" {
+		return &reason
+	}
+	return nil
+}
+
+// deletion returns the set deleted_* columns of a soft-deleted row: when it
+// was deleted and by whom. The request metadata of the deletion stays out
+func (h *historyRecorder[T]) deletion(ctx context.Context, row reflect.Value) map[string]interface{} {
+	deletion := make(map[string]interface{})
+	for _, field := range h.schema.Fields {
+		if !strings.HasPrefix(field.DBName, "deleted_") || requestMetadataColumn(field.DBName) {
+			continue
+		}
+		if value, zero := field.ValueOf(ctx, row); !zero {
+			deletion[field.DBName] = plainValue(value)
+		}
+	}
+	return deletion
+}
+
+func (h *historyRecorder[T]) value(ctx context.Context, row reflect.Value, column string) interface{} {
+	field, ok := h.schema.FieldsByDBName[column]
+	if !ok {
+		return nil
+	}
+	value, _ := field.ValueOf(ctx, row)
+	return plainValue(value)
+}
+
+// trackedColumn excludes bookkeeping columns that change on every write
+func trackedColumn(column string) bool {
+	switch column {
+	case "tenant_id", "version", "change_reason", "audit_trail":
+		return false
+	}
+	return !strings.HasPrefix(column, "created_") &&
+		!strings.HasPrefix(column, "updated_") &&
+		!strings.HasPrefix(column, "deleted_")
+}
+
+// plainValue dereferences pointers so that diffs hold values, not addresses
+func plainValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr {
+		return value
+	}
+	if v.IsNil() {
+		return nil
+	}
+	return v.Elem().Interface()
+}
+
+func sameValue(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	if ja, ok := a.(json.RawMessage); ok {
+		jb, ok := b.(json.RawMessage)
+		return ok && bytes.Equal(ja, jb)
+	}
+	return reflect.DeepEqual(a, b)
+}
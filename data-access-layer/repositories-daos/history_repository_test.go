@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestTrackedColumn(t *testing.T) {
+	tests := []struct {
+		column  string
+		tracked bool
+	}{
+		{"region_name", true},
+		{"parent_region_id", true},
+		{"is_active", true},
+		{"is_deleted", true},
+		{"valid_from", true},
+		{"tenant_id", false},
+		{"version", false},
+		{"change_reason", false},
+		{"audit_trail", false},
+		{"created_at", false},
+		{"updated_by", false},
+		{"deleted_session", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			if got := trackedColumn(tt.column); got != tt.tracked {
+				t.Errorf("trackedColumn(%q) = %v, want %v", tt.column, got, tt.tracked)
+			}
+		})
+	}
+}
+
+func TestHistoryDiff(t *testing.T) {
+	repo := NewRepository[models.Region](nil, RegionSpec)
+	parent := uuid.New()
+	now := time.Now()
+	id := uuid.New()
+	europe := models.Region{
+		RegionID:       id,
+		RegionCode:     "EU",
+		RegionName:     "Europe",
+		RegionType:     "CONTINENT",
+		ParentRegionID: &parent,
+		IsActive:       true,
+		TenantID:       "default-tenant",
+		UpdatedAt:      &now,
+		Version:        3,
+	}
+	renamed := europe
+	renamed.RegionName = "European Union"
+	later := now.Add(time.Minute)
+	renamed.UpdatedAt = &later
+	renamed.Version = 4
+	detached := europe
+	detached.ParentRegionID = nil
+	detached.IsActive = false
+
+	tests := []struct {
+		name   string
+		before *models.Region
+		after  *models.Region
+		want   map[string]FieldChange
+	}{
+		{
+			name:   "changed column only",
+			before: &europe,
+			after:  &renamed,
+			want:   map[string]FieldChange{"region_name": {Before: "Europe", After: "European Union"}},
+		},
+		{
+			name:   "cleared pointer and false boolean",
+			before: &europe,
+			after:  &detached,
+			want: map[string]FieldChange{
+				"parent_region_id": {Before: parent, After: nil},
+				"is_active":        {Before: true, After: false},
+			},
+		},
+		{
+			name:   "no change",
+			before: &europe,
+			after:  &europe,
+			want:   map[string]FieldChange{},
+		},
+		{
+			name:  "create records the set columns",
+			after: &detached,
+			want: map[string]FieldChange{
+				"region_id":   {After: id},
+				"region_code": {After: "EU"},
+				"region_name": {After: "Europe"},
+				"region_type": {After: "CONTINENT"},
+			},
+		},
+		{
+			name:   "purge records the set columns",
+			before: &europe,
+			want: map[string]FieldChange{
+				"region_id":        {Before: id},
+				"region_code":      {Before: "EU"},
+				"region_name":      {Before: "Europe"},
+				"region_type":      {Before: "CONTINENT"},
+				"parent_region_id": {Before: parent},
+				"is_active":        {Before: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before, after reflect.Value
+			if tt.before != nil {
+				before = reflect.ValueOf(tt.before).Elem()
+			}
+			if tt.after != nil {
+				after = reflect.ValueOf(tt.after).Elem()
+			}
+			got := repo.history.diff(context.Background(), before, after)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameValue(t *testing.T) {
+	instant := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		a, b interface{}
+		want bool
+	}{
+		{"same instant in another zone", instant, instant.In(time.FixedZone("CET", 3600)), true},
+		{"other instant", instant, instant.Add(time.Second), false},
+		{"time and nil", instant, nil, false},
+		{"same json", json.RawMessage(`{"os":"ios"}`), json.RawMessage(`{"os":"ios"}`), true},
+		{"other json", json.RawMessage(`{"os":"ios"}`), json.RawMessage(`{"os":"android"}`), false},
+		{"same string", "EU", "EU", true},
+		{"nil and nil", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameValue(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
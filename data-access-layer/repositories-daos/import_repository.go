@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// Import actions of an import plan
+const (
+	ImportCreate     = "create"
+	ImportUpdate     = "update"
+	ImportDeactivate = "deactivate"
+)
+
+// SubdivisionRecord is a subdivision together with the natural keys of its
+// country and parent, as read from an import file or joined from the database
+type SubdivisionRecord struct {
+	models.CountrySubdivision
+	CountryCode string `json:"country_code"`
+	ParentCode  string `json:"parent_code,omitempty"`
+}
+
+// ImportChange is one planned change of an import, matched on the natural key.
+// ID and Version identify the existing row of updates and deactivations; Patch
+// holds the row written by creates and updates, i.e. the stored row with the
+// imported columns applied for an update.
+type ImportChange[T any] struct {
+	Action  string                 `json:"action"`
+	Key     string                 `json:"key"`
+	ID      *uuid.UUID             `json:"id,omitempty"`
+	Version int                    `json:"version,omitempty"`
+	Changes map[string]FieldChange `json:"changes,omitempty"`
+	Patch   *T                     `json:"-"`
+}
+
+// ImportPlan is the dry-run diff of an import. Subdivisions are ordered so
+// that parents precede their children.
+type ImportPlan struct {
+	Countries    []ImportChange[models.Country]    `json:"countries"`
+	Subdivisions []ImportChange[SubdivisionRecord] `json:"subdivisions"`
+}
+
+// ImportRepository reads the rows an import is matched against and applies
+// import plans
+type ImportRepository struct {
+	db           *gorm.DB
+	countries    *CountryRepository
+	subdivisions *SubdivisionRepository
+}
+
+func NewImportRepository(db *gorm.DB) *ImportRepository {
+	return &ImportRepository{
+		db:           db,
+		countries:    NewCountryRepository(db),
+		subdivisions: NewSubdivisionRepository(db),
+	}
+}
+
+// Countries returns the countries of a tenant that are not deleted, active or
+// not
+func (r *ImportRepository) Countries(ctx context.Context, tenantID string) ([]models.Country, error) {
+	var countries []models.Country
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND is_deleted = ?", tenantID, false).
+		Order("country_code").
+		Find(&countries).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve countries for import", err)
+	}
+	return countries, nil
+}
+
+// Subdivisions returns the subdivisions of a tenant that are not deleted,
+// with the codes of their country and parent
+func (r *ImportRepository) Subdivisions(ctx context.Context, tenantID string) ([]SubdivisionRecord, error) {
+	var records []SubdivisionRecord
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT s.*, c.country_code, COALESCE(p.subdivision_code, '') AS parent_code
+		FROM `+r.subdivisions.schema.Table+` s
+		JOIN `+r.countries.schema.Table+` c ON c.country_id = s.country_id
+		LEFT JOIN `+r.subdivisions.schema.Table+` p ON p.subdivision_id = s.parent_subdivision_id
+		WHERE s.tenant_id = ? AND s.is_deleted = false
+		ORDER BY s.subdivision_code`, tenantID).
+		Scan(&records).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve subdivisions for import", err)
+	}
+	return records, nil
+}
+
+// Apply executes a plan in one transaction. Every change is recorded in the
+// entity history and raises a created, updated or deactivated event; any
+// failure rolls the whole import back.
+func (r *ImportRepository) Apply(ctx context.Context, tenantID string, plan *ImportPlan) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		countries := r.countries.Repository.WithTx(tx)
+		for _, change := range plan.Countries {
+			var country *models.Country
+			var action string
+			switch change.Action {
+			case ImportCreate:
+				country, action = change.Patch, EventCreated
+				if err := countries.Create(ctx, tenantID, country); err != nil {
+					return err
+				}
+			case ImportUpdate:
+				country, action = change.Patch, EventUpdated
+				if err := countries.UpdateByID(ctx, tenantID, *change.ID, country, change.Version); err != nil {
+					return err
+				}
+			case ImportDeactivate:
+				var err error
+				action = EventDeactivated
+				if country, err = countries.SetActive(ctx, tenantID, *change.ID, false, change.Version); err != nil {
+					return err
+				}
+			default:
+				return errors.NewValidationError("action", fmt.Sprintf("unknown import action %q", change.Action))
+			}
+			if err := countries.publish(ctx, tx, tenantID, action, country); err != nil {
+				return err
+			}
+		}
+
+		if len(plan.Subdivisions) == 0 {
+			return nil
+		}
+		return r.applySubdivisions(ctx, tx, tenantID, plan.Subdivisions)
+	})
+}
+
+// applySubdivisions resolves country and parent codes to IDs on tx, so that
+// rows created earlier in the same import can be referenced
+func (r *ImportRepository) applySubdivisions(ctx context.Context, tx *gorm.DB, tenantID string, changes []ImportChange[SubdivisionRecord]) error {
+	subdivisions := r.subdivisions.WithTx(tx)
+
+	var countries []models.Country
+	if err := tx.Select("country_id", "country_code").
+		Where("tenant_id = ? AND is_deleted = ?", tenantID, false).
+		Find(&countries).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to resolve import countries", err)
+	}
+	countryIDs := make(map[string]uuid.UUID, len(countries))
+	for _, c := range countries {
+		countryIDs[c.CountryCode] = c.CountryID
+	}
+
+	var existing []models.CountrySubdivision
+	if err := tx.Select("subdivision_id", "subdivision_code").
+		Where("tenant_id = ? AND is_deleted = ?", tenantID, false).
+		Find(&existing).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to resolve import subdivisions", err)
+	}
+	subdivisionIDs := make(map[string]uuid.UUID, len(existing))
+	for _, s := range existing {
+		subdivisionIDs[s.SubdivisionCode] = s.SubdivisionID
+	}
+
+	for _, change := range changes {
+		var subdivision *models.CountrySubdivision
+		var action string
+		switch change.Action {
+		case ImportCreate, ImportUpdate:
+			record := change.Patch
+			subdivision = &record.CountrySubdivision
+			countryID, ok := countryIDs[record.CountryCode]
+			if !ok {
+				return errors.NewValidationError("country_code", fmt.Sprintf("subdivision %s: unknown country %s", change.Key, record.CountryCode))
+			}
+			subdivision.CountryID = countryID
+			if record.ParentCode != "" {
+				parentID, ok := subdivisionIDs[record.ParentCode]
+				if !ok {
+					return errors.NewValidationError("parent_code", fmt.Sprintf("subdivision %s: unknown parent %s", change.Key, record.ParentCode))
+				}
+				subdivision.ParentSubdivisionID = &parentID
+			}
+
+			if change.Action == ImportCreate {
+				action = EventCreated
+				if err := subdivisions.Create(ctx, tenantID, subdivision); err != nil {
+					return err
+				}
+				subdivisionIDs[subdivision.SubdivisionCode] = subdivision.SubdivisionID
+			} else {
+				action = EventUpdated
+				if err := subdivisions.UpdateByID(ctx, tenantID, *change.ID, subdivision, change.Version); err != nil {
+					return err
+				}
+			}
+		case ImportDeactivate:
+			var err error
+			action = EventDeactivated
+			if subdivision, err = subdivisions.SetActive(ctx, tenantID, *change.ID, false, change.Version); err != nil {
+				return err
+			}
+		default:
+			return errors.NewValidationError("action", fmt.Sprintf("unknown import action %q", change.Action))
+		}
+		if err := subdivisions.publish(ctx, tx, tenantID, action, subdivision); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publish raises a domain event for entity on tx
+func (r *Repository[T]) publish(ctx context.Context, tx *gorm.DB, tenantID, action string, entity *T) error {
+	return publishEvent(ctx, tx, tenantID, r.spec.Name, action, r.idOf(ctx, entity), r.eventData(ctx, entity))
+}
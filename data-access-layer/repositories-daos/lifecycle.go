@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// Restore undeletes a soft-deleted row by its natural key and returns it. A
+// positive expectedVersion must equal the stored version.
+func (r *Repository[T]) Restore(ctx context.Context, tenantID, code string, expectedVersion int) (*T, error) {
+	if r.spec.CodeColumn == "" {
+		return nil, errors.NewValidationError("code", fmt.Sprintf("%s has no natural key", r.spec.Name))
+	}
+	return r.restore(ctx, tenantID, r.spec.CodeColumn, code, expectedVersion)
+}
+
+// RestoreByID undeletes a soft-deleted row by its primary key and returns it
+func (r *Repository[T]) RestoreByID(ctx context.Context, tenantID string, id uuid.UUID, expectedVersion int) (*T, error) {
+	return r.restore(ctx, tenantID, r.primaryColumn(), id, expectedVersion)
+}
+
+// Purge permanently removes a soft-deleted row by its natural key. Live rows
+// must be deleted first. The history of the row is kept.
+func (r *Repository[T]) Purge(ctx context.Context, tenantID, code string, expectedVersion int) error {
+	if r.spec.CodeColumn == "" {
+		return errors.NewValidationError("code", fmt.Sprintf("%s has no natural key", r.spec.Name))
+	}
+	return r.purge(ctx, tenantID, r.spec.CodeColumn, code, expectedVersion)
+}
+
+// PurgeByID permanently removes a soft-deleted row by its primary key
+func (r *Repository[T]) PurgeByID(ctx context.Context, tenantID string, id uuid.UUID, expectedVersion int) error {
+	return r.purge(ctx, tenantID, r.primaryColumn(), id, expectedVersion)
+}
+
+// SetActive activates or deactivates a live row by its primary key and
+// returns it. A positive expectedVersion must equal the stored version.
+func (r *Repository[T]) SetActive(ctx context.Context, tenantID string, id uuid.UUID, active bool, expectedVersion int) (*T, error) {
+	var updated *T
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := r.lockCurrent(ctx, tx, tenantID, r.primaryColumn(), id, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(new(T)).
+			Where(r.primaryColumn()+" = ? AND tenant_id = ?", id, tenantID).
+			Updates(map[string]interface{}{
+				"is_active":  active,
+				"updated_at": time.Now(),
+				"version":    r.VersionOf(ctx, before) + 1,
+			}).Error; err != nil {
+			return errors.NewRepositoryError("UPDATE_FAILED", fmt.Sprintf("Failed to update %s", r.spec.Name), err)
+		}
+
+		after, err := r.reload(tx, before)
+		if err != nil {
+			return err
+		}
+		updated = after
+		return r.history.record(ctx, tx, tenantID, HistoryUpdate, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *Repository[T]) restore(ctx context.Context, tenantID, column string, key interface{}, expectedVersion int) (*T, error) {
+	var restored *T
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := r.lockRow(ctx, tx, tenantID, column, key, true, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		values := map[string]interface{}{
+			"is_deleted": false,
+			"updated_at": time.Now(),
+			"version":    r.VersionOf(ctx, before) + 1,
+		}
+		// Clear deleted_at and the deleted_* audit columns; the history row
+		// and the restored event keep their values
+		for _, name := range r.schema.DBNames {
+			if strings.HasPrefix(name, "deleted_") {
+				values[name] = nil
+			}
+		}
+
+		if err := tx.Model(new(T)).
+			Where(column+" = ? AND tenant_id = ?", key, tenantID).
+			Updates(values).Error; err != nil {
+			if isDuplicateKeyError(err) {
+				return errors.NewRepositoryError("DUPLICATE_KEY", fmt.Sprintf("an active %s with the same key exists", r.spec.Name), err)
+			}
+			return errors.NewRepositoryError("RESTORE_FAILED", fmt.Sprintf("Failed to restore %s", r.spec.Name), err)
+		}
+
+		after, err := r.reload(tx, before)
+		if err != nil {
+			return err
+		}
+		if err := r.history.record(ctx, tx, tenantID, HistoryRestore, before, after); err != nil {
+			return err
+		}
+		restored = after
+		data := r.eventData(ctx, after)
+		if deletion := r.history.deletion(ctx, reflect.ValueOf(before).Elem()); len(deletion) > 0 {
+			data["deleted"] = deletion
+		}
+		return publishEvent(ctx, tx, tenantID, r.spec.Name, EventRestored, r.idOf(ctx, after), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+func (r *Repository[T]) purge(ctx context.Context, tenantID, column string, key interface{}, expectedVersion int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := r.lockRow(ctx, tx, tenantID, column, key, true, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		id := r.idOf(ctx, before)
+		if err := tx.Where(r.primaryColumn()+" = ?", id).Delete(new(T)).Error; err != nil {
+			if isConstraintViolationError(err) {
+				return errors.NewRepositoryError("CONSTRAINT_VIOLATION", fmt.Sprintf("%s is still referenced by other records", r.spec.Name), err)
+			}
+			return errors.NewRepositoryError("PURGE_FAILED", fmt.Sprintf("Failed to purge %s", r.spec.Name), err)
+		}
+
+		if err := r.history.record(ctx, tx, tenantID, HistoryPurge, before, nil); err != nil {
+			return err
+		}
+		return publishEvent(ctx, tx, tenantID, r.spec.Name, EventPurged, id, r.eventData(ctx, before))
+	})
+}
+
+func (r *Repository[T]) idOf(ctx context.Context, entity *T) uuid.UUID {
+	id, _ := r.history.value(ctx, reflect.ValueOf(entity).Elem(), r.primaryColumn()).(uuid.UUID)
+	return id
+}
+
+// eventData identifies entity in a lifecycle event payload
+func (r *Repository[T]) eventData(ctx context.Context, entity *T) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":      r.idOf(ctx, entity),
+		"version": r.VersionOf(ctx, entity),
+	}
+	if r.spec.CodeColumn != "" {
+		data["code"] = r.history.value(ctx, reflect.ValueOf(entity).Elem(), r.spec.CodeColumn)
+	}
+	return data
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestFilteredDeletedScope(t *testing.T) {
+	db := dryRunDB(t)
+	repo := NewRepository[models.Region](db, RegionSpec)
+	const table = `SELECT * FROM "domain_reference_master_geopolitical"."regions" WHERE tenant_id = 'default-tenant'`
+
+	tests := []struct {
+		name    string
+		scope   DeletedScope
+		want    string
+		wantErr bool
+	}{
+		{"live rows by default", DeletedExclude, table + ` AND is_deleted = false`, false},
+		{"deleted rows only", DeletedOnly, table + ` AND is_deleted = true`, false},
+		{"live and deleted rows", DeletedInclude, table, false},
+		{"unknown scope", DeletedScope("yes"), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := repo.filtered(context.Background(), "default-tenant", nil, nil, tt.scope)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filtered() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := query.Session(&gorm.Session{}).Find(&[]models.Region{}).Statement
+			if sql := db.Dialector.Explain(got.SQL.String(), got.Vars...); sql != tt.want {
+				t.Errorf("filtered() = %s, want %s", sql, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeletion(t *testing.T) {
+	repo := NewRepository[models.Region](nil, RegionSpec)
+	deletedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	deletedBy := uuid.New()
+	deletedIP := net.ParseIP("203.0.113.7")
+	deletedSession := uuid.New()
+	device := json.RawMessage(`{"agent":"curl"}`)
+	location := json.RawMessage(`{"country":"DE"}`)
+
+	tests := []struct {
+		name   string
+		region models.Region
+		want   map[string]interface{}
+	}{
+		{"live row", models.Region{RegionCode: "EU"}, map[string]interface{}{}},
+		{
+			"soft-deleted row",
+			models.Region{RegionCode: "EU", IsDeleted: true, DeletedAt: &deletedAt, DeletedBy: &deletedBy},
+			map[string]interface{}{"deleted_at": deletedAt, "deleted_by": deletedBy},
+		},
+		{
+			"row deleted with request metadata",
+			models.Region{
+				RegionCode: "EU", IsDeleted: true, DeletedAt: &deletedAt, DeletedBy: &deletedBy,
+				DeletedIP: &deletedIP, DeletedDevice: &device, DeletedSession: &deletedSession, DeletedLocation: &location,
+			},
+			map[string]interface{}{"deleted_at": deletedAt, "deleted_by": deletedBy},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := repo.history.deletion(context.Background(), reflect.ValueOf(&tt.region).Elem())
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deletion() = %v, want %v", got, tt.want)
+			}
+			for _, column := range []string{"deleted_ip", "deleted_device", "deleted_session", "deleted_location"} {
+				if _, ok := got[column]; ok {
+					t.Errorf("deletion() records %s", column)
+				}
+			}
+		})
+	}
+}
+
+func TestEventData(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		want map[string]interface{}
+	}{
+		{
+			"entity with a natural key",
+			NewRepository[models.Region](nil, RegionSpec).eventData(context.Background(), &models.Region{RegionID: id, RegionCode: "EU", Version: 2}),
+			map[string]interface{}{"id": id, "version": 2, "code": "EU"},
+		},
+		{
+			"entity without a natural key",
+			NewRepository[models.CountryCurrency](nil, CountryCurrencySpec).eventData(context.Background(), &models.CountryCurrency{CountryCurrencyID: id, Version: 1}),
+			map[string]interface{}{"id": id, "version": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.data, tt.want) {
+				t.Errorf("eventData() = %v, want %v", tt.data, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventType(t *testing.T) {
+	tests := []struct {
+		entity, action, want string
+	}{
+		{"subdivision", EventRestored, "geo.subdivision.restored.v1"},
+		{"country", EventPurged, "geo.country.purged.v1"},
+		{"region", EventDeactivated, "geo.region.deactivated.v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := EventType(tt.entity, tt.action); got != tt.want {
+				t.Errorf("EventType() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
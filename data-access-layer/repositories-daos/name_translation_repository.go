@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// translatedEntities are the entities whose names can be translated, by
+// entity type, with their table and ID column
+var translatedEntities = map[string]struct{ table, idColumn string }{
+	CountrySpec.Name:     {models.Country{}.TableName(), "country_id"},
+	RegionSpec.Name:      {models.Region{}.TableName(), "region_id"},
+	LanguageSpec.Name:    {models.Language{}.TableName(), "language_id"},
+	SubdivisionSpec.Name: {models.CountrySubdivision{}.TableName(), "subdivision_id"},
+}
+
+var nameTranslationValidator = validation.NewNameTranslationValidator(
+	CountrySpec.Name, RegionSpec.Name, LanguageSpec.Name, SubdivisionSpec.Name)
+
+// checkNameTranslation validates a translation and checks that its entity
+// exists and has no other name in the locale
+func checkNameTranslation(ctx context.Context, r *Repository[models.NameTranslation], tenantID string, id uuid.UUID, translation *models.NameTranslation) error {
+	if err := nameTranslationValidator.ValidateNameTranslation(translation).Err(); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	entity := translatedEntities[translation.EntityType]
+	var found int64
+	if err := db.Table(entity.table).
+		Where(entity.idColumn+" = ? AND tenant_id = ? AND is_deleted = ?", translation.EntityID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve "+translation.EntityType, err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("entity_id", translation.EntityType+" not found")
+	}
+	if err := db.Model(new(models.NameTranslation)).
+		Where("tenant_id = ? AND entity_type = ? AND entity_id = ? AND locale_code = ? AND is_deleted = ? AND name_translation_id <> ?",
+			tenantID, translation.EntityType, translation.EntityID, translation.LocaleCode, false, id).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check name translations", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the "+translation.EntityType+" already has a name in this locale", nil)
+	}
+	return nil
+}
+
+// Lookup returns the active translations of entities of one type in the
+// first locale of chain each entity has a name in, by entity ID. Entities
+// without a name in any locale of chain are missing from the result.
+func (r *NameTranslationRepository) Lookup(ctx context.Context, tenantID, entityType string, ids []uuid.UUID, chain []string) (map[uuid.UUID]models.NameTranslation, error) {
+	best := make(map[uuid.UUID]models.NameTranslation, len(ids))
+	if len(ids) == 0 || len(chain) == 0 {
+		return best, nil
+	}
+
+	var translations []models.NameTranslation
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND entity_type = ? AND is_active = ? AND is_deleted = ?", tenantID, entityType, true, false).
+		Where("entity_id IN ? AND locale_code IN ?", ids, chain).
+		Find(&translations).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve name translations", err)
+	}
+
+	rank := make(map[string]int, len(chain))
+	for i, tag := range chain {
+		rank[tag] = i
+	}
+	for _, t := range translations {
+		if current, ok := best[t.EntityID]; !ok || rank[t.LocaleCode] < rank[current.LocaleCode] {
+			best[t.EntityID] = t
+		}
+	}
+	return best, nil
+}
+
+// ActiveCodes returns the codes of the active locales of a tenant
+func (r *LocaleRepository) ActiveCodes(ctx context.Context, tenantID string) ([]string, error) {
+	var codes []string
+	if err := r.db.WithContext(ctx).
+		Model(new(models.Locales)).
+		Where("tenant_id = ? AND is_active = ? AND is_deleted = ?", tenantID, true, false).
+		Order("locale_code").
+		Pluck("locale_code", &codes).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve locales", err)
+	}
+	return codes, nil
+}
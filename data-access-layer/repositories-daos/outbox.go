@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+)
+
+// Domain event actions; the event type is geo.<entity>.<action>.v1
+const (
+	EventCreated     = "created"
+	EventUpdated     = "updated"
+	EventDeactivated = "deactivated"
+	EventRestored    = "restored"
+	EventPurged      = "purged"
+)
+
+// EventType returns the versioned domain event type of an entity action,
+// e.g. geo.subdivision.restored.v1
+func EventType(entityType, action string) string {
+	return fmt.Sprintf("geo.%s.%s.v1", entityType, action)
+}
+
+// publishEvent writes a domain event to the outbox on tx, so the event is
+// published if and only if the change commits. The actor and change reason of
+// the request are added to data.
+func publishEvent(ctx context.Context, tx *gorm.DB, tenantID, entityType, action string, aggregateID uuid.UUID, data map[string]interface{}) error {
+	if actor := audit.Actor(ctx); actor != "" {
+		data["actor"] = actor
+	}
+	if reason := audit.ChangeReason(ctx); reason != "" {
+		data["change_reason"] = reason
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.NewRepositoryError("EVENT_FAILED", fmt.Sprintf("Failed to encode %s event", entityType), err)
+	}
+
+	event := &models.OutboxEvent{
+		TenantID:      tenantID,
+		EventType:     EventType(entityType, action),
+		AggregateType: entityType,
+		AggregateID:   aggregateID,
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+	if correlationID := logging.GetCorrelationID(ctx); correlationID != "" {
+		event.CorrelationID = &correlationID
+	}
+
+	if err := tx.Create(event).Error; err != nil {
+		return errors.NewRepositoryError("EVENT_FAILED", fmt.Sprintf("Failed to publish %s event", entityType), err)
+	}
+	return nil
+}
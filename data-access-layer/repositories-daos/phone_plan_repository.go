@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var phonePlanValidator = validation.NewPhonePlanValidator()
+
+// PhoneNumberingPlanView is a numbering plan with the code and name of its
+// country
+type PhoneNumberingPlanView struct {
+	models.PhoneNumberingPlan
+	CountryCode string `json:"country_code"`
+	CountryName string `json:"country_name"`
+}
+
+// checkPhonePlan validates a numbering plan and checks that its country
+// exists and has no other plan
+func checkPhonePlan(ctx context.Context, r *Repository[models.PhoneNumberingPlan], tenantID string, id uuid.UUID, plan *models.PhoneNumberingPlan) error {
+	result := phonePlanValidator.ValidatePhonePlan(plan.CallingCode, plan.TrunkPrefix, plan.InternationalPrefix,
+		plan.MinLength, plan.MaxLength, plan.LeadingDigits)
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", plan.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+	if err := db.Model(new(models.PhoneNumberingPlan)).
+		Where("tenant_id = ? AND country_id = ? AND is_deleted = ? AND phone_numbering_plan_id <> ?", tenantID, plan.CountryID, false, id).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check phone numbering plans", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the country already has a phone numbering plan", nil)
+	}
+	return nil
+}
+
+// ForCountry returns the numbering plan of a country by ISO code, or nil
+// when it has none
+func (r *PhoneNumberingPlanRepository) ForCountry(ctx context.Context, tenantID, countryCode string) (*PhoneNumberingPlanView, error) {
+	views, err := r.views(ctx, tenantID, "c.country_code = ?", countryCode)
+	if err != nil || len(views) == 0 {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+// ForCallingCodes returns the plans of the given calling codes, e.g. the
+// United States, Canada and the other NANP countries for 1
+func (r *PhoneNumberingPlanRepository) ForCallingCodes(ctx context.Context, tenantID string, callingCodes []string) ([]PhoneNumberingPlanView, error) {
+	return r.views(ctx, tenantID, "p.calling_code IN ?", callingCodes)
+}
+
+func (r *PhoneNumberingPlanRepository) views(ctx context.Context, tenantID, where string, args ...interface{}) ([]PhoneNumberingPlanView, error) {
+	var views []PhoneNumberingPlanView
+	err := r.db.WithContext(ctx).
+		Table(r.schema.Table+" p").
+		Select("p.*, c.country_code, c.country_name").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = p.country_id AND c.is_deleted = false").
+		Where("p.tenant_id = ? AND p.is_deleted = ?", tenantID, false).
+		Where(where, args...).
+		Order("p.calling_code, c.country_code").
+		Scan(&views).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve phone numbering plans", err)
+	}
+	return views, nil
+}
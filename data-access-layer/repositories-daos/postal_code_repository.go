@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var postalCodeValidator = validation.NewPostalCodeValidator()
+
+// PostalCodeFormatView is a postal code rule with the code, name and parent
+// of its subdivision
+type PostalCodeFormatView struct {
+	models.PostalCodeFormat
+	SubdivisionCode     *string    `json:"subdivision_code,omitempty"`
+	SubdivisionName     *string    `json:"subdivision_name,omitempty"`
+	ParentSubdivisionID *uuid.UUID `json:"parent_subdivision_id,omitempty"`
+}
+
+// checkPostalCodeFormat validates a postal code rule and checks that its
+// country and subdivision exist and that the rule is not defined twice
+func checkPostalCodeFormat(ctx context.Context, r *Repository[models.PostalCodeFormat], tenantID string, id uuid.UUID, format *models.PostalCodeFormat) error {
+	required := format.IsRequired != nil && *format.IsRequired
+	result := postalCodeValidator.ValidatePostalCodeFormat(format.Pattern, format.Format, format.Example, required, format.SubdivisionID != nil)
+	if err := result.Err(); err != nil {
+		return err
+	}
+	if required && format.SubdivisionID != nil {
+		return errors.NewValidationError("is_required", "only country rules say whether a postal code is required")
+	}
+
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", format.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+	if format.SubdivisionID != nil {
+		if err := db.Model(&models.CountrySubdivision{}).
+			Where("subdivision_id = ? AND country_id = ? AND tenant_id = ? AND is_deleted = ?", *format.SubdivisionID, format.CountryID, tenantID, false).
+			Count(&found).Error; err != nil {
+			return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve subdivision", err)
+		}
+		if found == 0 {
+			return errors.NewValidationError("subdivision_id", "subdivision not found in this country")
+		}
+	}
+
+	existing := db.Model(new(models.PostalCodeFormat)).
+		Where("tenant_id = ? AND country_id = ? AND is_deleted = ? AND postal_code_format_id <> ?", tenantID, format.CountryID, false, id)
+	if format.SubdivisionID != nil {
+		existing = existing.Where("subdivision_id = ?", *format.SubdivisionID)
+	} else {
+		existing = existing.Where("subdivision_id IS NULL")
+	}
+	if format.Pattern != nil {
+		existing = existing.Where("pattern = ?", *format.Pattern)
+	} else {
+		existing = existing.Where("pattern IS NULL")
+	}
+	if err := existing.Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check postal code formats", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the postal code format already exists", nil)
+	}
+	return nil
+}
+
+// ForCountry returns the postal code rules of a country and its
+// subdivisions, country rules first
+func (r *PostalCodeFormatRepository) ForCountry(ctx context.Context, tenantID string, countryID uuid.UUID) ([]PostalCodeFormatView, error) {
+	var views []PostalCodeFormatView
+	err := r.db.WithContext(ctx).
+		Table(r.schema.Table+" pf").
+		Select("pf.*, s.subdivision_code, s.subdivision_name, s.parent_subdivision_id").
+		Joins("LEFT JOIN "+models.CountrySubdivision{}.TableName()+" s ON s.subdivision_id = pf.subdivision_id").
+		Where("pf.tenant_id = ? AND pf.is_deleted = ? AND pf.country_id = ?", tenantID, false, countryID).
+		Where("pf.subdivision_id IS NULL OR s.is_deleted = false").
+		Order("s.subdivision_code NULLS FIRST, pf.created_at").
+		Scan(&views).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve postal code formats", err)
+	}
+	return views, nil
+}
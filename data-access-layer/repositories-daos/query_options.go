@@ -0,0 +1,121 @@
+package repositories
+
+import "time"
+
+// FilterOp is a comparison operator supported by typed filter specs
+type FilterOp string
+
+const (
+	OpEq     FilterOp = "eq"
+	OpNe     FilterOp = "ne"
+	OpGt     FilterOp = "gt"
+	OpGte    FilterOp = "ge"
+	OpLt     FilterOp = "lt"
+	OpLte    FilterOp = "le"
+	OpIn     FilterOp = "in"
+	OpLike   FilterOp = "like"
+	OpIsNull FilterOp = "null"
+)
+
+const (
+	// DefaultPageSize is applied when ListOptions.Limit is not set
+	DefaultPageSize = 50
+	// MaxPageSize caps the number of rows a single List call may return
+	MaxPageSize = 500
+	// DateLayout is the format of valid-time dates such as as_of
+	DateLayout = "2006-01-02"
+)
+
+// DeletedScope selects live rows, soft-deleted rows or both
+type DeletedScope string
+
+const (
+	DeletedExclude DeletedScope = ""
+	DeletedInclude DeletedScope = "true"
+	DeletedOnly    DeletedScope = "only"
+)
+
+// Filter restricts a list query on a single column.
+// Value must be a slice for OpIn and a bool for OpIsNull.
+type Filter struct {
+	Column string      `json:"column"`
+	Op     FilterOp    `json:"op"`
+	Value  interface{} `json:"value"`
+}
+
+// SortField orders a list query by a single column
+type SortField struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc"`
+}
+
+// ListOptions controls filtering, ordering and pagination of a list query.
+// When After or Before is set keyset pagination is used and Offset is
+// ignored. After holds the values of the effective sort columns (see
+// Page.NextKey) of the last row already returned; Before holds those of the
+// first row (see Page.PrevKey) and pages backwards.
+//
+// AsOf selects rows of entities with a valid-time period that were valid on
+// that date; such entities default to rows valid today.
+//
+// Deleted widens the query to soft-deleted rows, which are excluded by
+// default.
+type ListOptions struct {
+	Filters      []Filter
+	Sort         []SortField
+	Limit        int
+	Offset       int
+	After        []interface{}
+	Before       []interface{}
+	IncludeTotal bool
+	AsOf         *time.Time
+	Deleted      DeletedScope
+}
+
+// Page is a single page of a list query
+type Page[T any] struct {
+	Items   []T           `json:"items"`
+	Total   *int64        `json:"total,omitempty"`
+	Limit   int           `json:"limit"`
+	Offset  int           `json:"offset"`
+	HasMore bool          `json:"has_more"`
+	HasPrev bool          `json:"has_prev"`
+	Sort    []SortField   `json:"sort"`
+	NextKey []interface{} `json:"next_key,omitempty"`
+	PrevKey []interface{} `json:"prev_key,omitempty"`
+}
+
+// Eq builds an equality filter
+func Eq(column string, value interface{}) Filter {
+	return Filter{Column: column, Op: OpEq, Value: value}
+}
+
+// Asc builds an ascending sort field
+func Asc(column string) SortField {
+	return SortField{Column: column}
+}
+
+// Desc builds a descending sort field
+func Desc(column string) SortField {
+	return SortField{Column: column, Desc: true}
+}
+
+// reversed flips the direction of every sort field
+func reversed(order []SortField) []SortField {
+	flipped := make([]SortField, len(order))
+	for i, s := range order {
+		flipped[i] = SortField{Column: s.Column, Desc: !s.Desc}
+	}
+	return flipped
+}
+
+func (o ListOptions) pageSize() int {
+	switch {
+	case o.Limit <= 0:
+		return DefaultPageSize
+	case o.Limit > MaxPageSize:
+		return MaxPageSize
+	default:
+		return o.Limit
+	}
+}
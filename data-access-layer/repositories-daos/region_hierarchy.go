@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// RegionNode is a region found by a hierarchy query. Depth is the distance
+// from the region the query started at, or from the root for trees.
+type RegionNode struct {
+	models.Region
+	Depth int `json:"depth"`
+}
+
+// RegionTree is a region with its live sub-regions
+type RegionTree struct {
+	RegionNode
+	Children []*RegionTree `json:"children,omitempty"`
+}
+
+// Hierarchy queries walk parent_region_id with recursive CTEs over the live
+// rows of a tenant. The path array stops the walk should a cycle ever exist.
+const (
+	regionDescendantsSQL = `
+		WITH RECURSIVE tree AS (
+			SELECT r.*, 1 AS depth, ARRAY[r.region_id] AS path
+			FROM %[1]s r
+			WHERE r.parent_region_id = ? AND r.tenant_id = ? AND r.is_deleted = false
+			UNION ALL
+			SELECT c.*, t.depth + 1, t.path || c.region_id
+			FROM %[1]s c
+			JOIN tree t ON c.parent_region_id = t.region_id
+			WHERE c.tenant_id = ? AND c.is_deleted = false AND NOT c.region_id = ANY(t.path)
+		)
+		SELECT * FROM tree ORDER BY depth, region_name`
+
+	regionAncestorsSQL = `
+		WITH RECURSIVE up AS (
+			SELECT p.*, 1 AS depth, ARRAY[r.region_id, p.region_id] AS path
+			FROM %[1]s p
+			JOIN %[1]s r ON r.parent_region_id = p.region_id
+			WHERE r.region_id = ? AND p.tenant_id = ? AND p.is_deleted = false
+			UNION ALL
+			SELECT p.*, u.depth + 1, u.path || p.region_id
+			FROM %[1]s p
+			JOIN up u ON p.region_id = u.parent_region_id
+			WHERE p.tenant_id = ? AND p.is_deleted = false AND NOT p.region_id = ANY(u.path)
+		)
+		SELECT * FROM up ORDER BY depth`
+
+	regionTreeSQL = `
+		WITH RECURSIVE tree AS (
+			SELECT r.*, 0 AS depth, ARRAY[r.region_id] AS path
+			FROM %[1]s r
+			WHERE r.tenant_id = ? AND r.is_deleted = false AND NOT EXISTS (
+				SELECT 1 FROM %[1]s p
+				WHERE p.region_id = r.parent_region_id AND p.tenant_id = r.tenant_id AND p.is_deleted = false)
+			UNION ALL
+			SELECT c.*, t.depth + 1, t.path || c.region_id
+			FROM %[1]s c
+			JOIN tree t ON c.parent_region_id = t.region_id
+			WHERE c.tenant_id = ? AND c.is_deleted = false AND NOT c.region_id = ANY(t.path)
+		)
+		SELECT * FROM tree ORDER BY depth, region_name`
+
+	// regionCycleSQL reports whether a region is the given parent or one of
+	// its ancestors, deleted rows included
+	regionCycleSQL = `
+		WITH RECURSIVE up AS (
+			SELECT region_id, parent_region_id, ARRAY[region_id] AS path
+			FROM %[1]s
+			WHERE region_id = ? AND tenant_id = ?
+			UNION ALL
+			SELECT p.region_id, p.parent_region_id, u.path || p.region_id
+			FROM %[1]s p
+			JOIN up u ON p.region_id = u.parent_region_id
+			WHERE p.tenant_id = ? AND NOT p.region_id = ANY(u.path)
+		)
+		SELECT EXISTS (SELECT 1 FROM up WHERE region_id = ?)`
+)
+
+// checkRegion rejects a parent region that is missing or would make the
+// region its own ancestor
+func checkRegion(ctx context.Context, r *Repository[models.Region], tenantID string, id uuid.UUID, region *models.Region) error {
+	if region.ParentRegionID == nil {
+		return nil
+	}
+	parent, err := r.GetByID(ctx, tenantID, *region.ParentRegionID)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return errors.NewValidationError("parent_region_id", "parent region not found")
+	}
+	if id == uuid.Nil {
+		return nil
+	}
+
+	var cycle bool
+	err = r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(regionCycleSQL, r.schema.Table), parent.RegionID, tenantID, tenantID, id).
+		Scan(&cycle).Error
+	if err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check region hierarchy", err)
+	}
+	if cycle {
+		return errors.NewValidationError("parent_region_id", fmt.Sprintf("parent %s is the region itself or one of its descendants", parent.RegionCode))
+	}
+	return nil
+}
+
+// Children returns the live direct sub-regions of a region
+func (r *RegionRepository) Children(ctx context.Context, tenantID string, id uuid.UUID) ([]models.Region, error) {
+	var children []models.Region
+	err := r.db.WithContext(ctx).
+		Where("parent_region_id = ? AND tenant_id = ? AND is_deleted = ?", id, tenantID, false).
+		Order("region_name").
+		Find(&children).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve child regions", err)
+	}
+	return children, nil
+}
+
+// Descendants returns all live regions below a region, nearest first
+func (r *RegionRepository) Descendants(ctx context.Context, tenantID string, id uuid.UUID) ([]RegionNode, error) {
+	return r.walk(ctx, regionDescendantsSQL, "descendants", id, tenantID, tenantID)
+}
+
+// Ancestors returns the live regions above a region, from its parent up to
+// the root
+func (r *RegionRepository) Ancestors(ctx context.Context, tenantID string, id uuid.UUID) ([]RegionNode, error) {
+	return r.walk(ctx, regionAncestorsSQL, "ancestors", id, tenantID, tenantID)
+}
+
+// Tree returns the live region forest of a tenant. Regions whose parent is
+// missing or deleted become roots.
+func (r *RegionRepository) Tree(ctx context.Context, tenantID string) ([]*RegionTree, error) {
+	nodes, err := r.walk(ctx, regionTreeSQL, "tree", tenantID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return regionForest(nodes), nil
+}
+
+// regionForest nests nodes ordered by depth, so that every parent precedes
+// its children, under their depth 0 roots
+func regionForest(nodes []RegionNode) []*RegionTree {
+	byID := make(map[uuid.UUID]*RegionTree, len(nodes))
+	var roots []*RegionTree
+	for _, node := range nodes {
+		t := &RegionTree{RegionNode: node}
+		byID[node.RegionID] = t
+		if node.Depth == 0 {
+			roots = append(roots, t)
+			continue
+		}
+		if parent, ok := byID[*node.ParentRegionID]; ok {
+			parent.Children = append(parent.Children, t)
+		}
+	}
+	return roots
+}
+
+// SubtreeIDs returns the ID of a region and of all its live descendants, for
+// rolling up rows that reference regions at any level
+func (r *RegionRepository) SubtreeIDs(ctx context.Context, tenantID string, id uuid.UUID) ([]uuid.UUID, error) {
+	descendants, err := r.Descendants(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(descendants)+1)
+	ids = append(ids, id)
+	for _, d := range descendants {
+		ids = append(ids, d.RegionID)
+	}
+	return ids, nil
+}
+
+func (r *RegionRepository) walk(ctx context.Context, query, what string, args ...interface{}) ([]RegionNode, error) {
+	var nodes []RegionNode
+	if err := r.db.WithContext(ctx).Raw(fmt.Sprintf(query, r.schema.Table), args...).Scan(&nodes).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to retrieve region %s", what), err)
+	}
+	return nodes, nil
+}
+
+// ChildrenOperation binds child lookup for a tenant to the
+// catalogue.RegionOperations.GetChildren signature
+func (r *RegionRepository) ChildrenOperation(tenantID string) func(ctx context.Context, parentID string) ([]interface{}, error) {
+	return func(ctx context.Context, parentID string) ([]interface{}, error) {
+		id, err := uuid.Parse(parentID)
+		if err != nil {
+			return nil, errors.NewValidationError("parent_id", "invalid ID format")
+		}
+		children, err := r.Children(ctx, tenantID, id)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, len(children))
+		for i, c := range children {
+			result[i] = c
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func regionNode(code string, depth int, parent *RegionNode) RegionNode {
+	node := RegionNode{Region: models.Region{RegionID: uuid.New(), RegionCode: code}, Depth: depth}
+	if parent != nil {
+		node.ParentRegionID = &parent.RegionID
+	}
+	return node
+}
+
+// shape renders a forest as region codes with their children in brackets
+func shape(trees []*RegionTree) []string {
+	var out []string
+	for _, t := range trees {
+		s := t.RegionCode
+		if len(t.Children) > 0 {
+			s += "[" + strings.Join(shape(t.Children), ",") + "]"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestRegionForest(t *testing.T) {
+	world := regionNode("001", 0, nil)
+	europe := regionNode("150", 1, &world)
+	asia := regionNode("142", 1, &world)
+	west := regionNode("155", 2, &europe)
+	antarctica := regionNode("AQ", 0, nil)
+	missing := regionNode("??", 0, nil)
+	orphan := regionNode("X1", 1, &missing)
+
+	tests := []struct {
+		name  string
+		nodes []RegionNode
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"single root", []RegionNode{antarctica}, []string{"AQ"}},
+		{"nested levels", []RegionNode{world, europe, asia, west}, []string{"001[150[155],142]"}},
+		{"several roots", []RegionNode{world, antarctica, europe}, []string{"001[150]", "AQ"}},
+		{"child of an unknown parent is dropped", []RegionNode{antarctica, orphan}, []string{"AQ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shape(regionForest(tt.nodes)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("regionForest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChildrenOperationRejectsInvalidID(t *testing.T) {
+	repo := NewRegionRepository(nil)
+	if _, err := repo.ChildrenOperation("default-tenant")(context.Background(), "europe"); err == nil {
+		t.Error("ChildrenOperation() succeeded for an invalid ID, want error")
+	}
+}
+
+func TestCheckRegionWithoutParent(t *testing.T) {
+	repo := NewRegionRepository(nil)
+	if err := checkRegion(context.Background(), repo.Repository, "default-tenant", uuid.New(), &models.Region{RegionCode: "AQ"}); err != nil {
+		t.Errorf("checkRegion() error = %v, want nil for a root region", err)
+	}
+}
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// anonymizedAuditColumns are the personal audit columns cleared once their
+// retention period ends, per audit event. Who made a change (*_by) is kept.
+var anonymizedAuditColumns = map[string][]string{
+	"created": {"created_ip", "created_device", "created_location"},
+	"updated": {"updated_ip", "updated_device", "updated_location"},
+	"deleted": {"deleted_ip", "deleted_device", "deleted_location"},
+}
+
+// RetentionTarget is a reference entity table subject to retention
+type RetentionTarget struct {
+	EntityType string
+	Table      string
+	KeyColumn  string
+	// Purge removes one soft-deleted row, recording history and an event
+	Purge func(ctx context.Context, tenantID string, id uuid.UUID) error
+}
+
+// RetentionTarget describes the table of model T for the retention engine
+func (r *Repository[T]) RetentionTarget() RetentionTarget {
+	return RetentionTarget{
+		EntityType: r.spec.Name,
+		Table:      r.schema.Table,
+		KeyColumn:  r.primaryColumn(),
+		Purge: func(ctx context.Context, tenantID string, id uuid.UUID) error {
+			return r.PurgeByID(ctx, tenantID, id, 0)
+		},
+	}
+}
+
+// RetentionGroup is the set of rows of one tenant governed by the same
+// policy. Policy is empty for rows that follow their classification default.
+type RetentionGroup struct {
+	TenantID       string
+	Policy         string
+	Classification string
+	Rows           int64
+}
+
+// where selects the rows of the group
+func (g RetentionGroup) where() (string, []interface{}) {
+	if g.Policy != "" {
+		return "tenant_id = ? AND retention_policy = ?", []interface{}{g.TenantID, g.Policy}
+	}
+	return "tenant_id = ? AND retention_policy IS NULL AND COALESCE(data_classification, 'PUBLIC') = ?",
+		[]interface{}{g.TenantID, g.Classification}
+}
+
+// RetentionRepository applies retention policies to the reference tables and
+// stores the retention reports. Statements bypass the GORM update callbacks so
+// that anonymizing does not stamp new audit data.
+type RetentionRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepository(db *gorm.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// Groups returns the policy groups of a table across all tenants
+func (r *RetentionRepository) Groups(ctx context.Context, target RetentionTarget) ([]RetentionGroup, error) {
+	var groups []RetentionGroup
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT tenant_id,
+		       COALESCE(retention_policy, '') AS policy,
+		       CASE WHEN retention_policy IS NULL THEN COALESCE(data_classification, 'PUBLIC') ELSE '' END AS classification,
+		       count(*) AS rows
+		FROM ` + target.Table + `
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`).Scan(&groups).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to group %ss by retention policy", target.EntityType), err)
+	}
+	return groups, nil
+}
+
+// AnonymizeAudit clears the IP, device and location of every audit event of
+// the group that happened before cutoff and returns the number of rows changed
+func (r *RetentionRepository) AnonymizeAudit(ctx context.Context, target RetentionTarget, group RetentionGroup, cutoff time.Time) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, event := range []string{"created", "updated", "deleted"} {
+			columns := anonymizedAuditColumns[event]
+			set, present := "", ""
+			for i, column := range columns {
+				if i > 0 {
+					set += ", "
+					present += " OR "
+				}
+				set += column + " = NULL"
+				present += column + " IS NOT NULL"
+			}
+
+			cond, args := group.where()
+			result := tx.Exec("UPDATE "+target.Table+" SET "+set+
+				" WHERE "+cond+" AND "+event+"_at < ? AND ("+present+")",
+				append(args, cutoff)...)
+			if result.Error != nil {
+				return errors.NewRepositoryError("RETENTION_FAILED", fmt.Sprintf("Failed to anonymize %s audit data", target.EntityType), result.Error)
+			}
+			total += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// ExpiredDeleted returns the keys of up to limit rows of the group that were
+// soft deleted before cutoff
+func (r *RetentionRepository) ExpiredDeleted(ctx context.Context, target RetentionTarget, group RetentionGroup, cutoff time.Time, limit int) ([]uuid.UUID, error) {
+	cond, args := group.where()
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Table(target.Table).
+		Where(cond, args...).
+		Where("is_deleted = ? AND deleted_at < ?", true, cutoff).
+		Order("deleted_at").
+		Limit(limit).
+		Pluck(target.KeyColumn, &ids).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to find expired deleted %ss", target.EntityType), err)
+	}
+	return ids, nil
+}
+
+// SaveReports stores the reports of a retention run
+func (r *RetentionRepository) SaveReports(ctx context.Context, reports []models.RetentionReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Create(&reports).Error; err != nil {
+		return errors.NewRepositoryError("CREATE_FAILED", "Failed to save retention reports", err)
+	}
+	return nil
+}
+
+// Reports returns the reports of a tenant, newest first
+func (r *RetentionRepository) Reports(ctx context.Context, tenantID string, limit int) ([]models.RetentionReport, error) {
+	var reports []models.RetentionReport
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&reports).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve retention reports", err)
+	}
+	return reports, nil
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// Searchable entity types
+const (
+	SearchCountry     = "country"
+	SearchSubdivision = "subdivision"
+	SearchLanguage    = "language"
+)
+
+// SearchEntityTypes lists every entity type covered by search
+var SearchEntityTypes = []string{SearchCountry, SearchSubdivision, SearchLanguage}
+
+// DefaultMinSimilarity is the trigram similarity below which fuzzy matches are dropped
+const DefaultMinSimilarity = 0.3
+
+// searchSource selects entity_id, code, name, matched_field, matched_text and
+// country_code from the rows of the current tenant. column is the name column
+// matched_text comes from.
+type searchSource struct {
+	query  string
+	column string
+}
+
+// searchSources maps each entity type to the name columns it is searched on
+var searchSources = map[string][]searchSource{
+	SearchCountry: {
+		{`SELECT c.country_id, c.country_code, c.country_name, 'country_name', c.country_name, c.country_code
+		FROM domain_reference_master_geopolitical.countries c
+		WHERE c.tenant_id = @tenant AND c.is_deleted = false AND c.is_active = true`, "c.country_name"},
+		{`SELECT c.country_id, c.country_code, c.country_name, 'official_name', c.official_name, c.country_code
+		FROM domain_reference_master_geopolitical.countries c
+		WHERE c.tenant_id = @tenant AND c.is_deleted = false AND c.is_active = true AND c.official_name IS NOT NULL`, "c.official_name"},
+	},
+	SearchSubdivision: {
+		{`SELECT s.subdivision_id, s.subdivision_code, s.subdivision_name, 'subdivision_name', s.subdivision_name, c.country_code
+		FROM domain_reference_master_geopolitical.country_subdivisions s
+		JOIN domain_reference_master_geopolitical.countries c ON c.country_id = s.country_id
+		WHERE s.tenant_id = @tenant AND s.is_deleted = false AND s.is_active = true`, "s.subdivision_name"},
+	},
+	SearchLanguage: {
+		{`SELECT l.language_id, l.language_code, l.language_name, 'language_name', l.language_name, NULL
+		FROM domain_reference_master_geopolitical.languages l
+		WHERE l.tenant_id = @tenant AND l.is_deleted = false AND l.is_active = true`, "l.language_name"},
+		{`SELECT l.language_id, l.language_code, l.language_name, 'native_name', l.native_name, NULL
+		FROM domain_reference_master_geopolitical.languages l
+		WHERE l.tenant_id = @tenant AND l.is_deleted = false AND l.is_active = true AND l.native_name IS NOT NULL`, "l.native_name"},
+	},
+}
+
+// searchFilter keeps the rows of a source that can match. It compares the
+// normalised name, the expression the trigram indexes of migration 002 are
+// built on, so that the % and <% operators and the prefix patterns are
+// answered from those indexes before any row is scored. The normalised query
+// is a constant the planner folds.
+const searchFilter = `(%[1]s %% %[2]s OR %[2]s <%% %[1]s OR %[1]s LIKE %[2]s || '%%' OR %[1]s LIKE '%% ' || %[2]s || '%%')`
+
+// filter returns the condition of src on the normalised query text
+func (src searchSource) filter() string {
+	return fmt.Sprintf(searchFilter,
+		"domain_reference_master_geopolitical.f_search_normalize("+src.column+")",
+		"domain_reference_master_geopolitical.f_search_normalize(@text)")
+}
+
+// SearchQuery describes a ranked name search
+type SearchQuery struct {
+	Text          string
+	EntityTypes   []string
+	Limit         int
+	MinSimilarity float64
+}
+
+// SearchHit is a single ranked match. MatchType is exact, prefix or fuzzy.
+type SearchHit struct {
+	EntityType   string    `json:"entity_type" gorm:"column:entity_type"`
+	EntityID     uuid.UUID `json:"entity_id" gorm:"column:entity_id"`
+	Code         string    `json:"code" gorm:"column:code"`
+	Name         string    `json:"name" gorm:"column:name"`
+	MatchedField string    `json:"matched_field" gorm:"column:matched_field"`
+	MatchedText  string    `json:"matched_text" gorm:"column:matched_text"`
+	CountryCode  *string   `json:"country_code,omitempty" gorm:"column:country_code"`
+	MatchType    string    `json:"match_type" gorm:"column:match_type"`
+	Score        float64   `json:"score" gorm:"column:score"`
+}
+
+// SearchResult holds the top hits and the number of matching entities per type
+type SearchResult struct {
+	Hits   []SearchHit      `json:"hits"`
+	Facets map[string]int64 `json:"facets"`
+}
+
+// SearchRepository runs accent-insensitive prefix and trigram searches over
+// country, official, subdivision and language names
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search returns hits ranked by score, one per entity, plus entity-type facets
+func (r *SearchRepository) Search(ctx context.Context, tenantID string, q SearchQuery) (*SearchResult, error) {
+	types := q.EntityTypes
+	if len(types) == 0 {
+		types = SearchEntityTypes
+	}
+
+	matches, err := searchMatches(types)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := q.Limit
+	if limit <= 0 || limit > MaxPageSize {
+		limit = DefaultPageSize
+	}
+	minSimilarity := q.MinSimilarity
+	if minSimilarity <= 0 {
+		minSimilarity = DefaultMinSimilarity
+	}
+
+	args := map[string]interface{}{
+		"tenant":         tenantID,
+		"text":           q.Text,
+		"min_similarity": minSimilarity,
+		"limit":          limit,
+	}
+
+	result := &SearchResult{Facets: make(map[string]int64, len(types))}
+	var facets []struct {
+		EntityType string `gorm:"column:entity_type"`
+		Total      int64  `gorm:"column:total"`
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The thresholds of % and <% for this transaction only
+		threshold := strconv.FormatFloat(minSimilarity, 'f', -1, 64)
+		if err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true), set_config('pg_trgm.word_similarity_threshold', ?, true)",
+			threshold, threshold).Error; err != nil {
+			return errors.NewRepositoryError("SEARCH_FAILED", "Failed to set the search threshold", err)
+		}
+		if err := tx.Raw(matches+`
+SELECT * FROM matches ORDER BY score DESC, name ASC LIMIT @limit`, args).Scan(&result.Hits).Error; err != nil {
+			return errors.NewRepositoryError("SEARCH_FAILED", "Failed to search reference data", err)
+		}
+		if err := tx.Raw(matches+`
+SELECT entity_type, count(*) AS total FROM matches GROUP BY entity_type`, args).Scan(&facets).Error; err != nil {
+			return errors.NewRepositoryError("SEARCH_FAILED", "Failed to compute search facets", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, entityType := range types {
+		result.Facets[entityType] = 0
+	}
+	for _, f := range facets {
+		result.Facets[f.EntityType] = f.Total
+	}
+
+	return result, nil
+}
+
+// searchMatches returns the matches CTE over the sources of types: every
+// source's candidate rows, scored and reduced to the best hit per entity
+func searchMatches(types []string) (string, error) {
+	var sources []string
+	for _, entityType := range types {
+		srcs, ok := searchSources[entityType]
+		if !ok {
+			return "", errors.NewValidationError("types", fmt.Sprintf("unsupported entity type %q", entityType))
+		}
+		for _, src := range srcs {
+			sources = append(sources, fmt.Sprintf("SELECT '%s' AS entity_type, src.* FROM (%s AND %s) AS src(entity_id, code, name, matched_field, matched_text, country_code)",
+				entityType, src.query, src.filter()))
+		}
+	}
+
+	// Exact and prefix matches always outrank fuzzy ones; word_similarity lets
+	// a short query match one word of a longer name ("Ivoire")
+	return `
+WITH params AS (
+	SELECT domain_reference_master_geopolitical.f_search_normalize(@text) AS q
+),
+candidates AS (
+	` + strings.Join(sources, "\n\tUNION ALL\n\t") + `
+),
+scored AS (
+	SELECT cand.*,
+		CASE
+			WHEN norm = p.q THEN 'exact'
+			WHEN norm LIKE p.q || '%' OR norm LIKE '% ' || p.q || '%' THEN 'prefix'
+			ELSE 'fuzzy'
+		END AS match_type,
+		CASE
+			WHEN norm = p.q THEN 2.0
+			WHEN norm LIKE p.q || '%' THEN 1.5 + similarity(norm, p.q) / 10
+			WHEN norm LIKE '% ' || p.q || '%' THEN 1.2 + similarity(norm, p.q) / 10
+			ELSE GREATEST(similarity(norm, p.q), word_similarity(p.q, norm))
+		END AS score
+	FROM (
+		SELECT c.*, domain_reference_master_geopolitical.f_search_normalize(c.matched_text) AS norm
+		FROM candidates c
+	) cand, params p
+),
+matches AS (
+	SELECT DISTINCT ON (entity_type, entity_id)
+		entity_type, entity_id, code, name, matched_field, matched_text, country_code, match_type, score
+	FROM scored
+	WHERE score >= @min_similarity
+	ORDER BY entity_type, entity_id, score DESC
+)`, nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchMatchesFiltersOnTheIndexedExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		types   []string
+		columns []string
+		absent  []string
+		wantErr bool
+	}{
+		{
+			name:    "countries",
+			types:   []string{SearchCountry},
+			columns: []string{"c.country_name", "c.official_name"},
+			absent:  []string{"s.subdivision_name", "l.language_name"},
+		},
+		{
+			name:    "subdivisions and languages",
+			types:   []string{SearchSubdivision, SearchLanguage},
+			columns: []string{"s.subdivision_name", "l.language_name", "l.native_name"},
+			absent:  []string{"c.country_name"},
+		},
+		{
+			name:    "every type",
+			types:   SearchEntityTypes,
+			columns: []string{"c.country_name", "c.official_name", "s.subdivision_name", "l.language_name", "l.native_name"},
+		},
+		{
+			name:    "unknown type",
+			types:   []string{"currency"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, err := searchMatches(tt.types)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("searchMatches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, column := range tt.columns {
+				norm := "domain_reference_master_geopolitical.f_search_normalize(" + column + ")"
+				query := "domain_reference_master_geopolitical.f_search_normalize(@text)"
+				for _, want := range []string{
+					norm + " % " + query,
+					query + " <% " + norm,
+					norm + " LIKE " + query + " || '%'",
+				} {
+					if !strings.Contains(sql, want) {
+						t.Errorf("searchMatches() lacks %q", want)
+					}
+				}
+			}
+			for _, column := range tt.absent {
+				if strings.Contains(sql, column) {
+					t.Errorf("searchMatches() searches %s", column)
+				}
+			}
+		})
+	}
+}
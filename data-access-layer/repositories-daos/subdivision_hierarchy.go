@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// SubdivisionNode is a subdivision found by a hierarchy query. Depth is 1
+// for top-level subdivisions of a country, or the distance from the
+// subdivision a descendant or ancestor query started at.
+type SubdivisionNode struct {
+	models.CountrySubdivision
+	Depth int `json:"depth"`
+}
+
+// Subdivision hierarchy queries walk parent_subdivision_id over the live rows
+// of a tenant; a maximum depth of 0 means unlimited
+const (
+	subdivisionCountryTreeSQL = `
+		WITH RECURSIVE tree AS (
+			SELECT s.*, 1 AS depth, ARRAY[s.subdivision_id] AS path
+			FROM %[1]s s
+			WHERE s.country_id = ? AND s.tenant_id = ? AND s.is_deleted = false AND NOT EXISTS (
+				SELECT 1 FROM %[1]s p
+				WHERE p.subdivision_id = s.parent_subdivision_id AND p.tenant_id = s.tenant_id AND p.is_deleted = false)
+			UNION ALL
+			SELECT c.*, t.depth + 1, t.path || c.subdivision_id
+			FROM %[1]s c
+			JOIN tree t ON c.parent_subdivision_id = t.subdivision_id
+			WHERE c.tenant_id = ? AND c.is_deleted = false AND NOT c.subdivision_id = ANY(t.path)
+			  AND (? = 0 OR t.depth < ?)
+		)
+		SELECT * FROM tree ORDER BY depth, subdivision_code`
+
+	subdivisionDescendantsSQL = `
+		WITH RECURSIVE tree AS (
+			SELECT s.*, 1 AS depth, ARRAY[s.subdivision_id] AS path
+			FROM %[1]s s
+			WHERE s.parent_subdivision_id = ? AND s.tenant_id = ? AND s.is_deleted = false
+			UNION ALL
+			SELECT c.*, t.depth + 1, t.path || c.subdivision_id
+			FROM %[1]s c
+			JOIN tree t ON c.parent_subdivision_id = t.subdivision_id
+			WHERE c.tenant_id = ? AND c.is_deleted = false AND NOT c.subdivision_id = ANY(t.path)
+			  AND (? = 0 OR t.depth < ?)
+		)
+		SELECT * FROM tree ORDER BY depth, subdivision_code`
+
+	subdivisionAncestorsSQL = `
+		WITH RECURSIVE up AS (
+			SELECT p.*, 1 AS depth, ARRAY[s.subdivision_id, p.subdivision_id] AS path
+			FROM %[1]s p
+			JOIN %[1]s s ON s.parent_subdivision_id = p.subdivision_id
+			WHERE s.subdivision_id = ? AND p.tenant_id = ? AND p.is_deleted = false
+			UNION ALL
+			SELECT p.*, u.depth + 1, u.path || p.subdivision_id
+			FROM %[1]s p
+			JOIN up u ON p.subdivision_id = u.parent_subdivision_id
+			WHERE p.tenant_id = ? AND p.is_deleted = false AND NOT p.subdivision_id = ANY(u.path)
+		)
+		SELECT * FROM up ORDER BY depth`
+
+	// subdivisionCycleSQL reports whether a subdivision is the given parent
+	// or one of its ancestors, deleted rows included
+	subdivisionCycleSQL = `
+		WITH RECURSIVE up AS (
+			SELECT subdivision_id, parent_subdivision_id, ARRAY[subdivision_id] AS path
+			FROM %[1]s
+			WHERE subdivision_id = ? AND tenant_id = ?
+			UNION ALL
+			SELECT p.subdivision_id, p.parent_subdivision_id, u.path || p.subdivision_id
+			FROM %[1]s p
+			JOIN up u ON p.subdivision_id = u.parent_subdivision_id
+			WHERE p.tenant_id = ? AND NOT p.subdivision_id = ANY(u.path)
+		)
+		SELECT EXISTS (SELECT 1 FROM up WHERE subdivision_id = ?)`
+)
+
+var subdivisionValidator = validation.NewSubdivisionValidator()
+
+// WithTx returns a copy of the repository whose statements, including its
+// validation queries, run on tx
+func (r *SubdivisionRepository) WithTx(tx *gorm.DB) *SubdivisionRepository {
+	return &SubdivisionRepository{r.Repository.WithTx(tx)}
+}
+
+// GetByCountryAndCode retrieves a subdivision of a country by its ISO 3166-2
+// code, or nil if there is none
+func (r *SubdivisionRepository) GetByCountryAndCode(ctx context.Context, tenantID string, countryID uuid.UUID, code string) (*models.CountrySubdivision, error) {
+	var subdivision models.CountrySubdivision
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND country_id = ? AND subdivision_code = ? AND is_deleted = ?", tenantID, countryID, code, false).
+		First(&subdivision).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve subdivision", err)
+	}
+	return &subdivision, nil
+}
+
+// checkSubdivision validates the ISO 3166-2 code of a subdivision against its
+// country and rejects a parent that is missing, belongs to another country or
+// would make the subdivision its own ancestor
+func checkSubdivision(ctx context.Context, r *Repository[models.CountrySubdivision], tenantID string, id uuid.UUID, subdivision *models.CountrySubdivision) error {
+	var country models.Country
+	err := r.db.WithContext(ctx).
+		Select("country_code").
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", subdivision.CountryID, tenantID, false).
+		First(&country).Error
+	if err == gorm.ErrRecordNotFound {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+	if err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve subdivision country", err)
+	}
+
+	if err := subdivisionValidator.ValidateSubdivisionCode(subdivision.SubdivisionCode, country.CountryCode).Err(); err != nil {
+		return err
+	}
+	if subdivision.ParentSubdivisionID == nil {
+		return nil
+	}
+
+	parent, err := r.GetByID(ctx, tenantID, *subdivision.ParentSubdivisionID)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return errors.NewValidationError("parent_subdivision_id", "parent subdivision not found")
+	}
+	if parent.CountryID != subdivision.CountryID {
+		return errors.NewValidationError("parent_subdivision_id", fmt.Sprintf("parent %s belongs to another country", parent.SubdivisionCode))
+	}
+	if id == uuid.Nil {
+		return nil
+	}
+
+	var cycle bool
+	err = r.db.WithContext(ctx).
+		Raw(fmt.Sprintf(subdivisionCycleSQL, r.schema.Table), parent.SubdivisionID, tenantID, tenantID, id).
+		Scan(&cycle).Error
+	if err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check subdivision hierarchy", err)
+	}
+	if cycle {
+		return errors.NewValidationError("parent_subdivision_id", fmt.Sprintf("parent %s is the subdivision itself or one of its descendants", parent.SubdivisionCode))
+	}
+	return nil
+}
+
+// CountryTree returns the live subdivisions of a country down to maxDepth
+// levels, top level first. Subdivisions whose parent is missing or deleted
+// count as top level.
+func (r *SubdivisionRepository) CountryTree(ctx context.Context, tenantID string, countryID uuid.UUID, maxDepth int) ([]SubdivisionNode, error) {
+	return r.walk(ctx, subdivisionCountryTreeSQL, "tree", countryID, tenantID, tenantID, maxDepth, maxDepth)
+}
+
+// Descendants returns the live subdivisions below a subdivision down to
+// maxDepth levels, nearest first
+func (r *SubdivisionRepository) Descendants(ctx context.Context, tenantID string, id uuid.UUID, maxDepth int) ([]SubdivisionNode, error) {
+	return r.walk(ctx, subdivisionDescendantsSQL, "descendants", id, tenantID, tenantID, maxDepth, maxDepth)
+}
+
+// Ancestors returns the live subdivisions above a subdivision, from its
+// parent up to the top level
+func (r *SubdivisionRepository) Ancestors(ctx context.Context, tenantID string, id uuid.UUID) ([]SubdivisionNode, error) {
+	return r.walk(ctx, subdivisionAncestorsSQL, "ancestors", id, tenantID, tenantID)
+}
+
+func (r *SubdivisionRepository) walk(ctx context.Context, query, what string, args ...interface{}) ([]SubdivisionNode, error) {
+	var nodes []SubdivisionNode
+	if err := r.db.WithContext(ctx).Raw(fmt.Sprintf(query, r.schema.Table), args...).Scan(&nodes).Error; err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", fmt.Sprintf("Failed to retrieve subdivision %s", what), err)
+	}
+	return nodes, nil
+}
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+var timezoneValidator = validation.NewTimezoneValidator()
+
+// checkTimezone checks that the code of a time zone is an IANA zone and
+// fills its offset columns from the tz database
+func checkTimezone(_ context.Context, _ *Repository[models.Timezone], _ string, _ uuid.UUID, timezone *models.Timezone) error {
+	if err := timezoneValidator.ValidateTimezone(timezone.TimezoneCode, timezone.TimezoneName).Err(); err != nil {
+		return err
+	}
+	loc, _ := timezoneValidator.Location(timezone.TimezoneCode)
+	setOffsets(timezone, loc, time.Now())
+	return nil
+}
+
+// setOffsets derives the standard offset and daylight saving shift of loc
+// from the offsets in effect in January and July of the year of now
+func setOffsets(timezone *models.Timezone, loc *time.Location, now time.Time) {
+	_, january := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC).In(loc).Zone()
+	_, july := time.Date(now.Year(), time.July, 1, 0, 0, 0, 0, time.UTC).In(loc).Zone()
+	standard, daylight := january, july
+	if july < january {
+		// Southern hemisphere
+		standard, daylight = july, january
+	}
+
+	timezone.UTCOffsetHours = standard / 3600
+	timezone.UTCOffsetMinutes = standard % 3600 / 60
+	timezone.SupportsDST = daylight != standard
+	timezone.DSTOffsetHours = nil
+	if timezone.SupportsDST {
+		shift := (daylight - standard) / 3600
+		timezone.DSTOffsetHours = &shift
+	}
+}
+
+// CountryTimezoneView is a time zone link with the codes of the country,
+// subdivision and zone
+type CountryTimezoneView struct {
+	models.CountryTimezone
+	CountryCode     string  `json:"country_code"`
+	SubdivisionCode *string `json:"subdivision_code,omitempty"`
+	SubdivisionName *string `json:"subdivision_name,omitempty"`
+	TimezoneCode    string  `json:"timezone_code"`
+	TimezoneName    string  `json:"timezone_name"`
+}
+
+// checkCountryTimezone checks that the country, subdivision and time zone of
+// a link exist, that the subdivision belongs to the country and that the
+// link is not made twice
+func checkCountryTimezone(ctx context.Context, r *Repository[models.CountryTimezone], tenantID string, id uuid.UUID, link *models.CountryTimezone) error {
+	db := r.db.WithContext(ctx)
+	var found int64
+	if err := db.Model(&models.Country{}).
+		Where("country_id = ? AND tenant_id = ? AND is_deleted = ?", link.CountryID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("country_id", "country not found")
+	}
+	if err := db.Model(&models.Timezone{}).
+		Where("timezone_id = ? AND tenant_id = ? AND is_deleted = ?", link.TimezoneID, tenantID, false).
+		Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve timezone", err)
+	}
+	if found == 0 {
+		return errors.NewValidationError("timezone_id", "timezone not found")
+	}
+	if link.SubdivisionID != nil {
+		if err := db.Model(&models.CountrySubdivision{}).
+			Where("subdivision_id = ? AND country_id = ? AND tenant_id = ? AND is_deleted = ?", *link.SubdivisionID, link.CountryID, tenantID, false).
+			Count(&found).Error; err != nil {
+			return errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve subdivision", err)
+		}
+		if found == 0 {
+			return errors.NewValidationError("subdivision_id", "subdivision not found in this country")
+		}
+	}
+
+	existing := db.Model(new(models.CountryTimezone)).
+		Where("tenant_id = ? AND country_id = ? AND timezone_id = ? AND is_deleted = ? AND country_timezone_id <> ?",
+			tenantID, link.CountryID, link.TimezoneID, false, id)
+	if link.SubdivisionID != nil {
+		existing = existing.Where("subdivision_id = ?", *link.SubdivisionID)
+	} else {
+		existing = existing.Where("subdivision_id IS NULL")
+	}
+	if err := existing.Count(&found).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to check country timezones", err)
+	}
+	if found > 0 {
+		return errors.NewRepositoryError("DUPLICATE_KEY", "the timezone is already linked", nil)
+	}
+	return nil
+}
+
+// ForCountry returns the time zones of a country and its subdivisions,
+// country-wide zones first
+func (r *CountryTimezoneRepository) ForCountry(ctx context.Context, tenantID string, countryID uuid.UUID) ([]CountryTimezoneView, error) {
+	var views []CountryTimezoneView
+	err := r.db.WithContext(ctx).
+		Table(r.schema.Table+" ct").
+		Select("ct.*, c.country_code, s.subdivision_code, s.subdivision_name, tz.timezone_code, tz.timezone_name").
+		Joins("JOIN "+models.Country{}.TableName()+" c ON c.country_id = ct.country_id AND c.is_deleted = false").
+		Joins("JOIN "+models.Timezone{}.TableName()+" tz ON tz.timezone_id = ct.timezone_id AND tz.is_deleted = false").
+		Joins("LEFT JOIN "+models.CountrySubdivision{}.TableName()+" s ON s.subdivision_id = ct.subdivision_id").
+		Where("ct.tenant_id = ? AND ct.is_deleted = ? AND ct.country_id = ?", tenantID, false, countryID).
+		Where("ct.subdivision_id IS NULL OR s.is_deleted = false").
+		Order("s.subdivision_code NULLS FIRST, tz.timezone_code").
+		Scan(&views).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country timezones", err)
+	}
+	return views, nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestSetOffsets(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		zone    string
+		hours   int
+		minutes int
+		dst     *int
+	}{
+		{"UTC", 0, 0, nil},
+		{"Europe/Berlin", 1, 0, intPtr(1)},
+		{"America/New_York", -5, 0, intPtr(1)},
+		{"Asia/Kolkata", 5, 30, nil},
+		{"Asia/Kathmandu", 5, 45, nil},
+		{"America/St_Johns", -3, -30, intPtr(1)},
+		{"Australia/Sydney", 10, 0, intPtr(1)},
+		{"America/Sao_Paulo", -3, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.zone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Stale values from a previous write must not survive
+			tz := &models.Timezone{TimezoneCode: tt.zone, SupportsDST: true, DSTOffsetHours: intPtr(2)}
+			setOffsets(tz, loc, now)
+
+			if tz.UTCOffsetHours != tt.hours || tz.UTCOffsetMinutes != tt.minutes {
+				t.Errorf("offset = %d:%d, want %d:%d", tz.UTCOffsetHours, tz.UTCOffsetMinutes, tt.hours, tt.minutes)
+			}
+			if tz.SupportsDST != (tt.dst != nil) {
+				t.Errorf("SupportsDST = %v, want %v", tz.SupportsDST, tt.dst != nil)
+			}
+			if (tz.DSTOffsetHours == nil) != (tt.dst == nil) || (tt.dst != nil && *tz.DSTOffsetHours != *tt.dst) {
+				t.Errorf("DSTOffsetHours = %v, want %v", tz.DSTOffsetHours, tt.dst)
+			}
+		})
+	}
+}
+
+func TestCheckTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    models.Timezone
+		wantErr bool
+	}{
+		{"iana zone gets its offsets", models.Timezone{TimezoneCode: "Asia/Tokyo", TimezoneName: "Japan Standard Time", UTCOffsetHours: -1}, false},
+		{"unknown zone", models.Timezone{TimezoneCode: "JST", TimezoneName: "Japan Standard Time"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTimezone(context.Background(), nil, "default-tenant", uuid.Nil, &tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkTimezone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.zone.UTCOffsetHours != 9 {
+				t.Errorf("UTCOffsetHours = %d, want 9", tt.zone.UTCOffsetHours)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
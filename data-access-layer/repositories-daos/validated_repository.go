@@ -6,6 +6,7 @@ import (
 	"time"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/validation"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
 	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
@@ -14,12 +15,18 @@ import (
 type ValidatedCountryRepository struct {
 	db        *gorm.DB
 	validator *validation.SchemaValidator
+	history   *historyRecorder[models.Country]
 }
 
 func NewValidatedCountryRepository(db *gorm.DB) CountryRepositoryInterface {
+	s, err := parseSchema[models.Country](db)
+	if err != nil {
+		panic(fmt.Sprintf("repositories: cannot parse country model: %v", err))
+	}
 	return &ValidatedCountryRepository{
 		db:        db,
 		validator: validation.NewSchemaValidator(db),
+		history:   newHistoryRecorder[models.Country](s, CountrySpec.Name, CountrySpec.CodeColumn),
 	}
 }
 
@@ -90,19 +97,20 @@ func (r *ValidatedCountryRepository) Create(ctx context.Context, tenantID string
 	country.Version = 1
 	country.SourceSystem = "reference_master_geopolitical"
 	
-	// Database operation
-	if err := r.db.WithContext(ctx).Create(country).Error; err != nil {
-		// Map database errors
-		if isDuplicateKeyError(err) {
-			return errors.NewRepositoryError("DUPLICATE_KEY", "Country code already exists", err)
-		}
-		if isConstraintViolationError(err) {
-			return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "Data violates database constraints", err)
+	// Database operation and history in one transaction
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(country).Error; err != nil {
+			// Map database errors
+			if isDuplicateKeyError(err) {
+				return errors.NewRepositoryError("DUPLICATE_KEY", "Country code already exists", err)
+			}
+			if isConstraintViolationError(err) {
+				return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "Data violates database constraints", err)
+			}
+			return errors.NewRepositoryError("CREATE_FAILED", "Failed to create country", err)
 		}
-		return errors.NewRepositoryError("CREATE_FAILED", "Failed to create country", err)
-	}
-	
-	return nil
+		return r.history.record(ctx, tx, tenantID, HistoryCreate, nil, country)
+	})
 }
 
 func (r *ValidatedCountryRepository) Update(ctx context.Context, tenantID string, country *models.Country) error {
@@ -123,59 +131,106 @@ func (r *ValidatedCountryRepository) Update(ctx context.Context, tenantID string
 	now := time.Now()
 	country.UpdatedAt = &now
 	
-	result := r.db.WithContext(ctx).
-		Where("country_code = ? AND tenant_id = ? AND version = ?", country.CountryCode, tenantID, country.Version).
-		Updates(map[string]interface{}{
-			"country_name":   country.CountryName,
-			"iso3_code":      country.ISO3Code,
-			"official_name":  country.OfficialName,
-			"capital_city":   country.CapitalCity,
-			"continent_code": country.ContinentCode,
-			"phone_prefix":   country.PhonePrefix,
-			"is_active":      country.IsActive,
-			"updated_at":     now,
-			"version":        gorm.Expr("version + 1"),
-		})
-	
-	if result.Error != nil {
-		if isConstraintViolationError(result.Error) {
-			return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "Data violates database constraints", result.Error)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// country.Version is the version the caller last saw; zero skips the check
+		before, err := r.lockCurrent(tx, tenantID, country.CountryCode, country.Version)
+		if err != nil {
+			return err
 		}
-		return errors.NewRepositoryError("UPDATE_FAILED", "Failed to update country", result.Error)
-	}
-	
-	if result.RowsAffected == 0 {
-		return errors.NewRepositoryError("NOT_FOUND", "Country not found or version conflict", nil)
-	}
-	
-	return nil
+		
+		result := tx.Model(&models.Country{}).
+			Where("country_code = ? AND tenant_id = ? AND version = ?", country.CountryCode, tenantID, before.Version).
+			Updates(map[string]interface{}{
+				"country_name":     country.CountryName,
+				"iso3_code":        country.ISO3Code,
+				"official_name":    country.OfficialName,
+				"capital_city":     country.CapitalCity,
+				"continent_code":   country.ContinentCode,
+				"phone_prefix":     country.PhonePrefix,
+				"is_active":        country.IsActive,
+				"valid_from":       country.ValidFrom,
+				"valid_to":         country.ValidTo,
+				"retention_policy": country.RetentionPolicy,
+				"change_reason":    country.ChangeReason,
+				"updated_at":       now,
+				"version":          gorm.Expr("version + 1"),
+			})
+		
+		if result.Error != nil {
+			if isConstraintViolationError(result.Error) {
+				return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "Data violates database constraints", result.Error)
+			}
+			return errors.NewRepositoryError("UPDATE_FAILED", "Failed to update country", result.Error)
+		}
+		
+		if result.RowsAffected == 0 {
+			return errors.NewRepositoryError("NOT_FOUND", "Country not found or version conflict", nil)
+		}
+		
+		if err := r.recordChange(ctx, tx, tenantID, HistoryUpdate, before); err != nil {
+			return err
+		}
+		country.Version = before.Version + 1
+		return nil
+	})
 }
 
-func (r *ValidatedCountryRepository) Delete(ctx context.Context, tenantID, code string) error {
+func (r *ValidatedCountryRepository) Delete(ctx context.Context, tenantID, code string, expectedVersion int) error {
 	// Input validation
 	if code == "" {
 		return errors.NewValidationError("country_code", "cannot be empty")
 	}
 	
 	now := time.Now()
-	result := r.db.WithContext(ctx).
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := r.lockCurrent(tx, tenantID, code, expectedVersion)
+		if err != nil {
+			return err
+		}
+		
+		result := tx.Model(&models.Country{}).
+			Where("country_code = ? AND tenant_id = ? AND is_deleted = ?", code, tenantID, false).
+			Updates(map[string]interface{}{
+				"is_deleted":  true,
+				"deleted_at":  now,
+				"updated_at":  now,
+				"version":     gorm.Expr("version + 1"),
+			})
+		
+		if result.Error != nil {
+			return errors.NewRepositoryError("DELETE_FAILED", "Failed to delete country", result.Error)
+		}
+		
+		return r.recordChange(ctx, tx, tenantID, HistoryDelete, before)
+	})
+}
+
+// lockCurrent reads the live country for a write, locks it until the
+// transaction ends and checks it against a positive expected version
+func (r *ValidatedCountryRepository) lockCurrent(tx *gorm.DB, tenantID, code string, expectedVersion int) (*models.Country, error) {
+	var country models.Country
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("country_code = ? AND tenant_id = ? AND is_deleted = ?", code, tenantID, false).
-		Updates(map[string]interface{}{
-			"is_deleted":  true,
-			"deleted_at":  now,
-			"updated_at":  now,
-			"version":     gorm.Expr("version + 1"),
-		})
-	
-	if result.Error != nil {
-		return errors.NewRepositoryError("DELETE_FAILED", "Failed to delete country", result.Error)
+		First(&country).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewRepositoryError("NOT_FOUND", "Country not found", nil)
+		}
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to retrieve country", err)
 	}
-	
-	if result.RowsAffected == 0 {
-		return errors.NewRepositoryError("NOT_FOUND", "Country not found", nil)
+	if expectedVersion > 0 && country.Version != expectedVersion {
+		return nil, errors.NewVersionConflictError("country", expectedVersion, country.Version)
 	}
-	
-	return nil
+	return &country, nil
+}
+
+// recordChange reloads the country after a write and records the difference
+func (r *ValidatedCountryRepository) recordChange(ctx context.Context, tx *gorm.DB, tenantID, operation string, before *models.Country) error {
+	var after models.Country
+	if err := tx.Where("country_id = ?", before.CountryID).First(&after).Error; err != nil {
+		return errors.NewRepositoryError("QUERY_FAILED", "Failed to reload country", err)
+	}
+	return r.history.record(ctx, tx, tenantID, operation, before, &after)
 }
 
 func (r *ValidatedCountryRepository) BulkCreate(ctx context.Context, tenantID string, countries []models.Country) error {
@@ -204,6 +259,12 @@ func (r *ValidatedCountryRepository) BulkCreate(ctx context.Context, tenantID st
 			return errors.NewRepositoryError("BULK_CREATE_FAILED", "Failed to bulk create countries", err)
 		}
 		
+		for i := range countries {
+			if err := r.history.record(ctx, tx, tenantID, HistoryCreate, nil, &countries[i]); err != nil {
+				return err
+			}
+		}
+		
 		return nil
 	})
 }
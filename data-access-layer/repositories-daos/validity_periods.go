@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// List returns a page of countries valid on opts.AsOf (today by default).
+// Historical pages carry the names in force on that date; the order still
+// follows the current names.
+func (r *CountryRepository) List(ctx context.Context, tenantID string, opts ListOptions) (*Page[models.Country], error) {
+	page, err := r.Repository.List(ctx, tenantID, opts)
+	if err != nil || opts.AsOf == nil {
+		return page, err
+	}
+	return page, r.applyPeriods(ctx, tenantID, page.Items, *opts.AsOf)
+}
+
+// GetByCodeAsOf retrieves a country as it was on asOf, or nil if it did not
+// exist on that date
+func (r *CountryRepository) GetByCodeAsOf(ctx context.Context, tenantID, code string, asOf time.Time) (*models.Country, error) {
+	country, err := r.Repository.GetByCodeAsOf(ctx, tenantID, code, asOf)
+	if err != nil || country == nil {
+		return country, err
+	}
+	countries := []models.Country{*country}
+	if err := r.applyPeriods(ctx, tenantID, countries, asOf); err != nil {
+		return nil, err
+	}
+	return &countries[0], nil
+}
+
+// Periods returns the naming periods of a country, oldest first
+func (r *CountryRepository) Periods(ctx context.Context, tenantID string, countryID uuid.UUID) ([]models.CountryPeriod, error) {
+	return periodsOf[models.CountryPeriod](ctx, r.db, tenantID, "country_id", countryID)
+}
+
+// RecordPeriod starts a new naming period for a country, closing the open
+// period on period.ValidFrom
+func (r *CountryRepository) RecordPeriod(ctx context.Context, tenantID string, period *models.CountryPeriod) error {
+	if period.ValidTo != nil && !period.ValidTo.After(period.ValidFrom) {
+		return errors.NewValidationError("valid_to", "must be after valid_from")
+	}
+	period.TenantID = tenantID
+	return recordPeriod(ctx, r.db, tenantID, "country_id", period.CountryID, period.ValidFrom, period)
+}
+
+func (r *CountryRepository) applyPeriods(ctx context.Context, tenantID string, countries []models.Country, asOf time.Time) error {
+	ids := make([]uuid.UUID, len(countries))
+	for i := range countries {
+		ids[i] = countries[i].CountryID
+	}
+	periods, err := periodsAt[models.CountryPeriod](ctx, r.db, tenantID, "country_id", ids, asOf)
+	if err != nil {
+		return err
+	}
+
+	byCountry := make(map[uuid.UUID]models.CountryPeriod, len(periods))
+	for _, p := range periods {
+		byCountry[p.CountryID] = p
+	}
+	for i := range countries {
+		p, ok := byCountry[countries[i].CountryID]
+		if !ok {
+			continue
+		}
+		countries[i].CountryName = p.CountryName
+		if p.OfficialName != nil {
+			countries[i].OfficialName = p.OfficialName
+		}
+		if p.CapitalCity != nil {
+			countries[i].CapitalCity = p.CapitalCity
+		}
+	}
+	return nil
+}
+
+// List returns a page of subdivisions valid on opts.AsOf (today by default),
+// carrying the names in force on that date
+func (r *SubdivisionRepository) List(ctx context.Context, tenantID string, opts ListOptions) (*Page[models.CountrySubdivision], error) {
+	page, err := r.Repository.List(ctx, tenantID, opts)
+	if err != nil || opts.AsOf == nil {
+		return page, err
+	}
+	return page, r.applyPeriods(ctx, tenantID, page.Items, *opts.AsOf)
+}
+
+// Periods returns the naming periods of a subdivision, oldest first
+func (r *SubdivisionRepository) Periods(ctx context.Context, tenantID string, subdivisionID uuid.UUID) ([]models.SubdivisionPeriod, error) {
+	return periodsOf[models.SubdivisionPeriod](ctx, r.db, tenantID, "subdivision_id", subdivisionID)
+}
+
+// RecordPeriod starts a new naming period for a subdivision, closing the
+// open period on period.ValidFrom
+func (r *SubdivisionRepository) RecordPeriod(ctx context.Context, tenantID string, period *models.SubdivisionPeriod) error {
+	if period.ValidTo != nil && !period.ValidTo.After(period.ValidFrom) {
+		return errors.NewValidationError("valid_to", "must be after valid_from")
+	}
+	period.TenantID = tenantID
+	return recordPeriod(ctx, r.db, tenantID, "subdivision_id", period.SubdivisionID, period.ValidFrom, period)
+}
+
+func (r *SubdivisionRepository) applyPeriods(ctx context.Context, tenantID string, subdivisions []models.CountrySubdivision, asOf time.Time) error {
+	ids := make([]uuid.UUID, len(subdivisions))
+	for i := range subdivisions {
+		ids[i] = subdivisions[i].SubdivisionID
+	}
+	periods, err := periodsAt[models.SubdivisionPeriod](ctx, r.db, tenantID, "subdivision_id", ids, asOf)
+	if err != nil {
+		return err
+	}
+
+	bySubdivision := make(map[uuid.UUID]models.SubdivisionPeriod, len(periods))
+	for _, p := range periods {
+		bySubdivision[p.SubdivisionID] = p
+	}
+	for i := range subdivisions {
+		p, ok := bySubdivision[subdivisions[i].SubdivisionID]
+		if !ok {
+			continue
+		}
+		subdivisions[i].SubdivisionName = p.SubdivisionName
+		if p.SubdivisionType != nil {
+			subdivisions[i].SubdivisionType = *p.SubdivisionType
+		}
+	}
+	return nil
+}
+
+// periodsAt loads the periods of the given owners that cover asOf
+func periodsAt[P any](ctx context.Context, db *gorm.DB, tenantID, ownerColumn string, owners []uuid.UUID, asOf time.Time) ([]P, error) {
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	date := asOf.Format(DateLayout)
+	var periods []P
+	err := db.WithContext(ctx).
+		Where("tenant_id = ? AND "+ownerColumn+" IN ?", tenantID, owners).
+		Where("valid_from <= ?::date AND (valid_to IS NULL OR valid_to > ?::date)", date, date).
+		Find(&periods).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to load validity periods", err)
+	}
+	return periods, nil
+}
+
+// periodsOf loads every period of one owner ordered by valid_from
+func periodsOf[P any](ctx context.Context, db *gorm.DB, tenantID, ownerColumn string, owner uuid.UUID) ([]P, error) {
+	var periods []P
+	err := db.WithContext(ctx).
+		Where("tenant_id = ? AND "+ownerColumn+" = ?", tenantID, owner).
+		Order("valid_from ASC").
+		Find(&periods).Error
+	if err != nil {
+		return nil, errors.NewRepositoryError("QUERY_FAILED", "Failed to load validity periods", err)
+	}
+	return periods, nil
+}
+
+// recordPeriod closes the owner's open period on validFrom and inserts the
+// new one in a single transaction. Overlaps are rejected by the exclusion
+// constraint on the period table.
+func recordPeriod[P any](ctx context.Context, db *gorm.DB, tenantID, ownerColumn string, owner uuid.UUID, validFrom time.Time, period *P) error {
+	date := validFrom.Format(DateLayout)
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(new(P)).
+			Where("tenant_id = ? AND "+ownerColumn+" = ? AND valid_to IS NULL AND valid_from < ?::date", tenantID, owner, date).
+			Update("valid_to", date).Error; err != nil {
+			return err
+		}
+		return tx.Create(period).Error
+	})
+	if err != nil {
+		if isConstraintViolationError(err) {
+			return errors.NewRepositoryError("CONSTRAINT_VIOLATION", "Period overlaps an existing validity period", err)
+		}
+		return errors.NewRepositoryError("CREATE_FAILED", "Failed to record validity period", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+// dryRunDB renders statements without connecting to a database
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestValidAt(t *testing.T) {
+	db := dryRunDB(t)
+	countries := NewRepository[models.Country](db, CountrySpec)
+	regions := NewRepository[models.Region](db, RegionSpec)
+	asOf := time.Date(1990, 10, 2, 23, 0, 0, 0, time.UTC)
+	today := time.Now().Format(DateLayout)
+
+	tests := []struct {
+		name    string
+		render  func() (string, error)
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "country on a past date",
+			render: func() (string, error) { return renderValidAt(db, countries, &asOf) },
+			want:   `SELECT * FROM "domain_reference_master_geopolitical"."countries" WHERE (valid_from IS NULL OR valid_from <= '1990-10-02'::date) AND (valid_to IS NULL OR valid_to > '1990-10-02'::date)`,
+		},
+		{
+			name:   "country today",
+			render: func() (string, error) { return renderValidAt(db, countries, nil) },
+			want:   `SELECT * FROM "domain_reference_master_geopolitical"."countries" WHERE (valid_from IS NULL OR valid_from <= '` + today + `'::date) AND (valid_to IS NULL OR valid_to > '` + today + `'::date)`,
+		},
+		{
+			name:   "region without a period",
+			render: func() (string, error) { return renderValidAt(db, regions, nil) },
+			want:   `SELECT * FROM "domain_reference_master_geopolitical"."regions"`,
+		},
+		{
+			name:    "region on a past date",
+			render:  func() (string, error) { return renderValidAt(db, regions, &asOf) },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.render()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("validAt() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func renderValidAt[T any](db *gorm.DB, repo *Repository[T], asOf *time.Time) (string, error) {
+	var err error
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		query, verr := repo.validAt(tx.Model(new(T)), asOf)
+		if verr != nil {
+			err = verr
+			return tx
+		}
+		return query.Find(&[]T{})
+	})
+	if err != nil {
+		return "", err
+	}
+	return sql, nil
+}
+
+func TestCheckPeriod(t *testing.T) {
+	day := func(d int) *time.Time {
+		t := time.Date(2002, 1, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		from    *time.Time
+		to      *time.Time
+		wantErr bool
+	}{
+		{"open period", nil, nil, false},
+		{"open start", nil, day(1), false},
+		{"open end", day(1), nil, false},
+		{"one day", day(1), day(2), false},
+		{"empty period", day(1), day(1), true},
+		{"ends before it starts", day(2), day(1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkPeriod(tt.from, tt.to); (err != nil) != tt.wantErr {
+				t.Errorf("checkPeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"strings"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+// AddressFieldLetters are the field tokens of address templates
+const AddressFieldLetters = "NOADCSZX"
+
+// AddressFormatValidator checks address format templates and labels
+type AddressFormatValidator struct {
+	subdivisionLabels map[string]bool
+	localityLabels    map[string]bool
+	postalCodeLabels  map[string]bool
+}
+
+func NewAddressFormatValidator() *AddressFormatValidator {
+	return &AddressFormatValidator{
+		subdivisionLabels: setOf("area", "county", "department", "district", "do_si", "emirate", "island",
+			"oblast", "parish", "prefecture", "province", "region", "state"),
+		localityLabels:   setOf("city", "district", "post_town", "suburb"),
+		postalCodeLabels: setOf("eircode", "pin", "postal", "zip"),
+	}
+}
+
+// ValidateAddressFormat validates the templates, field letters, labels and
+// subdivision style of an address format
+func (v *AddressFormatValidator) ValidateAddressFormat(format *models.AddressFormat) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if format.Template == "" {
+		result.AddError("template", "Template is required")
+	} else if message := checkAddressTemplate(format.Template); message != "" {
+		result.AddError("template", message)
+	}
+	if format.LatinTemplate != nil {
+		if message := checkAddressTemplate(*format.LatinTemplate); message != "" {
+			result.AddError("latin_template", message)
+		}
+	}
+	if format.RequiredFields != nil && !isFieldLetters(*format.RequiredFields) {
+		result.AddError("required_fields", "Must be field letters from "+AddressFieldLetters)
+	}
+	if format.UppercaseFields != nil && !isFieldLetters(*format.UppercaseFields) {
+		result.AddError("uppercase_fields", "Must be field letters from "+AddressFieldLetters)
+	}
+	if format.SubdivisionLabel != nil && !v.subdivisionLabels[*format.SubdivisionLabel] {
+		result.AddError("subdivision_label", "Unknown label, e.g. state, province or prefecture")
+	}
+	if format.LocalityLabel != nil && !v.localityLabels[*format.LocalityLabel] {
+		result.AddError("locality_label", "Must be city, district, post_town or suburb")
+	}
+	if format.PostalCodeLabel != nil && !v.postalCodeLabels[*format.PostalCodeLabel] {
+		result.AddError("postal_code_label", "Must be eircode, pin, postal or zip")
+	}
+	if format.SubdivisionStyle != "" && format.SubdivisionStyle != models.SubdivisionStyleName &&
+		format.SubdivisionStyle != models.SubdivisionStyleCode {
+		result.AddError("subdivision_style", "Must be name or code")
+	}
+
+	return result
+}
+
+// checkAddressTemplate returns why a template is invalid, or ""
+func checkAddressTemplate(template string) string {
+	seen := map[byte]bool{}
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+		if i+1 == len(template) {
+			return "Ends with a lone %"
+		}
+		i++
+		token := template[i]
+		if token == 'n' {
+			continue
+		}
+		if !strings.ContainsRune(AddressFieldLetters, rune(token)) {
+			return "Unknown field %" + string(token)
+		}
+		if seen[token] {
+			return "Field %" + string(token) + " appears twice"
+		}
+		seen[token] = true
+	}
+	if !seen['A'] {
+		return "Must contain the address lines %A"
+	}
+	return ""
+}
+
+func isFieldLetters(letters string) bool {
+	for _, r := range letters {
+		if !strings.ContainsRune(AddressFieldLetters, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func setOf(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
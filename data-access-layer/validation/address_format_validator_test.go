@@ -0,0 +1,52 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestValidateAddressFormat(t *testing.T) {
+	v := NewAddressFormatValidator()
+
+	tests := []struct {
+		name   string
+		format models.AddressFormat
+		failed []string
+	}{
+		{
+			name: "united states",
+			format: models.AddressFormat{Template: "%N%n%O%n%A%n%C, %S %Z", RequiredFields: stringPtr("ACSZ"), UppercaseFields: stringPtr("CS"),
+				SubdivisionLabel: stringPtr("state"), LocalityLabel: stringPtr("city"), PostalCodeLabel: stringPtr("zip"), SubdivisionStyle: models.SubdivisionStyleCode},
+			failed: []string{},
+		},
+		{
+			name:   "latin template",
+			format: models.AddressFormat{Template: "〒%Z%n%S%n%A%n%O%n%N", LatinTemplate: stringPtr("%N%n%O%n%A, %S%n%Z"), SubdivisionStyle: models.SubdivisionStyleName},
+			failed: []string{},
+		},
+		{name: "missing template", format: models.AddressFormat{}, failed: []string{"template"}},
+		{name: "template without address lines", format: models.AddressFormat{Template: "%N%n%C"}, failed: []string{"template"}},
+		{name: "unknown field", format: models.AddressFormat{Template: "%A%n%Q"}, failed: []string{"template"}},
+		{name: "field twice", format: models.AddressFormat{Template: "%A%n%C %C"}, failed: []string{"template"}},
+		{name: "lone percent", format: models.AddressFormat{Template: "%A%"}, failed: []string{"template"}},
+		{name: "invalid latin template", format: models.AddressFormat{Template: "%A", LatinTemplate: stringPtr("%N")}, failed: []string{"latin_template"}},
+		{name: "bad field letters", format: models.AddressFormat{Template: "%A", RequiredFields: stringPtr("AQ"), UppercaseFields: stringPtr("a")}, failed: []string{"required_fields", "uppercase_fields"}},
+		{
+			name:   "unknown labels",
+			format: models.AddressFormat{Template: "%A", SubdivisionLabel: stringPtr("canton"), LocalityLabel: stringPtr("state"), PostalCodeLabel: stringPtr("plz")},
+			failed: []string{"locality_label", "postal_code_label", "subdivision_label"},
+		},
+		{name: "unknown subdivision style", format: models.AddressFormat{Template: "%A", SubdivisionStyle: "abbreviation"}, failed: []string{"subdivision_style"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateAddressFormat(&tt.format)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateAddressFormat() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
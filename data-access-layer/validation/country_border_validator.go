@@ -0,0 +1,43 @@
+package validation
+
+import (
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+// CountryBorderValidator checks borders between countries
+type CountryBorderValidator struct {
+	borderTypes map[string]bool
+}
+
+func NewCountryBorderValidator() *CountryBorderValidator {
+	return &CountryBorderValidator{
+		borderTypes: map[string]bool{
+			models.BorderTypeLand:     true,
+			models.BorderTypeMaritime: true,
+		},
+	}
+}
+
+// ValidBorderType reports whether t is a known border type
+func (v *CountryBorderValidator) ValidBorderType(t string) bool {
+	return v.borderTypes[t]
+}
+
+// ValidateCountryBorder validates the countries, type and length of a border
+func (v *CountryBorderValidator) ValidateCountryBorder(border *models.CountryBorder) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if border.NeighborCountryID == border.CountryID {
+		result.AddError("neighbor_country_id", "A country cannot border itself")
+	}
+	if border.BorderType == "" {
+		result.AddError("border_type", "Border type is required")
+	} else if !v.borderTypes[border.BorderType] {
+		result.AddError("border_type", "Must be LAND or MARITIME")
+	}
+	if border.LengthKm != nil && *border.LengthKm <= 0 {
+		result.AddError("length_km", "Must be greater than 0")
+	}
+
+	return result
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestValidateCountryBorder(t *testing.T) {
+	v := NewCountryBorderValidator()
+	france, spain := uuid.New(), uuid.New()
+	length, zero := 646.0, 0.0
+
+	tests := []struct {
+		name   string
+		border models.CountryBorder
+		failed []string
+	}{
+		{"land border", models.CountryBorder{CountryID: spain, NeighborCountryID: france, BorderType: models.BorderTypeLand, LengthKm: &length}, []string{}},
+		{"maritime border without length", models.CountryBorder{CountryID: spain, NeighborCountryID: france, BorderType: models.BorderTypeMaritime}, []string{}},
+		{"border with itself", models.CountryBorder{CountryID: spain, NeighborCountryID: spain, BorderType: models.BorderTypeLand}, []string{"neighbor_country_id"}},
+		{"missing type", models.CountryBorder{CountryID: spain, NeighborCountryID: france}, []string{"border_type"}},
+		{"lowercase type", models.CountryBorder{CountryID: spain, NeighborCountryID: france, BorderType: "land"}, []string{"border_type"}},
+		{"zero length", models.CountryBorder{CountryID: spain, NeighborCountryID: france, BorderType: models.BorderTypeLand, LengthKm: &zero}, []string{"length_km"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateCountryBorder(&tt.border)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateCountryBorder() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"regexp"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+var (
+	codeSystemPattern   = regexp.MustCompile(`^[a-z][a-z0-9_]{1,19}$`)
+	externalCodePattern = regexp.MustCompile(`^[A-Z0-9-]{1,10}$`)
+)
+
+// CountryCodeValidator checks code system names and the codes of countries
+// in external systems
+type CountryCodeValidator struct {
+	// isoSystems are held on the country and cannot be stored as external codes
+	isoSystems map[string]bool
+	// codePatterns narrow the codes of well-known external systems
+	codePatterns map[string]*regexp.Regexp
+}
+
+func NewCountryCodeValidator() *CountryCodeValidator {
+	return &CountryCodeValidator{
+		isoSystems: map[string]bool{
+			models.CodeSystemISO2:    true,
+			models.CodeSystemISO3:    true,
+			models.CodeSystemNumeric: true,
+		},
+		codePatterns: map[string]*regexp.Regexp{
+			models.CodeSystemFIPS:      regexp.MustCompile(`^[A-Z]{2}$`),
+			models.CodeSystemIOC:       regexp.MustCompile(`^[A-Z]{3}$`),
+			models.CodeSystemITU:       regexp.MustCompile(`^[A-Z]{1,3}$`),
+			models.CodeSystemGENC:      regexp.MustCompile(`^[A-Z]{2,3}$`),
+			models.CodeSystemWorldBank: regexp.MustCompile(`^[A-Z0-9]{2,3}$`),
+		},
+	}
+}
+
+// ValidCodeSystem reports whether system is a well-formed code system name,
+// ISO or external
+func (v *CountryCodeValidator) ValidCodeSystem(system string) bool {
+	return codeSystemPattern.MatchString(system)
+}
+
+// IsISOSystem reports whether system is one of the ISO 3166-1 code systems
+func (v *CountryCodeValidator) IsISOSystem(system string) bool {
+	return v.isoSystems[system]
+}
+
+// ValidateExternalCode validates the system and code of an external code of
+// a country. Codes are uppercase.
+func (v *CountryCodeValidator) ValidateExternalCode(system, code string) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	switch {
+	case system == "":
+		result.AddError("code_system", "Code system is required")
+	case v.isoSystems[system]:
+		result.AddError("code_system", "ISO codes are held on the country")
+	case !codeSystemPattern.MatchString(system):
+		result.AddError("code_system", "Must be 2-20 lowercase letters, digits or underscores")
+	}
+
+	pattern := externalCodePattern
+	if p, ok := v.codePatterns[system]; ok {
+		pattern = p
+	}
+	if code == "" {
+		result.AddError("code", "Code is required")
+	} else if !pattern.MatchString(code) {
+		result.AddError("code", "Must match "+pattern.String())
+	}
+
+	return result
+}
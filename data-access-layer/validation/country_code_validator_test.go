@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateExternalCode(t *testing.T) {
+	v := NewCountryCodeValidator()
+
+	tests := []struct {
+		name   string
+		system string
+		code   string
+		failed []string
+	}{
+		{"fips", "fips", "GM", []string{}},
+		{"ioc", "ioc", "GER", []string{}},
+		{"itu", "itu", "D", []string{}},
+		{"world bank aggregate", "wb", "1W", []string{}},
+		{"custom system", "erp_legacy", "DE-01", []string{}},
+		{"missing system and code", "", "", []string{"code", "code_system"}},
+		{"iso system", "iso2", "DE", []string{"code_system"}},
+		{"uppercase system", "FIPS", "GM", []string{"code_system"}},
+		{"fips code too long", "fips", "GER", []string{"code"}},
+		{"ioc code lowercase", "ioc", "ger", []string{"code"}},
+		{"generic code too long", "erp_legacy", "DEUTSCHLAND", []string{"code"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateExternalCode(tt.system, tt.code)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateExternalCode() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
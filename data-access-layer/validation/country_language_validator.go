@@ -0,0 +1,36 @@
+package validation
+
+import (
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+// CountryLanguageValidator checks country–language links
+type CountryLanguageValidator struct {
+	statuses map[string]bool
+}
+
+func NewCountryLanguageValidator() *CountryLanguageValidator {
+	return &CountryLanguageValidator{
+		statuses: map[string]bool{
+			models.LanguageStatusOfficial:   true,
+			models.LanguageStatusRegional:   true,
+			models.LanguageStatusRecognized: true,
+		},
+	}
+}
+
+// ValidateCountryLanguage validates the status and speaker share of a link
+func (v *CountryLanguageValidator) ValidateCountryLanguage(status string, speakerPercentage *float64) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if status == "" {
+		result.AddError("status", "Status is required")
+	} else if !v.statuses[status] {
+		result.AddError("status", "Must be OFFICIAL, REGIONAL or RECOGNIZED")
+	}
+	if speakerPercentage != nil && (*speakerPercentage < 0 || *speakerPercentage > 100) {
+		result.AddError("speaker_percentage", "Must be between 0 and 100")
+	}
+
+	return result
+}
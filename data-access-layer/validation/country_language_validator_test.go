@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestValidateCountryLanguage(t *testing.T) {
+	v := NewCountryLanguageValidator()
+	share := func(p float64) *float64 { return &p }
+
+	tests := []struct {
+		name   string
+		status string
+		share  *float64
+		failed []string
+	}{
+		{"official", models.LanguageStatusOfficial, share(62.1), []string{}},
+		{"regional without a share", models.LanguageStatusRegional, nil, []string{}},
+		{"recognized by nobody", models.LanguageStatusRecognized, share(0), []string{}},
+		{"spoken by everyone", models.LanguageStatusOfficial, share(100), []string{}},
+		{"missing status", "", nil, []string{"status"}},
+		{"lowercase status", "official", nil, []string{"status"}},
+		{"unknown status", "NATIONAL", nil, []string{"status"}},
+		{"negative share", models.LanguageStatusOfficial, share(-0.1), []string{"speaker_percentage"}},
+		{"share above 100", models.LanguageStatusOfficial, share(100.5), []string{"speaker_percentage"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateCountryLanguage(tt.status, tt.share)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateCountryLanguage() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
@@ -12,6 +12,8 @@ package validation
 import (
 	"regexp"
 	"strings"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
 )
 
 // CountryValidator implements all database constraints in code
@@ -65,4 +67,13 @@ type ValidationResult struct {
 func (vr *ValidationResult) AddError(field, message string) {
 	vr.IsValid = false
 	vr.Errors[field] = message
+}
+
+// Err returns nil for a valid result, otherwise a validation error naming
+// every failed field
+func (vr *ValidationResult) Err() error {
+	if len(vr.Errors) == 0 {
+		return nil
+	}
+	return errors.NewValidationErrors(vr.Errors)
 }
\ No newline at end of file
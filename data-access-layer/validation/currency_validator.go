@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"regexp"
+)
+
+// CurrencyValidator checks ISO 4217 currency data
+type CurrencyValidator struct {
+	alphaPattern *regexp.Regexp
+}
+
+func NewCurrencyValidator() *CurrencyValidator {
+	return &CurrencyValidator{
+		alphaPattern: regexp.MustCompile(`^[A-Z]{3}$`),
+	}
+}
+
+// ValidateCurrency validates the alpha code, numeric code and minor units of
+// a currency. Nil numeric code and minor units are accepted; ISO 4217 leaves
+// the minor units of funds and precious metals undefined.
+func (v *CurrencyValidator) ValidateCurrency(code, name string, numericCode, minorUnits *int16) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if code == "" {
+		result.AddError("currency_code", "Currency code is required")
+	} else if !v.alphaPattern.MatchString(code) {
+		result.AddError("currency_code", "Must be 3 uppercase letters (ISO 4217)")
+	}
+	if name == "" {
+		result.AddError("currency_name", "Currency name is required")
+	} else if len(name) > 100 {
+		result.AddError("currency_name", "Must be at most 100 characters")
+	}
+	if numericCode != nil && (*numericCode < 1 || *numericCode > 999) {
+		result.AddError("numeric_code", "Must be between 001 and 999 (ISO 4217)")
+	}
+	if minorUnits != nil && (*minorUnits < 0 || *minorUnits > 4) {
+		result.AddError("minor_units", "Must be between 0 and 4")
+	}
+
+	return result
+}
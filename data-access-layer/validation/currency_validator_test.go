@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// failedFields lists the fields a validation result rejected, sorted
+func failedFields(result *ValidationResult) []string {
+	fields := []string{}
+	for field := range result.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func int16Ptr(n int16) *int16 {
+	return &n
+}
+
+func TestValidateCurrency(t *testing.T) {
+	v := NewCurrencyValidator()
+
+	tests := []struct {
+		name        string
+		code        string
+		currency    string
+		numericCode *int16
+		minorUnits  *int16
+		failed      []string
+	}{
+		{"euro", "EUR", "Euro", int16Ptr(978), int16Ptr(2), []string{}},
+		{"no minor units", "JPY", "Yen", int16Ptr(392), int16Ptr(0), []string{}},
+		{"four minor units", "CLF", "Unidad de Fomento", int16Ptr(990), int16Ptr(4), []string{}},
+		{"precious metal without minor units", "XAU", "Gold", int16Ptr(959), nil, []string{}},
+		{"no numeric code", "XXX", "No currency", nil, nil, []string{}},
+		{"missing code and name", "", "", nil, nil, []string{"currency_code", "currency_name"}},
+		{"lowercase code", "eur", "Euro", nil, nil, []string{"currency_code"}},
+		{"numeric code zero", "EUR", "Euro", int16Ptr(0), nil, []string{"numeric_code"}},
+		{"numeric code too long", "EUR", "Euro", int16Ptr(1000), nil, []string{"numeric_code"}},
+		{"too many minor units", "EUR", "Euro", nil, int16Ptr(5), []string{"minor_units"}},
+		{"negative minor units", "EUR", "Euro", nil, int16Ptr(-1), []string{"minor_units"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateCurrency(tt.code, tt.currency, tt.numericCode, tt.minorUnits)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateCurrency() failed fields = %v, want %v", got, tt.failed)
+			}
+			if result.IsValid != (len(tt.failed) == 0) {
+				t.Errorf("ValidateCurrency() valid = %v with errors %v", result.IsValid, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"regexp"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+// GroupingValidator checks groupings of countries and their memberships
+type GroupingValidator struct {
+	codePattern     *regexp.Regexp
+	groupingTypes   map[string]bool
+	membershipTypes map[string]bool
+}
+
+func NewGroupingValidator() *GroupingValidator {
+	return &GroupingValidator{
+		codePattern: regexp.MustCompile(`^[A-Z][A-Z0-9_-]{1,19}$`),
+		groupingTypes: map[string]bool{
+			models.GroupingTypePolitical:     true,
+			models.GroupingTypeCustomsUnion:  true,
+			models.GroupingTypeMonetaryUnion: true,
+			models.GroupingTypeFreeMovement:  true,
+			models.GroupingTypeEconomic:      true,
+			models.GroupingTypeCustom:        true,
+		},
+		membershipTypes: map[string]bool{
+			models.MembershipTypeMember:    true,
+			models.MembershipTypeAssociate: true,
+			models.MembershipTypeObserver:  true,
+			models.MembershipTypeCandidate: true,
+		},
+	}
+}
+
+// ValidMembershipType reports whether t is a known membership type
+func (v *GroupingValidator) ValidMembershipType(t string) bool {
+	return v.membershipTypes[t]
+}
+
+// ValidateGrouping validates the code, name and type of a grouping
+func (v *GroupingValidator) ValidateGrouping(grouping *models.Grouping) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if grouping.GroupingCode == "" {
+		result.AddError("grouping_code", "Grouping code is required")
+	} else if !v.codePattern.MatchString(grouping.GroupingCode) {
+		result.AddError("grouping_code", "Must be 2 to 20 uppercase letters, digits, hyphens or underscores, starting with a letter")
+	}
+	if grouping.GroupingName == "" {
+		result.AddError("grouping_name", "Grouping name is required")
+	} else if len(grouping.GroupingName) > 100 {
+		result.AddError("grouping_name", "Must be at most 100 characters")
+	}
+	if grouping.GroupingType == "" {
+		result.AddError("grouping_type", "Grouping type is required")
+	} else if !v.groupingTypes[grouping.GroupingType] {
+		result.AddError("grouping_type", "Must be POLITICAL, CUSTOMS_UNION, MONETARY_UNION, FREE_MOVEMENT, ECONOMIC or CUSTOM")
+	}
+
+	return result
+}
+
+// ValidateMembership validates the type and period of a membership
+func (v *GroupingValidator) ValidateMembership(membership *models.GroupingMembership) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if membership.MembershipType == "" {
+		result.AddError("membership_type", "Membership type is required")
+	} else if !v.membershipTypes[membership.MembershipType] {
+		result.AddError("membership_type", "Must be MEMBER, ASSOCIATE, OBSERVER or CANDIDATE")
+	}
+	if membership.ValidFrom != nil && membership.ValidTo != nil && !membership.ValidTo.After(*membership.ValidFrom) {
+		result.AddError("valid_to", "Must be after valid_from")
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestValidateGrouping(t *testing.T) {
+	v := NewGroupingValidator()
+
+	tests := []struct {
+		name     string
+		grouping models.Grouping
+		failed   []string
+	}{
+		{"european union", models.Grouping{GroupingCode: "EU", GroupingName: "European Union", GroupingType: models.GroupingTypePolitical}, []string{}},
+		{"code with digits and separators", models.Grouping{GroupingCode: "G7_PLUS-2", GroupingName: "G7+", GroupingType: models.GroupingTypeCustom}, []string{}},
+		{"missing everything", models.Grouping{}, []string{"grouping_code", "grouping_name", "grouping_type"}},
+		{"lowercase code", models.Grouping{GroupingCode: "eu", GroupingName: "European Union", GroupingType: models.GroupingTypePolitical}, []string{"grouping_code"}},
+		{"code starting with a digit", models.Grouping{GroupingCode: "7G", GroupingName: "Group of Seven", GroupingType: models.GroupingTypeEconomic}, []string{"grouping_code"}},
+		{"one letter code", models.Grouping{GroupingCode: "E", GroupingName: "Eurozone", GroupingType: models.GroupingTypeMonetaryUnion}, []string{"grouping_code"}},
+		{"name too long", models.Grouping{GroupingCode: "EU", GroupingName: strings.Repeat("x", 101), GroupingType: models.GroupingTypePolitical}, []string{"grouping_name"}},
+		{"unknown type", models.Grouping{GroupingCode: "NATO", GroupingName: "NATO", GroupingType: "MILITARY"}, []string{"grouping_type"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateGrouping(&tt.grouping)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateGrouping() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
+
+func TestValidateMembership(t *testing.T) {
+	v := NewGroupingValidator()
+	joined := time.Date(1973, 1, 1, 0, 0, 0, 0, time.UTC)
+	left := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		membership models.GroupingMembership
+		failed     []string
+	}{
+		{"open membership", models.GroupingMembership{MembershipType: models.MembershipTypeMember, ValidFrom: &joined}, []string{}},
+		{"ended membership", models.GroupingMembership{MembershipType: models.MembershipTypeMember, ValidFrom: &joined, ValidTo: &left}, []string{}},
+		{"undated candidacy", models.GroupingMembership{MembershipType: models.MembershipTypeCandidate}, []string{}},
+		{"missing type", models.GroupingMembership{}, []string{"membership_type"}},
+		{"unknown type", models.GroupingMembership{MembershipType: "PARTNER"}, []string{"membership_type"}},
+		{"ends before it starts", models.GroupingMembership{MembershipType: models.MembershipTypeObserver, ValidFrom: &left, ValidTo: &joined}, []string{"valid_to"}},
+		{"empty period", models.GroupingMembership{MembershipType: models.MembershipTypeAssociate, ValidFrom: &joined, ValidTo: &joined}, []string{"valid_to"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateMembership(&tt.membership)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateMembership() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"strings"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/i18n"
+)
+
+// NameTranslationValidator checks localized entity names
+type NameTranslationValidator struct {
+	entityTypes map[string]bool
+}
+
+// NewNameTranslationValidator accepts names of the given entity types
+func NewNameTranslationValidator(entityTypes ...string) *NameTranslationValidator {
+	v := &NameTranslationValidator{entityTypes: make(map[string]bool, len(entityTypes))}
+	for _, t := range entityTypes {
+		v.entityTypes[t] = true
+	}
+	return v
+}
+
+// ValidEntityType reports whether names of entity type t can be translated
+func (v *NameTranslationValidator) ValidEntityType(t string) bool {
+	return v.entityTypes[t]
+}
+
+// ValidateNameTranslation validates the entity type, locale and name forms
+// of a translation. The locale must be in canonical form.
+func (v *NameTranslationValidator) ValidateNameTranslation(translation *models.NameTranslation) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if !v.entityTypes[translation.EntityType] {
+		result.AddError("entity_type", "Must be country, language, region or subdivision")
+	}
+	if canonical, err := i18n.CanonicalTag(translation.LocaleCode); err != nil {
+		result.AddError("locale_code", "Must be a BCP 47 language tag")
+	} else if canonical != translation.LocaleCode {
+		result.AddError("locale_code", "Must be written "+canonical)
+	}
+	if strings.TrimSpace(translation.Name) == "" {
+		result.AddError("name", "Name is required")
+	}
+	for field, form := range map[string]*string{
+		"short_name":    translation.ShortName,
+		"official_name": translation.OfficialName,
+		"sort_name":     translation.SortName,
+	} {
+		if form != nil && strings.TrimSpace(*form) == "" {
+			result.AddError(field, "Must not be blank; omit it instead")
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestValidateNameTranslation(t *testing.T) {
+	v := NewNameTranslationValidator("country", "language", "region", "subdivision")
+
+	tests := []struct {
+		name        string
+		translation models.NameTranslation
+		failed      []string
+	}{
+		{"country name", models.NameTranslation{EntityType: "country", LocaleCode: "de", Name: "Deutschland"}, []string{}},
+		{
+			"all name forms",
+			models.NameTranslation{EntityType: "country", LocaleCode: "ja-JP", Name: "日本", ShortName: stringPtr("日本"), OfficialName: stringPtr("日本国"), SortName: stringPtr("にほん")},
+			[]string{},
+		},
+		{"locale with script", models.NameTranslation{EntityType: "region", LocaleCode: "zh-Hant", Name: "歐洲"}, []string{}},
+		{"unknown entity type", models.NameTranslation{EntityType: "city", LocaleCode: "de", Name: "München"}, []string{"entity_type"}},
+		{"locale not canonical", models.NameTranslation{EntityType: "country", LocaleCode: "de_ch", Name: "Schweiz"}, []string{"locale_code"}},
+		{"invalid locale", models.NameTranslation{EntityType: "country", LocaleCode: "german", Name: "Deutschland"}, []string{"locale_code"}},
+		{"blank name", models.NameTranslation{EntityType: "country", LocaleCode: "de", Name: "  "}, []string{"name"}},
+		{
+			"blank name forms",
+			models.NameTranslation{EntityType: "country", LocaleCode: "de", Name: "Deutschland", ShortName: stringPtr(""), SortName: stringPtr(" ")},
+			[]string{"short_name", "sort_name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateNameTranslation(&tt.translation)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateNameTranslation() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// E164MaxDigits is the maximum length of an E.164 number, calling code
+// included
+const E164MaxDigits = 15
+
+// PhonePlanValidator checks phone numbering plans
+type PhonePlanValidator struct {
+	callingCodePattern *regexp.Regexp
+	prefixPattern      *regexp.Regexp
+	leadingPattern     *regexp.Regexp
+}
+
+func NewPhonePlanValidator() *PhonePlanValidator {
+	return &PhonePlanValidator{
+		callingCodePattern: regexp.MustCompile(`^[1-9][0-9]{0,2}$`),
+		prefixPattern:      regexp.MustCompile(`^[0-9]{1,6}$`),
+		leadingPattern:     regexp.MustCompile(`^[0-9]{1,6}(,[0-9]{1,6})*$`),
+	}
+}
+
+// ValidatePhonePlan validates the calling code, prefixes, national number
+// lengths and leading digits of a plan
+func (v *PhonePlanValidator) ValidatePhonePlan(callingCode string, trunkPrefix, internationalPrefix *string, minLength, maxLength int16, leadingDigits *string) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if callingCode == "" {
+		result.AddError("calling_code", "Calling code is required")
+	} else if !v.callingCodePattern.MatchString(callingCode) {
+		result.AddError("calling_code", "Must be 1 to 3 digits without leading zero or +")
+	}
+	if trunkPrefix != nil && (len(*trunkPrefix) > 4 || !v.prefixPattern.MatchString(*trunkPrefix)) {
+		result.AddError("trunk_prefix", "Must be 1 to 4 digits")
+	}
+	if internationalPrefix != nil && !v.prefixPattern.MatchString(*internationalPrefix) {
+		result.AddError("international_prefix", "Must be 1 to 6 digits")
+	}
+	if minLength < 1 {
+		result.AddError("min_length", "Must be at least 1")
+	}
+	if maxLength < minLength {
+		result.AddError("max_length", "Must not be less than min_length")
+	} else if int(maxLength)+len(callingCode) > E164MaxDigits {
+		result.AddError("max_length", "Calling code and national number exceed the 15 digits of E.164")
+	}
+	if leadingDigits != nil && !v.leadingPattern.MatchString(strings.ReplaceAll(*leadingDigits, " ", "")) {
+		result.AddError("leading_digits", "Must be comma-separated digit prefixes")
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestValidatePhonePlan(t *testing.T) {
+	v := NewPhonePlanValidator()
+
+	tests := []struct {
+		name          string
+		callingCode   string
+		trunk         *string
+		international *string
+		min, max      int16
+		leading       *string
+		failed        []string
+	}{
+		{"united kingdom", "44", stringPtr("0"), stringPtr("00"), 9, 10, nil, []string{}},
+		{"country without trunk prefix", "34", nil, stringPtr("00"), 9, 9, nil, []string{}},
+		{"leading digits with spaces", "1", stringPtr("1"), stringPtr("011"), 10, 10, stringPtr("204, 416,514"), []string{}},
+		{"longest national number", "49", stringPtr("0"), stringPtr("00"), 6, 13, nil, []string{}},
+		{"missing calling code", "", nil, nil, 9, 9, nil, []string{"calling_code"}},
+		{"calling code with plus", "+44", nil, nil, 9, 9, nil, []string{"calling_code"}},
+		{"calling code with leading zero", "044", nil, nil, 9, 9, nil, []string{"calling_code"}},
+		{"trunk prefix too long", "44", stringPtr("00000"), nil, 9, 9, nil, []string{"trunk_prefix"}},
+		{"international prefix with plus", "44", nil, stringPtr("+"), 9, 9, nil, []string{"international_prefix"}},
+		{"zero minimum length", "44", nil, nil, 0, 9, nil, []string{"min_length"}},
+		{"maximum below minimum", "44", nil, nil, 10, 9, nil, []string{"max_length"}},
+		{"beyond fifteen digits", "880", nil, nil, 10, 13, nil, []string{"max_length"}},
+		{"leading digits not digits", "1", nil, nil, 10, 10, stringPtr("41x"), []string{"leading_digits"}},
+		{"empty leading digit prefix", "1", nil, nil, 10, 10, stringPtr("204,,416"), []string{"leading_digits"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidatePhonePlan(tt.callingCode, tt.trunk, tt.international, tt.min, tt.max, tt.leading)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidatePhonePlan() failed fields = %v, want %v", got, tt.failed)
+			}
+			if result.IsValid != (len(tt.failed) == 0) {
+				t.Errorf("ValidatePhonePlan() valid = %v with errors %v", result.IsValid, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// PostalCodeValidator checks postal code formats
+type PostalCodeValidator struct{}
+
+func NewPostalCodeValidator() *PostalCodeValidator {
+	return &PostalCodeValidator{}
+}
+
+// CompactPostalCode uppercases a postal code and drops everything but
+// letters and digits, the form postal code patterns are matched against
+func CompactPostalCode(code string) string {
+	var b strings.Builder
+	for _, r := range code {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// ValidatePostalCodeFormat validates a pattern and its format and example.
+// Subdivision rules need a pattern; a country rule without one means the
+// country has no postal codes, which cannot be required.
+func (v *PostalCodeValidator) ValidatePostalCodeFormat(pattern, format, example *string, isRequired, forSubdivision bool) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if pattern == nil {
+		if forSubdivision {
+			result.AddError("pattern", "Pattern is required for a subdivision")
+		}
+		if isRequired {
+			result.AddError("is_required", "A postal code cannot be required without a pattern")
+		}
+		if format != nil || example != nil {
+			result.AddError("pattern", "Format and example need a pattern")
+		}
+		return result
+	}
+
+	if !strings.HasPrefix(*pattern, "^") || !strings.HasSuffix(*pattern, "$") {
+		result.AddError("pattern", "Must be anchored with ^ and $")
+		return result
+	}
+	re, err := regexp.Compile(*pattern)
+	if err != nil {
+		result.AddError("pattern", "Must be a valid regular expression: "+err.Error())
+		return result
+	}
+	if format != nil && re.NumSubexp() == 0 && strings.Contains(*format, "$") {
+		result.AddError("format", "Refers to groups the pattern does not have")
+	}
+	if example != nil && !re.MatchString(CompactPostalCode(*example)) {
+		result.AddError("example", "Does not match the pattern")
+	}
+
+	return result
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompactPostalCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"SW1A 1AA", "SW1A1AA"},
+		{" k1a-0b1 ", "K1A0B1"},
+		{"20500-0003", "205000003"},
+		{"100\u20110001", "1000001"},
+		{" - ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := CompactPostalCode(tt.code); got != tt.want {
+				t.Errorf("CompactPostalCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePostalCodeFormat(t *testing.T) {
+	v := NewPostalCodeValidator()
+
+	tests := []struct {
+		name           string
+		pattern        *string
+		format         *string
+		example        *string
+		required       bool
+		forSubdivision bool
+		failed         []string
+	}{
+		{name: "groups and format", pattern: stringPtr(`^([0-9]{5})([0-9]{4})$`), format: stringPtr("$1-$2"), example: stringPtr("20500-0003"), required: true, failed: []string{}},
+		{name: "example is compacted", pattern: stringPtr(`^([A-Z]{1,2}[0-9][A-Z0-9]?)([0-9][A-Z]{2})$`), example: stringPtr("sw1a 1aa"), failed: []string{}},
+		{name: "country without postal codes", failed: []string{}},
+		{name: "subdivision pattern", pattern: stringPtr(`^75[0-9]{3}$`), forSubdivision: true, failed: []string{}},
+		{name: "subdivision without pattern", forSubdivision: true, failed: []string{"pattern"}},
+		{name: "required without pattern", required: true, failed: []string{"is_required"}},
+		{name: "example without pattern", example: stringPtr("12345"), failed: []string{"pattern"}},
+		{name: "unanchored pattern", pattern: stringPtr(`[0-9]{5}`), failed: []string{"pattern"}},
+		{name: "pattern does not compile", pattern: stringPtr(`^([0-9]$`), failed: []string{"pattern"}},
+		{name: "format refers to missing groups", pattern: stringPtr(`^[0-9]{5}$`), format: stringPtr("$1"), failed: []string{"format"}},
+		{name: "example does not match", pattern: stringPtr(`^[0-9]{5}$`), example: stringPtr("1234"), failed: []string{"example"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidatePostalCodeFormat(tt.pattern, tt.format, tt.example, tt.required, tt.forSubdivision)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidatePostalCodeFormat() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SubdivisionValidator checks ISO 3166-2 subdivision codes
+type SubdivisionValidator struct {
+	iso3166_2Pattern *regexp.Regexp
+}
+
+func NewSubdivisionValidator() *SubdivisionValidator {
+	return &SubdivisionValidator{
+		iso3166_2Pattern: regexp.MustCompile(`^[A-Z]{2}-[A-Z0-9]{1,3}$`),
+	}
+}
+
+// ValidateSubdivisionCode validates ISO 3166-2 format, e.g. GB-ENG, and that
+// the prefix is the alpha-2 code of the subdivision's country
+func (v *SubdivisionValidator) ValidateSubdivisionCode(code, countryCode string) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if code == "" {
+		result.AddError("subdivision_code", "Subdivision code is required")
+	} else if !v.iso3166_2Pattern.MatchString(code) {
+		result.AddError("subdivision_code", "Must be a country code, '-' and 1-3 uppercase letters or digits (ISO 3166-2)")
+	} else if !strings.HasPrefix(code, countryCode+"-") {
+		result.AddError("subdivision_code", "Prefix must match country code "+countryCode)
+	}
+
+	return result
+}
@@ -0,0 +1,38 @@
+package validation
+
+import "testing"
+
+func TestValidateSubdivisionCode(t *testing.T) {
+	v := NewSubdivisionValidator()
+
+	tests := []struct {
+		name        string
+		code        string
+		countryCode string
+		valid       bool
+	}{
+		{"letters", "GB-ENG", "GB", true},
+		{"digits", "FR-75", "FR", true},
+		{"alphanumeric", "FR-75C", "FR", true},
+		{"single character", "ES-M", "ES", true},
+		{"empty", "", "GB", false},
+		{"lowercase", "gb-eng", "GB", false},
+		{"no separator", "GBENG", "GB", false},
+		{"suffix too long", "GB-ENGL", "GB", false},
+		{"empty suffix", "GB-", "GB", false},
+		{"alpha-3 prefix", "GBR-ENG", "GB", false},
+		{"other country", "GB-ENG", "IE", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateSubdivisionCode(tt.code, tt.countryCode)
+			if result.IsValid != tt.valid {
+				t.Errorf("ValidateSubdivisionCode(%q, %q) valid = %v, want %v (%v)", tt.code, tt.countryCode, result.IsValid, tt.valid, result.Errors)
+			}
+			if !tt.valid && result.Errors["subdivision_code"] == "" {
+				t.Errorf("ValidateSubdivisionCode(%q, %q) has no subdivision_code error", tt.code, tt.countryCode)
+			}
+		})
+	}
+}
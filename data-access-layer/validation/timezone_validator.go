@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// The IANA tz database is embedded so that zones resolve the same on
+	// every host, including minimal containers without /usr/share/zoneinfo
+	_ "time/tzdata"
+)
+
+// TimezoneValidator checks time zone names against the IANA tz database
+type TimezoneValidator struct{}
+
+func NewTimezoneValidator() *TimezoneValidator {
+	return &TimezoneValidator{}
+}
+
+// ValidateTimezone validates an IANA zone name such as America/New_York
+func (v *TimezoneValidator) ValidateTimezone(code, name string) *ValidationResult {
+	result := &ValidationResult{IsValid: true, Errors: make(map[string]string)}
+
+	if code == "" {
+		result.AddError("timezone_code", "Timezone code is required")
+	} else if _, err := v.Location(code); err != nil {
+		result.AddError("timezone_code", "Must be an IANA time zone such as America/New_York")
+	}
+	if name == "" {
+		result.AddError("timezone_name", "Timezone name is required")
+	} else if len(name) > 100 {
+		result.AddError("timezone_name", "Must be at most 100 characters")
+	}
+
+	return result
+}
+
+// Location loads an IANA zone. Unlike time.LoadLocation it rejects "Local"
+// and the empty name, which depend on the host.
+func (v *TimezoneValidator) Location(code string) (*time.Location, error) {
+	if code == "" || code == "Local" || len(code) > 64 || strings.HasPrefix(code, "/") {
+		return nil, fmt.Errorf("unknown time zone %q", code)
+	}
+	return time.LoadLocation(code)
+}
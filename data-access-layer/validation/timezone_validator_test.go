@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateTimezone(t *testing.T) {
+	v := NewTimezoneValidator()
+
+	tests := []struct {
+		name     string
+		code     string
+		timezone string
+		failed   []string
+	}{
+		{"zone", "America/New_York", "Eastern Time", []string{}},
+		{"three-level zone", "America/Argentina/Buenos_Aires", "Argentina Time", []string{}},
+		{"utc", "UTC", "Coordinated Universal Time", []string{}},
+		{"missing code and name", "", "", []string{"timezone_code", "timezone_name"}},
+		{"host zone", "Local", "Local time", []string{"timezone_code"}},
+		{"unknown zone", "Mars/Olympus_Mons", "Mars", []string{"timezone_code"}},
+		{"wrong case", "america/new_york", "Eastern Time", []string{"timezone_code"}},
+		{"absolute path", "/etc/localtime", "Local time", []string{"timezone_code"}},
+		{"abbreviation", "CEST", "Central European Summer Time", []string{"timezone_code"}},
+		{"name too long", "Europe/Paris", strings.Repeat("x", 101), []string{"timezone_name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := v.ValidateTimezone(tt.code, tt.timezone)
+			if got := failedFields(result); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("ValidateTimezone() failed fields = %v, want %v", got, tt.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+-- ============================================================================
+-- MIGRATION: 002 fuzzy search
+-- PURPOSE: Accent-insensitive prefix and trigram search over entity names
+-- DEPENDENCIES: countries, country_subdivisions, languages
+-- ============================================================================
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- Normalised search form: lower case, accents and punctuation removed.
+-- Declared IMMUTABLE (unaccent itself is only STABLE) so it can back indexes.
+CREATE OR REPLACE FUNCTION domain_reference_master_geopolitical.f_search_normalize(input TEXT)
+RETURNS TEXT
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT AS $$
+    SELECT regexp_replace(lower(public.unaccent('public.unaccent'::regdictionary, input)), '[[:punct:]]', '', 'g')
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_countries_name_trgm
+    ON domain_reference_master_geopolitical.countries
+    USING gin (domain_reference_master_geopolitical.f_search_normalize(country_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_countries_official_name_trgm
+    ON domain_reference_master_geopolitical.countries
+    USING gin (domain_reference_master_geopolitical.f_search_normalize(official_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_subdivisions_name_trgm
+    ON domain_reference_master_geopolitical.country_subdivisions
+    USING gin (domain_reference_master_geopolitical.f_search_normalize(subdivision_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_languages_name_trgm
+    ON domain_reference_master_geopolitical.languages
+    USING gin (domain_reference_master_geopolitical.f_search_normalize(language_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_languages_native_name_trgm
+    ON domain_reference_master_geopolitical.languages
+    USING gin (domain_reference_master_geopolitical.f_search_normalize(native_name) gin_trgm_ops);
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('002', 'Fuzzy search: pg_trgm, unaccent and trigram name indexes',
+ 'DROP INDEX IF EXISTS domain_reference_master_geopolitical.idx_countries_name_trgm, domain_reference_master_geopolitical.idx_countries_official_name_trgm, domain_reference_master_geopolitical.idx_subdivisions_name_trgm, domain_reference_master_geopolitical.idx_languages_name_trgm, domain_reference_master_geopolitical.idx_languages_native_name_trgm; DROP FUNCTION IF EXISTS domain_reference_master_geopolitical.f_search_normalize(TEXT);')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,65 @@
+-- ============================================================================
+-- MIGRATION: 003 bitemporal validity
+-- PURPOSE: Valid-time periods for countries and subdivisions, alongside the
+--          transaction-time audit columns (created_at/updated_at/deleted_at)
+-- DEPENDENCIES: countries, country_subdivisions
+-- ============================================================================
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Existence period of the entity itself: [valid_from, valid_to), NULL = open
+ALTER TABLE domain_reference_master_geopolitical.countries
+    ADD COLUMN IF NOT EXISTS valid_from DATE,
+    ADD COLUMN IF NOT EXISTS valid_to DATE,
+    DROP CONSTRAINT IF EXISTS chk_countries_validity,
+    ADD CONSTRAINT chk_countries_validity CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from);
+
+ALTER TABLE domain_reference_master_geopolitical.country_subdivisions
+    ADD COLUMN IF NOT EXISTS valid_from DATE,
+    ADD COLUMN IF NOT EXISTS valid_to DATE,
+    DROP CONSTRAINT IF EXISTS chk_subdivisions_validity,
+    ADD CONSTRAINT chk_subdivisions_validity CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from);
+
+CREATE INDEX IF NOT EXISTS idx_countries_validity
+    ON domain_reference_master_geopolitical.countries (tenant_id, valid_from, valid_to);
+CREATE INDEX IF NOT EXISTS idx_subdivisions_validity
+    ON domain_reference_master_geopolitical.country_subdivisions (tenant_id, valid_from, valid_to);
+
+-- Names carried during a period (Swaziland until 2018-04-19, Eswatini since)
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.country_periods (
+    period_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id),
+    country_name VARCHAR(100) NOT NULL,
+    official_name VARCHAR(200),
+    capital_city VARCHAR(100),
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    change_reason TEXT,
+    CHECK (valid_to IS NULL OR valid_to > valid_from),
+    EXCLUDE USING gist (tenant_id WITH =, country_id WITH =, daterange(valid_from, valid_to) WITH &&)
+);
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.subdivision_periods (
+    period_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subdivision_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.country_subdivisions(subdivision_id),
+    subdivision_name VARCHAR(100) NOT NULL,
+    subdivision_type VARCHAR(20),
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    change_reason TEXT,
+    CHECK (valid_to IS NULL OR valid_to > valid_from),
+    EXCLUDE USING gist (tenant_id WITH =, subdivision_id WITH =, daterange(valid_from, valid_to) WITH &&)
+);
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('003', 'Bitemporal validity: valid_from/valid_to and name periods for countries and subdivisions',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.subdivision_periods, domain_reference_master_geopolitical.country_periods; ALTER TABLE domain_reference_master_geopolitical.country_subdivisions DROP COLUMN IF EXISTS valid_from, DROP COLUMN IF EXISTS valid_to; ALTER TABLE domain_reference_master_geopolitical.countries DROP COLUMN IF EXISTS valid_from, DROP COLUMN IF EXISTS valid_to;')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,46 @@
+-- ============================================================================
+-- MIGRATION: 004 entity history
+-- PURPOSE: Immutable field-level change history for all reference entities
+-- DEPENDENCIES: none
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.entity_history (
+    history_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(30) NOT NULL,
+    entity_id UUID NOT NULL,
+    entity_code VARCHAR(64),
+    operation VARCHAR(10) NOT NULL CHECK (operation IN ('CREATE', 'UPDATE', 'DELETE')),
+    version INTEGER NOT NULL,
+    changes JSONB NOT NULL,
+    actor VARCHAR(100),
+    change_reason TEXT,
+    correlation_id VARCHAR(100),
+    changed_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_history_code
+    ON domain_reference_master_geopolitical.entity_history (tenant_id, entity_type, entity_code, changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_entity_history_entity
+    ON domain_reference_master_geopolitical.entity_history (tenant_id, entity_type, entity_id, changed_at DESC);
+
+-- History rows are append-only
+CREATE OR REPLACE FUNCTION domain_reference_master_geopolitical.f_entity_history_immutable()
+RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'entity_history rows are immutable' USING ERRCODE = 'GEO10';
+END
+$$;
+
+DROP TRIGGER IF EXISTS trg_entity_history_immutable ON domain_reference_master_geopolitical.entity_history;
+CREATE TRIGGER trg_entity_history_immutable
+    BEFORE UPDATE OR DELETE ON domain_reference_master_geopolitical.entity_history
+    FOR EACH ROW EXECUTE FUNCTION domain_reference_master_geopolitical.f_entity_history_immutable();
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('004', 'Entity history: immutable field-level audit trail',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.entity_history; DROP FUNCTION IF EXISTS domain_reference_master_geopolitical.f_entity_history_immutable();')
+ON CONFLICT (version) DO NOTHING;
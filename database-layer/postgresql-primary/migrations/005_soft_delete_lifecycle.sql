@@ -0,0 +1,55 @@
+-- ============================================================================
+-- MIGRATION: 005 soft-delete lifecycle
+-- PURPOSE: Restore and purge of soft-deleted entities, recorded in entity
+--          history and announced through a transactional outbox
+-- DEPENDENCIES: 003 bitemporal validity, 004 entity history
+-- ============================================================================
+
+-- History records restores and purges alongside creates, updates and deletes
+ALTER TABLE domain_reference_master_geopolitical.entity_history
+    DROP CONSTRAINT IF EXISTS entity_history_operation_check,
+    ADD CONSTRAINT entity_history_operation_check
+        CHECK (operation IN ('CREATE', 'UPDATE', 'DELETE', 'RESTORE', 'PURGE'));
+
+-- Naming periods belong to their entity and are purged with it
+ALTER TABLE domain_reference_master_geopolitical.country_periods
+    DROP CONSTRAINT IF EXISTS country_periods_country_id_fkey,
+    ADD CONSTRAINT country_periods_country_id_fkey
+        FOREIGN KEY (country_id) REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE;
+
+ALTER TABLE domain_reference_master_geopolitical.subdivision_periods
+    DROP CONSTRAINT IF EXISTS subdivision_periods_subdivision_id_fkey,
+    ADD CONSTRAINT subdivision_periods_subdivision_id_fkey
+        FOREIGN KEY (subdivision_id) REFERENCES domain_reference_master_geopolitical.country_subdivisions(subdivision_id) ON DELETE CASCADE;
+
+-- Domain events written in the same transaction as the change that raised them
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.outbox_events (
+    event_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id VARCHAR(100) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(30) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    correlation_id VARCHAR(100),
+    occurred_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending
+    ON domain_reference_master_geopolitical.outbox_events (occurred_at)
+    WHERE published_at IS NULL;
+
+-- Listing deleted rows (?include_deleted=only)
+CREATE INDEX IF NOT EXISTS idx_countries_deleted
+    ON domain_reference_master_geopolitical.countries (tenant_id, deleted_at)
+    WHERE is_deleted;
+CREATE INDEX IF NOT EXISTS idx_subdivisions_deleted
+    ON domain_reference_master_geopolitical.country_subdivisions (tenant_id, deleted_at)
+    WHERE is_deleted;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('005', 'Soft-delete lifecycle: restore/purge history operations and event outbox',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.outbox_events; DROP INDEX IF EXISTS domain_reference_master_geopolitical.idx_countries_deleted; DROP INDEX IF EXISTS domain_reference_master_geopolitical.idx_subdivisions_deleted;')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,55 @@
+-- ============================================================================
+-- MIGRATION: 006 retention policies
+-- PURPOSE: Per-row retention policy and data classification on every
+--          reference entity, and the report written by each retention run
+-- DEPENDENCIES: 005 soft-delete lifecycle
+-- ============================================================================
+
+-- retention_policy names a policy such as '7y-audit' or 'purge-deleted-90d';
+-- rows without one follow the default policy of their data classification
+ALTER TABLE domain_reference_master_geopolitical.regions
+    ADD COLUMN IF NOT EXISTS data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    ADD COLUMN IF NOT EXISTS retention_policy VARCHAR(50);
+ALTER TABLE domain_reference_master_geopolitical.languages
+    ADD COLUMN IF NOT EXISTS data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    ADD COLUMN IF NOT EXISTS retention_policy VARCHAR(50);
+ALTER TABLE domain_reference_master_geopolitical.timezones
+    ADD COLUMN IF NOT EXISTS data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    ADD COLUMN IF NOT EXISTS retention_policy VARCHAR(50);
+ALTER TABLE domain_reference_master_geopolitical.countries
+    ADD COLUMN IF NOT EXISTS data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    ADD COLUMN IF NOT EXISTS retention_policy VARCHAR(50);
+ALTER TABLE domain_reference_master_geopolitical.country_subdivisions
+    ADD COLUMN IF NOT EXISTS data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    ADD COLUMN IF NOT EXISTS retention_policy VARCHAR(50);
+ALTER TABLE domain_reference_master_geopolitical.locales
+    ADD COLUMN IF NOT EXISTS data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    ADD COLUMN IF NOT EXISTS retention_policy VARCHAR(50);
+
+-- One row per entity, tenant, policy and action of a retention run
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.retention_reports (
+    report_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id UUID NOT NULL,
+    tenant_id VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(30) NOT NULL,
+    policy VARCHAR(50) NOT NULL,
+    classification VARCHAR(20),
+    action VARCHAR(20) NOT NULL CHECK (action IN ('anonymize_audit', 'purge_deleted')),
+    cutoff TIMESTAMPTZ NOT NULL,
+    rows_affected BIGINT NOT NULL,
+    error TEXT,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_reports_run
+    ON domain_reference_master_geopolitical.retention_reports (run_id);
+CREATE INDEX IF NOT EXISTS idx_retention_reports_tenant
+    ON domain_reference_master_geopolitical.retention_reports (tenant_id, started_at DESC);
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('006', 'Retention policies: classification/policy columns and retention reports',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.retention_reports;')
+ON CONFLICT (version) DO NOTHING;
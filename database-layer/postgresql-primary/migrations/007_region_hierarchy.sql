@@ -0,0 +1,54 @@
+-- ============================================================================
+-- MIGRATION: 007 region hierarchy
+-- PURPOSE: Guard the UN M49 region tree (world -> continent -> sub-region ->
+--          intermediate region) against cycles and index its traversal
+-- DEPENDENCIES: none
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_regions_tenant_parent
+    ON domain_reference_master_geopolitical.regions (tenant_id, parent_region_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_countries_tenant_region
+    ON domain_reference_master_geopolitical.countries (tenant_id, region_id)
+    WHERE is_deleted = false;
+
+-- A region may not become its own ancestor. The API rejects cycles before
+-- writing; this trigger also catches concurrent re-parenting.
+CREATE OR REPLACE FUNCTION domain_reference_master_geopolitical.f_regions_no_cycle()
+RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF NEW.parent_region_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+    IF NEW.parent_region_id = NEW.region_id OR EXISTS (
+        WITH RECURSIVE ancestors AS (
+            SELECT region_id, parent_region_id, ARRAY[region_id] AS path
+            FROM domain_reference_master_geopolitical.regions
+            WHERE region_id = NEW.parent_region_id
+            UNION ALL
+            SELECT p.region_id, p.parent_region_id, a.path || p.region_id
+            FROM domain_reference_master_geopolitical.regions p
+            JOIN ancestors a ON p.region_id = a.parent_region_id
+            WHERE NOT p.region_id = ANY(a.path)
+        )
+        SELECT 1 FROM ancestors WHERE region_id = NEW.region_id
+    ) THEN
+        RAISE EXCEPTION 'region % would become its own ancestor', NEW.region_code
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END
+$$;
+
+DROP TRIGGER IF EXISTS trg_regions_no_cycle ON domain_reference_master_geopolitical.regions;
+CREATE TRIGGER trg_regions_no_cycle
+    BEFORE INSERT OR UPDATE OF parent_region_id ON domain_reference_master_geopolitical.regions
+    FOR EACH ROW EXECUTE FUNCTION domain_reference_master_geopolitical.f_regions_no_cycle();
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('007', 'Region hierarchy: cycle guard and traversal indexes',
+ 'DROP TRIGGER IF EXISTS trg_regions_no_cycle ON domain_reference_master_geopolitical.regions; DROP FUNCTION IF EXISTS domain_reference_master_geopolitical.f_regions_no_cycle(); DROP INDEX IF EXISTS domain_reference_master_geopolitical.idx_regions_tenant_parent; DROP INDEX IF EXISTS domain_reference_master_geopolitical.idx_countries_tenant_region;')
+ON CONFLICT (version) DO NOTHING;
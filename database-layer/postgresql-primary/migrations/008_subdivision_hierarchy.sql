@@ -0,0 +1,87 @@
+-- ============================================================================
+-- MIGRATION: 008 subdivision hierarchy
+-- PURPOSE: Multi-level subdivisions (region -> province -> district) and
+--          ISO 3166-2 subdivision codes
+-- DEPENDENCIES: none
+-- ============================================================================
+
+ALTER TABLE domain_reference_master_geopolitical.country_subdivisions
+    ADD COLUMN IF NOT EXISTS parent_subdivision_id UUID
+        REFERENCES domain_reference_master_geopolitical.country_subdivisions(subdivision_id);
+
+CREATE INDEX IF NOT EXISTS idx_country_subdivisions_parent
+    ON domain_reference_master_geopolitical.country_subdivisions (tenant_id, parent_subdivision_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_country_subdivisions_country
+    ON domain_reference_master_geopolitical.country_subdivisions (tenant_id, country_id)
+    WHERE is_deleted = false;
+
+-- Codes are ISO 3166-2: country alpha-2, '-', 1-3 letters or digits. Added
+-- NOT VALID so existing free-form codes can be corrected before running
+-- ALTER TABLE ... VALIDATE CONSTRAINT chk_country_subdivisions_iso3166_2.
+ALTER TABLE domain_reference_master_geopolitical.country_subdivisions
+    DROP CONSTRAINT IF EXISTS chk_country_subdivisions_iso3166_2;
+ALTER TABLE domain_reference_master_geopolitical.country_subdivisions
+    ADD CONSTRAINT chk_country_subdivisions_iso3166_2
+    CHECK (subdivision_code ~ '^[A-Z]{2}-[A-Z0-9]{1,3}$') NOT VALID;
+
+-- The code prefix must be the country code, a parent must belong to the same
+-- country and a subdivision may not become its own ancestor. The API checks
+-- all three before writing; this trigger also catches concurrent writes.
+CREATE OR REPLACE FUNCTION domain_reference_master_geopolitical.f_country_subdivisions_hierarchy()
+RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF (TG_OP = 'INSERT' OR NEW.subdivision_code IS DISTINCT FROM OLD.subdivision_code
+            OR NEW.country_id IS DISTINCT FROM OLD.country_id) AND NOT EXISTS (
+        SELECT 1 FROM domain_reference_master_geopolitical.countries
+        WHERE country_id = NEW.country_id AND NEW.subdivision_code LIKE country_code || '-%'
+    ) THEN
+        RAISE EXCEPTION 'subdivision code % does not start with its country code', NEW.subdivision_code
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    IF NEW.parent_subdivision_id IS NULL OR (TG_OP = 'UPDATE'
+            AND NEW.parent_subdivision_id IS NOT DISTINCT FROM OLD.parent_subdivision_id
+            AND NEW.country_id IS NOT DISTINCT FROM OLD.country_id) THEN
+        RETURN NEW;
+    END IF;
+    IF NOT EXISTS (
+        SELECT 1 FROM domain_reference_master_geopolitical.country_subdivisions
+        WHERE subdivision_id = NEW.parent_subdivision_id AND country_id = NEW.country_id
+    ) THEN
+        RAISE EXCEPTION 'parent of subdivision % belongs to another country', NEW.subdivision_code
+            USING ERRCODE = 'check_violation';
+    END IF;
+    IF NEW.parent_subdivision_id = NEW.subdivision_id OR EXISTS (
+        WITH RECURSIVE ancestors AS (
+            SELECT subdivision_id, parent_subdivision_id, ARRAY[subdivision_id] AS path
+            FROM domain_reference_master_geopolitical.country_subdivisions
+            WHERE subdivision_id = NEW.parent_subdivision_id
+            UNION ALL
+            SELECT p.subdivision_id, p.parent_subdivision_id, a.path || p.subdivision_id
+            FROM domain_reference_master_geopolitical.country_subdivisions p
+            JOIN ancestors a ON p.subdivision_id = a.parent_subdivision_id
+            WHERE NOT p.subdivision_id = ANY(a.path)
+        )
+        SELECT 1 FROM ancestors WHERE subdivision_id = NEW.subdivision_id
+    ) THEN
+        RAISE EXCEPTION 'subdivision % would become its own ancestor', NEW.subdivision_code
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END
+$$;
+
+DROP TRIGGER IF EXISTS trg_country_subdivisions_hierarchy ON domain_reference_master_geopolitical.country_subdivisions;
+CREATE TRIGGER trg_country_subdivisions_hierarchy
+    BEFORE INSERT OR UPDATE OF subdivision_code, country_id, parent_subdivision_id
+    ON domain_reference_master_geopolitical.country_subdivisions
+    FOR EACH ROW EXECUTE FUNCTION domain_reference_master_geopolitical.f_country_subdivisions_hierarchy();
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('008', 'Subdivision hierarchy: parent links, ISO 3166-2 codes and hierarchy guard',
+ 'DROP TRIGGER IF EXISTS trg_country_subdivisions_hierarchy ON domain_reference_master_geopolitical.country_subdivisions; DROP FUNCTION IF EXISTS domain_reference_master_geopolitical.f_country_subdivisions_hierarchy(); ALTER TABLE domain_reference_master_geopolitical.country_subdivisions DROP CONSTRAINT IF EXISTS chk_country_subdivisions_iso3166_2;')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,18 @@
+-- ============================================================================
+-- MIGRATION: 009 ISO 3166 import
+-- PURPOSE: Room for ISO 3166-2 subdivision category names
+--          ("Metropolitan department", "Autonomous community", ...)
+-- DEPENDENCIES: 003
+-- ============================================================================
+
+ALTER TABLE domain_reference_master_geopolitical.country_subdivisions
+    ALTER COLUMN subdivision_type TYPE VARCHAR(50);
+ALTER TABLE domain_reference_master_geopolitical.subdivision_periods
+    ALTER COLUMN subdivision_type TYPE VARCHAR(50);
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('009', 'ISO 3166 import: widen subdivision_type to 50 characters',
+ 'ALTER TABLE domain_reference_master_geopolitical.country_subdivisions ALTER COLUMN subdivision_type TYPE VARCHAR(20); ALTER TABLE domain_reference_master_geopolitical.subdivision_periods ALTER COLUMN subdivision_type TYPE VARCHAR(20);')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,111 @@
+-- ============================================================================
+-- MIGRATION: 010 currencies
+-- PURPOSE: ISO 4217 currencies and the currencies each country uses, with
+--          valid-time periods and a legal-tender flag
+-- DEPENDENCIES: 003 bitemporal validity, 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.currencies (
+    currency_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    currency_code CHAR(3) COLLATE "C" NOT NULL CHECK (currency_code ~ '^[A-Z]{3}$'),
+    currency_name VARCHAR(100) NOT NULL,
+    numeric_code SMALLINT CHECK (numeric_code BETWEEN 1 AND 999),
+    -- NULL where ISO 4217 defines no minor unit (funds, precious metals)
+    minor_units SMALLINT CHECK (minor_units BETWEEN 0 AND 4),
+    symbol VARCHAR(10),
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50),
+    UNIQUE (tenant_id, currency_code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_currencies_tenant_id
+    ON domain_reference_master_geopolitical.currencies (tenant_id);
+
+-- A country may use several currencies at once (e.g. PA: PAB and USD) and
+-- change currency over time (e.g. HR: HRK until 2023-01-01, EUR since)
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.country_currencies (
+    country_currency_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    currency_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.currencies(currency_id) ON DELETE CASCADE,
+    is_legal_tender BOOLEAN DEFAULT true NOT NULL,
+    valid_from DATE,
+    valid_to DATE,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50),
+    CONSTRAINT chk_country_currencies_validity CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from),
+    -- Live periods of the same pair never overlap; the API reports overlaps
+    -- before writing
+    CONSTRAINT excl_country_currencies_period EXCLUDE USING gist (
+        tenant_id WITH =, country_id WITH =, currency_id WITH =, daterange(valid_from, valid_to) WITH &&
+    ) WHERE (is_deleted = false)
+);
+
+CREATE INDEX IF NOT EXISTS idx_country_currencies_country
+    ON domain_reference_master_geopolitical.country_currencies (tenant_id, country_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_country_currencies_currency
+    ON domain_reference_master_geopolitical.country_currencies (tenant_id, currency_id)
+    WHERE is_deleted = false;
+
+-- countries.currency_id, the primary currency, now has a table to point to.
+-- NOT VALID keeps ids issued by the commerce domain until they are remapped.
+ALTER TABLE domain_reference_master_geopolitical.countries
+    DROP CONSTRAINT IF EXISTS fk_countries_currency;
+ALTER TABLE domain_reference_master_geopolitical.countries
+    ADD CONSTRAINT fk_countries_currency
+    FOREIGN KEY (currency_id) REFERENCES domain_reference_master_geopolitical.currencies(currency_id) NOT VALID;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('010', 'Currencies: ISO 4217 currencies and country currency periods',
+ 'ALTER TABLE domain_reference_master_geopolitical.countries DROP CONSTRAINT IF EXISTS fk_countries_currency; DROP TABLE IF EXISTS domain_reference_master_geopolitical.country_currencies, domain_reference_master_geopolitical.currencies;')
+ON CONFLICT (version) DO NOTHING;
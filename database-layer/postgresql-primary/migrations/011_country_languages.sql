@@ -0,0 +1,71 @@
+-- ============================================================================
+-- MIGRATION: 011 country languages
+-- PURPOSE: Languages spoken in each country with official status, speaker
+--          share and one primary language per country
+-- DEPENDENCIES: 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.country_languages (
+    country_language_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    language_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.languages(language_id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('OFFICIAL', 'REGIONAL', 'RECOGNIZED')),
+    speaker_percentage NUMERIC(5,2) CHECK (speaker_percentage BETWEEN 0 AND 100),
+    is_primary BOOLEAN DEFAULT false NOT NULL,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_country_languages_pair
+    ON domain_reference_master_geopolitical.country_languages (tenant_id, country_id, language_id)
+    WHERE is_deleted = false;
+-- At most one primary language per country
+CREATE UNIQUE INDEX IF NOT EXISTS uq_country_languages_primary
+    ON domain_reference_master_geopolitical.country_languages (tenant_id, country_id)
+    WHERE is_primary AND is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_country_languages_language
+    ON domain_reference_master_geopolitical.country_languages (tenant_id, language_id)
+    WHERE is_deleted = false;
+
+-- Carry the single language pointer over as the primary official language
+INSERT INTO domain_reference_master_geopolitical.country_languages
+    (country_id, language_id, status, is_primary, tenant_id, change_reason)
+SELECT c.country_id, c.primary_language_id, 'OFFICIAL', true, c.tenant_id, 'Migration 011: countries.primary_language_id'
+FROM domain_reference_master_geopolitical.countries c
+WHERE c.primary_language_id IS NOT NULL
+  AND c.is_deleted = false
+  AND NOT EXISTS (
+      SELECT 1 FROM domain_reference_master_geopolitical.country_languages cl
+      WHERE cl.country_id = c.country_id AND cl.is_deleted = false
+  );
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('011', 'Country languages: status, speaker share and primary language',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.country_languages;')
+ON CONFLICT (version) DO NOTHING;
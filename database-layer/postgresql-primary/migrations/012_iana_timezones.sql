@@ -0,0 +1,116 @@
+-- ============================================================================
+-- MIGRATION: 012 IANA time zones
+-- PURPOSE: Name time zones by IANA zone (America/New_York) instead of
+--          ambiguous abbreviations, and link zones to countries and
+--          subdivisions
+-- DEPENDENCIES: 006 retention policies
+-- ============================================================================
+
+ALTER TABLE domain_reference_master_geopolitical.timezones
+    ALTER COLUMN timezone_code TYPE VARCHAR(64);
+
+-- Abbreviations of the sample data become the zone they stood for, with the
+-- zone's standard offset and daylight saving shift. Each rename is recorded
+-- in the entity history like any other update.
+WITH renamed AS (
+    UPDATE domain_reference_master_geopolitical.timezones t
+    SET timezone_code = m.zone,
+        utc_offset_hours = m.offset_hours,
+        utc_offset_minutes = m.offset_minutes,
+        supports_dst = m.supports_dst,
+        dst_offset_hours = m.dst_shift,
+        change_reason = 'Migration 012: ' || m.code || ' renamed to IANA zone ' || m.zone,
+        version = t.version + 1,
+        updated_at = now()
+    FROM (VALUES
+        ('EST', 'America/New_York', -5, 0, true, 1),
+        ('PST', 'America/Los_Angeles', -8, 0, true, 1),
+        ('GMT', 'Europe/London', 0, 0, true, 1),
+        ('CET', 'Europe/Berlin', 1, 0, true, 1),
+        ('JST', 'Asia/Tokyo', 9, 0, false, NULL),
+        ('CST', 'Asia/Shanghai', 8, 0, false, NULL),
+        ('IST', 'Asia/Kolkata', 5, 30, false, NULL),
+        ('AEST', 'Australia/Sydney', 10, 0, true, 1),
+        ('BRT', 'America/Sao_Paulo', -3, 0, false, NULL)
+    ) AS m (code, zone, offset_hours, offset_minutes, supports_dst, dst_shift),
+    domain_reference_master_geopolitical.timezones old
+    WHERE old.timezone_id = t.timezone_id
+      AND t.timezone_code = m.code
+      AND NOT EXISTS (
+          SELECT 1 FROM domain_reference_master_geopolitical.timezones z
+          WHERE z.timezone_code = m.zone AND z.tenant_id = t.tenant_id
+      )
+    RETURNING t.timezone_id, t.tenant_id, t.timezone_code, t.version, t.change_reason,
+              old.timezone_code AS old_code,
+              old.utc_offset_hours AS old_offset_hours, t.utc_offset_hours,
+              old.utc_offset_minutes AS old_offset_minutes, t.utc_offset_minutes,
+              old.supports_dst AS old_supports_dst, t.supports_dst,
+              old.dst_offset_hours AS old_dst_offset_hours, t.dst_offset_hours
+)
+INSERT INTO domain_reference_master_geopolitical.entity_history
+(tenant_id, entity_type, entity_id, entity_code, operation, version, changes, actor, change_reason)
+SELECT COALESCE(r.tenant_id, 'default-tenant'), 'timezone', r.timezone_id, r.timezone_code, 'UPDATE', r.version,
+       jsonb_build_object('timezone_code', jsonb_build_object('before', r.old_code, 'after', r.timezone_code))
+       || CASE WHEN r.old_offset_hours IS DISTINCT FROM r.utc_offset_hours
+               THEN jsonb_build_object('utc_offset_hours', jsonb_build_object('before', r.old_offset_hours, 'after', r.utc_offset_hours))
+               ELSE '{}'::jsonb END
+       || CASE WHEN r.old_offset_minutes IS DISTINCT FROM r.utc_offset_minutes
+               THEN jsonb_build_object('utc_offset_minutes', jsonb_build_object('before', r.old_offset_minutes, 'after', r.utc_offset_minutes))
+               ELSE '{}'::jsonb END
+       || CASE WHEN r.old_supports_dst IS DISTINCT FROM r.supports_dst
+               THEN jsonb_build_object('supports_dst', jsonb_build_object('before', r.old_supports_dst, 'after', r.supports_dst))
+               ELSE '{}'::jsonb END
+       || CASE WHEN r.old_dst_offset_hours IS DISTINCT FROM r.dst_offset_hours
+               THEN jsonb_build_object('dst_offset_hours', jsonb_build_object('before', r.old_dst_offset_hours, 'after', r.dst_offset_hours))
+               ELSE '{}'::jsonb END,
+       'migration-012', r.change_reason
+FROM renamed r;
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.country_timezones (
+    country_timezone_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    -- NULL links the zone to the whole country
+    subdivision_id UUID REFERENCES domain_reference_master_geopolitical.country_subdivisions(subdivision_id) ON DELETE CASCADE,
+    timezone_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.timezones(timezone_id) ON DELETE CASCADE,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_country_timezones_link
+    ON domain_reference_master_geopolitical.country_timezones
+       (tenant_id, country_id, COALESCE(subdivision_id, '00000000-0000-0000-0000-000000000000'::uuid), timezone_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_country_timezones_timezone
+    ON domain_reference_master_geopolitical.country_timezones (tenant_id, timezone_id)
+    WHERE is_deleted = false;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('012', 'IANA time zones and country/subdivision time zone links',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.country_timezones; UPDATE domain_reference_master_geopolitical.timezones t SET timezone_code = m.code FROM (VALUES (''EST'', ''America/New_York''), (''PST'', ''America/Los_Angeles''), (''GMT'', ''Europe/London''), (''CET'', ''Europe/Berlin''), (''JST'', ''Asia/Tokyo''), (''CST'', ''Asia/Shanghai''), (''IST'', ''Asia/Kolkata''), (''AEST'', ''Australia/Sydney''), (''BRT'', ''America/Sao_Paulo'')) AS m (code, zone) WHERE t.timezone_code = m.zone AND t.change_reason LIKE ''Migration 012:%''; ALTER TABLE domain_reference_master_geopolitical.timezones ALTER COLUMN timezone_code TYPE VARCHAR(50);')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,65 @@
+-- ============================================================================
+-- MIGRATION: 013 phone numbering plans
+-- PURPOSE: Per-country E.164 calling codes, trunk and international prefixes
+--          and national number lengths, replacing the free-text
+--          countries.phone_prefix
+-- DEPENDENCIES: 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.phone_numbering_plans (
+    phone_numbering_plan_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    calling_code VARCHAR(3) NOT NULL CHECK (calling_code ~ '^[1-9][0-9]{0,2}$'),
+    trunk_prefix VARCHAR(4) CHECK (trunk_prefix ~ '^[0-9]+$'),
+    international_prefix VARCHAR(6) CHECK (international_prefix ~ '^[0-9]+$'),
+    min_length SMALLINT NOT NULL CHECK (min_length >= 1),
+    max_length SMALLINT NOT NULL,
+    -- Comma-separated national number prefixes telling apart the countries
+    -- of a shared calling code; NULL for the main country of the code
+    leading_digits TEXT CHECK (leading_digits ~ '^[0-9]+(,[0-9]+)*$'),
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50),
+    CONSTRAINT chk_phone_numbering_plans_length
+        CHECK (max_length >= min_length AND max_length + length(calling_code) <= 15)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_phone_numbering_plans_country
+    ON domain_reference_master_geopolitical.phone_numbering_plans (tenant_id, country_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_phone_numbering_plans_calling_code
+    ON domain_reference_master_geopolitical.phone_numbering_plans (tenant_id, calling_code)
+    WHERE is_deleted = false;
+
+COMMENT ON COLUMN domain_reference_master_geopolitical.countries.phone_prefix IS
+    'Deprecated: display only, superseded by phone_numbering_plans.calling_code';
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('013', 'Phone numbering plans: calling codes, prefixes and number lengths',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.phone_numbering_plans;')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,65 @@
+-- ============================================================================
+-- MIGRATION: 014 postal code formats
+-- PURPOSE: Postal code patterns, display formats, examples and the "postal
+--          code required" flag per country, with optional narrower patterns
+--          per subdivision
+-- DEPENDENCIES: 006 retention policies, 008 subdivision hierarchy
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.postal_code_formats (
+    postal_code_format_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    subdivision_id UUID REFERENCES domain_reference_master_geopolitical.country_subdivisions(subdivision_id) ON DELETE CASCADE,
+    -- Anchored RE2 pattern over the compact code (uppercase letters and
+    -- digits only); NULL for a country without postal codes
+    pattern VARCHAR(200) CHECK (pattern ~ '^\^.*\$$'),
+    -- Replacement rebuilding the display form from the pattern groups
+    format VARCHAR(50),
+    example VARCHAR(20),
+    is_required BOOLEAN DEFAULT false NOT NULL,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50),
+    CONSTRAINT chk_postal_code_formats_subdivision
+        CHECK (subdivision_id IS NULL OR (pattern IS NOT NULL AND NOT is_required)),
+    CONSTRAINT chk_postal_code_formats_required
+        CHECK (pattern IS NOT NULL OR (NOT is_required AND format IS NULL AND example IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_postal_code_formats_rule
+    ON domain_reference_master_geopolitical.postal_code_formats
+       (tenant_id, country_id, COALESCE(subdivision_id, '00000000-0000-0000-0000-000000000000'::uuid), COALESCE(pattern, ''))
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_postal_code_formats_subdivision
+    ON domain_reference_master_geopolitical.postal_code_formats (subdivision_id)
+    WHERE subdivision_id IS NOT NULL AND is_deleted = false;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('014', 'Postal code formats per country and subdivision',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.postal_code_formats;')
+ON CONFLICT (version) DO NOTHING;
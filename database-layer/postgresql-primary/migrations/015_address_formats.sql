@@ -0,0 +1,59 @@
+-- ============================================================================
+-- MIGRATION: 015 address formats
+-- PURPOSE: Per-country address layout templates, required and uppercase
+--          fields and field labels, in the style of libaddressinput
+-- DEPENDENCIES: 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.address_formats (
+    address_format_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    -- Field tokens: %N recipient, %O organization, %A address lines,
+    -- %D dependent locality, %C locality, %S subdivision, %Z postal code,
+    -- %X sorting code, %n line break
+    template VARCHAR(200) NOT NULL CHECK (template LIKE '%\%A%'),
+    latin_template VARCHAR(200) CHECK (latin_template LIKE '%\%A%'),
+    required_fields VARCHAR(10) CHECK (required_fields ~ '^[NOADCSZX]*$'),
+    uppercase_fields VARCHAR(10) CHECK (uppercase_fields ~ '^[NOADCSZX]*$'),
+    subdivision_label VARCHAR(20),
+    locality_label VARCHAR(20),
+    postal_code_label VARCHAR(20),
+    subdivision_style VARCHAR(10) DEFAULT 'name' NOT NULL CHECK (subdivision_style IN ('name', 'code')),
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_address_formats_country
+    ON domain_reference_master_geopolitical.address_formats (tenant_id, country_id)
+    WHERE is_deleted = false;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('015', 'Address formats: layout templates, required fields and labels',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.address_formats;')
+ON CONFLICT (version) DO NOTHING;
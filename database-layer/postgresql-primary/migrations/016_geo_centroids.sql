@@ -0,0 +1,27 @@
+-- ============================================================================
+-- MIGRATION: 016 geo centroids
+-- PURPOSE: Centroids of the simplified boundaries of countries and
+--          subdivisions. Boundary polygons and bounding boxes are bundled
+--          with the service for offline reverse geocoding.
+-- DEPENDENCIES: countries, country_subdivisions
+-- ============================================================================
+
+ALTER TABLE domain_reference_master_geopolitical.countries
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180);
+
+ALTER TABLE domain_reference_master_geopolitical.country_subdivisions
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180);
+
+COMMENT ON COLUMN domain_reference_master_geopolitical.countries.latitude IS
+    'Centroid of the simplified boundary, WGS84; used as the GeoJSON export geometry';
+COMMENT ON COLUMN domain_reference_master_geopolitical.country_subdivisions.latitude IS
+    'Centroid of the simplified boundary, WGS84; used as the GeoJSON export geometry';
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('016', 'Centroids of countries and subdivisions',
+ 'ALTER TABLE domain_reference_master_geopolitical.countries DROP COLUMN IF EXISTS latitude, DROP COLUMN IF EXISTS longitude; ALTER TABLE domain_reference_master_geopolitical.country_subdivisions DROP COLUMN IF EXISTS latitude, DROP COLUMN IF EXISTS longitude;')
+ON CONFLICT (version) DO NOTHING;
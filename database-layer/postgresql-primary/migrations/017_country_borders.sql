@@ -0,0 +1,60 @@
+-- ============================================================================
+-- MIGRATION: 017 country borders
+-- PURPOSE: Land and maritime borders between countries, the edges of the
+--          border graph behind neighbor and route queries
+-- DEPENDENCIES: 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.country_borders (
+    country_border_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    neighbor_country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    border_type VARCHAR(10) NOT NULL CHECK (border_type IN ('LAND', 'MARITIME')),
+    length_km NUMERIC(10,2) CHECK (length_km > 0),
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50),
+    CHECK (country_id <> neighbor_country_id)
+);
+
+-- Borders are undirected: one live border per pair and type, in either order
+CREATE UNIQUE INDEX IF NOT EXISTS uq_country_borders_pair
+    ON domain_reference_master_geopolitical.country_borders
+    (tenant_id, LEAST(country_id, neighbor_country_id), GREATEST(country_id, neighbor_country_id), border_type)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_country_borders_neighbor
+    ON domain_reference_master_geopolitical.country_borders (tenant_id, neighbor_country_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_country_borders_country
+    ON domain_reference_master_geopolitical.country_borders (tenant_id, country_id)
+    WHERE is_deleted = false;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('017', 'Country borders: land and maritime border graph',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.country_borders;')
+ON CONFLICT (version) DO NOTHING;
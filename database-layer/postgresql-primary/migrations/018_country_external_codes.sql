@@ -0,0 +1,66 @@
+-- ============================================================================
+-- MIGRATION: 018 country external codes
+-- PURPOSE: Codes of countries in external code systems (FIPS 10-4, IOC, ITU,
+--          GENC, World Bank, ...) with validity periods, for the code
+--          crosswalk
+-- DEPENDENCIES: 003 bitemporal validity (btree_gist), 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.country_external_codes (
+    country_external_code_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    -- ISO 3166-1 codes stay on countries
+    code_system VARCHAR(20) NOT NULL CHECK (code_system ~ '^[a-z][a-z0-9_]{1,19}$' AND code_system NOT IN ('iso2', 'iso3', 'numeric')),
+    code VARCHAR(10) NOT NULL CHECK (code ~ '^[A-Z0-9-]{1,10}$'),
+    valid_from DATE,
+    valid_to DATE,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50),
+    CONSTRAINT chk_country_external_codes_validity CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from),
+    -- A country has one code per system at a time, and a code names one
+    -- country at a time; the API reports overlaps before writing
+    CONSTRAINT excl_country_external_codes_country EXCLUDE USING gist (
+        tenant_id WITH =, country_id WITH =, code_system WITH =, daterange(valid_from, valid_to) WITH &&
+    ) WHERE (is_deleted = false),
+    CONSTRAINT excl_country_external_codes_code EXCLUDE USING gist (
+        tenant_id WITH =, code_system WITH =, code WITH =, daterange(valid_from, valid_to) WITH &&
+    ) WHERE (is_deleted = false)
+);
+
+CREATE INDEX IF NOT EXISTS idx_country_external_codes_country
+    ON domain_reference_master_geopolitical.country_external_codes (tenant_id, country_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_country_external_codes_code
+    ON domain_reference_master_geopolitical.country_external_codes (tenant_id, code_system, code)
+    WHERE is_deleted = false;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('018', 'Country external codes: code crosswalk with validity periods',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.country_external_codes;')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,64 @@
+-- ============================================================================
+-- MIGRATION: 019 name translations
+-- PURPOSE: Names of countries, regions, languages and subdivisions in other
+--          locales, with short, official and sort forms, served by the read
+--          endpoints per Accept-Language
+-- DEPENDENCIES: 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.name_translations (
+    name_translation_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    -- The entity is referenced by type and ID, so it has no foreign key; the
+    -- API checks that it exists
+    entity_type VARCHAR(30) NOT NULL CHECK (entity_type IN ('country', 'region', 'language', 'subdivision')),
+    entity_id UUID NOT NULL,
+    -- Canonical BCP 47 tag, e.g. ja or pt-BR
+    locale_code VARCHAR(20) NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    short_name VARCHAR(100),
+    official_name VARCHAR(300),
+    -- Orders names where the written form does not, e.g. the kana reading
+    -- of a Japanese name
+    sort_name VARCHAR(200),
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50)
+);
+
+-- An entity has one name per locale
+CREATE UNIQUE INDEX IF NOT EXISTS uq_name_translations_entity_locale
+    ON domain_reference_master_geopolitical.name_translations (tenant_id, entity_type, entity_id, locale_code)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_name_translations_locale
+    ON domain_reference_master_geopolitical.name_translations (tenant_id, entity_type, locale_code)
+    WHERE is_deleted = false;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('019', 'Name translations: localized names of countries, regions, languages and subdivisions',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.name_translations;')
+ON CONFLICT (version) DO NOTHING;
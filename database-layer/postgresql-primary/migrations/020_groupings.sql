@@ -0,0 +1,101 @@
+-- ============================================================================
+-- MIGRATION: 020 groupings
+-- PURPOSE: Groupings of countries (EU, Schengen Area, euro area, ASEAN, OECD,
+--          tenant groupings) and the dated memberships of countries in them
+-- DEPENDENCIES: 003 bitemporal validity (btree_gist), 006 retention policies
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.groupings (
+    grouping_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    grouping_code VARCHAR(20) NOT NULL CHECK (grouping_code ~ '^[A-Z][A-Z0-9_-]{1,19}$'),
+    grouping_name VARCHAR(100) NOT NULL,
+    grouping_type VARCHAR(20) NOT NULL CHECK (grouping_type IN
+        ('POLITICAL', 'CUSTOMS_UNION', 'MONETARY_UNION', 'FREE_MOVEMENT', 'ECONOMIC', 'CUSTOM')),
+    description TEXT,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_groupings_code
+    ON domain_reference_master_geopolitical.groupings (tenant_id, grouping_code)
+    WHERE is_deleted = false;
+
+CREATE TABLE IF NOT EXISTS domain_reference_master_geopolitical.grouping_memberships (
+    grouping_membership_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    grouping_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.groupings(grouping_id) ON DELETE CASCADE,
+    country_id UUID NOT NULL REFERENCES domain_reference_master_geopolitical.countries(country_id) ON DELETE CASCADE,
+    membership_type VARCHAR(20) DEFAULT 'MEMBER' NOT NULL CHECK (membership_type IN ('MEMBER', 'ASSOCIATE', 'OBSERVER', 'CANDIDATE')),
+    -- The country joined on valid_from and left on valid_to
+    valid_from DATE,
+    valid_to DATE,
+    is_active BOOLEAN DEFAULT true NOT NULL,
+    is_deleted BOOLEAN DEFAULT false NOT NULL,
+    tenant_id VARCHAR(100) DEFAULT 'default-tenant' NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    created_by UUID,
+    created_ip INET,
+    created_device JSONB,
+    created_session UUID,
+    created_location JSONB,
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    updated_by UUID,
+    updated_ip INET,
+    updated_device JSONB,
+    updated_session UUID,
+    updated_location JSONB,
+    deleted_at TIMESTAMPTZ,
+    deleted_by UUID,
+    deleted_ip INET,
+    deleted_device JSONB,
+    deleted_session UUID,
+    deleted_location JSONB,
+    source_system VARCHAR(50) DEFAULT 'reference_master_geopolitical',
+    change_reason TEXT,
+    version INTEGER DEFAULT 1 NOT NULL,
+    data_classification VARCHAR(20) DEFAULT 'PUBLIC' NOT NULL,
+    retention_policy VARCHAR(50),
+    CONSTRAINT chk_grouping_memberships_validity CHECK (valid_to IS NULL OR valid_from IS NULL OR valid_to > valid_from),
+    -- A country holds one membership of a grouping at a time; the API
+    -- reports overlaps before writing
+    CONSTRAINT excl_grouping_memberships_period EXCLUDE USING gist (
+        tenant_id WITH =, grouping_id WITH =, country_id WITH =, daterange(valid_from, valid_to) WITH &&
+    ) WHERE (is_deleted = false)
+);
+
+CREATE INDEX IF NOT EXISTS idx_grouping_memberships_grouping
+    ON domain_reference_master_geopolitical.grouping_memberships (tenant_id, grouping_id)
+    WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_grouping_memberships_country
+    ON domain_reference_master_geopolitical.grouping_memberships (tenant_id, country_id)
+    WHERE is_deleted = false;
+
+INSERT INTO domain_reference_master_geopolitical.schema_migrations
+(version, description, rollback_sql)
+VALUES
+('020', 'Groupings: groupings of countries with dated memberships',
+ 'DROP TABLE IF EXISTS domain_reference_master_geopolitical.grouping_memberships; DROP TABLE IF EXISTS domain_reference_master_geopolitical.groupings;')
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,40 @@
+-- ============================================================================
+-- ADDRESS FORMAT SEEDING
+-- PURPOSE: Address layouts of the sample countries, after libaddressinput
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, migrations/015_address_formats.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+-- Countries that write the subdivision as its code, such as CA in the
+-- United States, use the code style. Japan and China put the largest unit
+-- first and have a Latin layout for romanized addresses.
+INSERT INTO address_formats (
+    country_id, template, latin_template, required_fields, uppercase_fields,
+    subdivision_label, locality_label, postal_code_label, subdivision_style,
+    tenant_id, change_reason
+)
+SELECT c.country_id, f.template, f.latin_template, f.required_fields, f.uppercase_fields,
+       f.subdivision_label, f.locality_label, f.postal_code_label, f.subdivision_style,
+       'default-tenant', 'Seed: address formats'
+FROM (VALUES
+    ('US', '%N%n%O%n%A%n%C, %S %Z', NULL, 'ACSZ', 'CS', 'state', 'city', 'zip', 'code'),
+    ('CA', '%N%n%O%n%A%n%C %S %Z', NULL, 'ACSZ', 'ACSZ', 'province', 'city', 'postal', 'code'),
+    ('GB', '%N%n%O%n%A%n%C%n%Z', NULL, 'ACZ', 'CZ', 'county', 'post_town', 'postal', 'name'),
+    ('DE', '%N%n%O%n%A%n%Z %C', NULL, 'ACZ', NULL, NULL, 'city', 'postal', 'name'),
+    ('FR', '%O%n%N%n%A%n%Z %C %X', NULL, 'ACZ', 'CX', NULL, 'city', 'postal', 'name'),
+    ('ES', '%N%n%O%n%A%n%Z %C %S', NULL, 'ACSZ', 'CS', 'province', 'city', 'postal', 'name'),
+    ('CH', '%O%n%N%n%A%nCH-%Z %C', NULL, 'ACZ', NULL, NULL, 'city', 'postal', 'name'),
+    ('JP', '〒%Z%n%S%n%A%n%O%n%N', '%N%n%O%n%A, %S%n%Z', 'ASZ', 'S', 'prefecture', 'city', 'postal', 'name'),
+    ('CN', '%Z%n%S%C%D%n%A%n%O%n%N', '%N%n%O%n%A%n%D%n%C%n%S, %Z', 'ACS', NULL, 'province', 'city', 'postal', 'name'),
+    ('IN', '%N%n%O%n%A%n%D%n%C %Z%n%S', NULL, 'ACSZ', NULL, 'state', 'city', 'pin', 'name'),
+    ('BR', '%O%n%N%n%A%n%D%n%C-%S%n%Z', NULL, 'ACSZ', 'CS', 'state', 'city', 'postal', 'code'),
+    ('AU', '%O%n%N%n%A%n%C %S %Z', NULL, 'ACSZ', 'CS', 'state', 'suburb', 'postal', 'code')
+) AS f (country_code, template, latin_template, required_fields, uppercase_fields,
+        subdivision_label, locality_label, postal_code_label, subdivision_style)
+JOIN countries c ON c.country_code = f.country_code AND c.tenant_id = 'default-tenant'
+WHERE NOT EXISTS (
+    SELECT 1 FROM address_formats af
+    WHERE af.country_id = c.country_id AND af.is_deleted = false
+);
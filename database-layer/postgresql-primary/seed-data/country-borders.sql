@@ -0,0 +1,39 @@
+-- ============================================================================
+-- COUNTRY BORDER SEEDING
+-- PURPOSE: Land and maritime borders between the sample countries
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, country-languages.sql,
+--               migrations/017_country_borders.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+-- Land border lengths are from the CIA World Factbook. FR borders BR in
+-- French Guiana and ES borders GB at Gibraltar.
+INSERT INTO country_borders (
+    country_id, neighbor_country_id, border_type, length_km, tenant_id, change_reason
+)
+SELECT c.country_id, n.country_id, b.border_type, b.length_km, 'default-tenant', 'Seed: country borders'
+FROM (VALUES
+    ('CA', 'US', 'LAND', 8893.00),
+    ('CH', 'DE', 'LAND', 348.00),
+    ('CH', 'FR', 'LAND', 525.00),
+    ('CN', 'IN', 'LAND', 2659.00),
+    ('DE', 'FR', 'LAND', 418.00),
+    ('ES', 'FR', 'LAND', 646.00),
+    ('ES', 'GB', 'LAND', 1.20),
+    ('FR', 'BR', 'LAND', 649.00),
+    ('CA', 'US', 'MARITIME', NULL),
+    ('DE', 'GB', 'MARITIME', NULL),
+    ('ES', 'FR', 'MARITIME', NULL),
+    ('FR', 'AU', 'MARITIME', NULL),
+    ('FR', 'GB', 'MARITIME', NULL)
+) AS b (country_code, neighbor_code, border_type, length_km)
+JOIN countries c ON c.country_code = b.country_code AND c.tenant_id = 'default-tenant' AND c.is_deleted = false
+JOIN countries n ON n.country_code = b.neighbor_code AND n.tenant_id = 'default-tenant' AND n.is_deleted = false
+WHERE NOT EXISTS (
+    SELECT 1 FROM country_borders cb
+    WHERE cb.border_type = b.border_type AND cb.tenant_id = 'default-tenant' AND cb.is_deleted = false
+      AND ((cb.country_id = c.country_id AND cb.neighbor_country_id = n.country_id)
+        OR (cb.country_id = n.country_id AND cb.neighbor_country_id = c.country_id))
+);
@@ -0,0 +1,49 @@
+-- ============================================================================
+-- COUNTRY EXTERNAL CODE SEEDING
+-- PURPOSE: FIPS 10-4, IOC, ITU, GENC and World Bank codes of the sample
+--          countries
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, country-languages.sql,
+--               migrations/018_country_external_codes.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+-- GENC codes are the trigraphs. West Germany competed as FRG at the Olympic
+-- Games until reunification.
+INSERT INTO country_external_codes (
+    country_id, code_system, code, valid_from, valid_to, tenant_id, change_reason
+)
+SELECT c.country_id, x.code_system, x.code, x.valid_from::date, x.valid_to::date, 'default-tenant', 'Seed: country external codes'
+FROM (VALUES
+    ('US', 'fips', 'US', NULL, NULL), ('US', 'ioc', 'USA', NULL, NULL), ('US', 'itu', 'USA', NULL, NULL),
+    ('US', 'genc', 'USA', NULL, NULL), ('US', 'wb', 'USA', NULL, NULL),
+    ('CA', 'fips', 'CA', NULL, NULL), ('CA', 'ioc', 'CAN', NULL, NULL), ('CA', 'itu', 'CAN', NULL, NULL),
+    ('CA', 'genc', 'CAN', NULL, NULL), ('CA', 'wb', 'CAN', NULL, NULL),
+    ('GB', 'fips', 'UK', NULL, NULL), ('GB', 'ioc', 'GBR', NULL, NULL), ('GB', 'itu', 'G', NULL, NULL),
+    ('GB', 'genc', 'GBR', NULL, NULL), ('GB', 'wb', 'GBR', NULL, NULL),
+    ('DE', 'fips', 'GM', NULL, NULL), ('DE', 'ioc', 'FRG', '1968-01-01', '1990-10-03'),
+    ('DE', 'ioc', 'GER', '1990-10-03', NULL), ('DE', 'itu', 'D', NULL, NULL),
+    ('DE', 'genc', 'DEU', NULL, NULL), ('DE', 'wb', 'DEU', NULL, NULL),
+    ('FR', 'fips', 'FR', NULL, NULL), ('FR', 'ioc', 'FRA', NULL, NULL), ('FR', 'itu', 'F', NULL, NULL),
+    ('FR', 'genc', 'FRA', NULL, NULL), ('FR', 'wb', 'FRA', NULL, NULL),
+    ('ES', 'fips', 'SP', NULL, NULL), ('ES', 'ioc', 'ESP', NULL, NULL), ('ES', 'itu', 'E', NULL, NULL),
+    ('ES', 'genc', 'ESP', NULL, NULL), ('ES', 'wb', 'ESP', NULL, NULL),
+    ('CH', 'fips', 'SZ', NULL, NULL), ('CH', 'ioc', 'SUI', NULL, NULL), ('CH', 'itu', 'SUI', NULL, NULL),
+    ('CH', 'genc', 'CHE', NULL, NULL), ('CH', 'wb', 'CHE', NULL, NULL),
+    ('JP', 'fips', 'JA', NULL, NULL), ('JP', 'ioc', 'JPN', NULL, NULL), ('JP', 'itu', 'J', NULL, NULL),
+    ('JP', 'genc', 'JPN', NULL, NULL), ('JP', 'wb', 'JPN', NULL, NULL),
+    ('CN', 'fips', 'CH', NULL, NULL), ('CN', 'ioc', 'CHN', NULL, NULL), ('CN', 'itu', 'CHN', NULL, NULL),
+    ('CN', 'genc', 'CHN', NULL, NULL), ('CN', 'wb', 'CHN', NULL, NULL),
+    ('IN', 'fips', 'IN', NULL, NULL), ('IN', 'ioc', 'IND', NULL, NULL), ('IN', 'itu', 'IND', NULL, NULL),
+    ('IN', 'genc', 'IND', NULL, NULL), ('IN', 'wb', 'IND', NULL, NULL),
+    ('BR', 'fips', 'BR', NULL, NULL), ('BR', 'ioc', 'BRA', NULL, NULL), ('BR', 'itu', 'B', NULL, NULL),
+    ('BR', 'genc', 'BRA', NULL, NULL), ('BR', 'wb', 'BRA', NULL, NULL),
+    ('AU', 'fips', 'AS', NULL, NULL), ('AU', 'ioc', 'AUS', NULL, NULL), ('AU', 'itu', 'AUS', NULL, NULL),
+    ('AU', 'genc', 'AUS', NULL, NULL), ('AU', 'wb', 'AUS', NULL, NULL)
+) AS x (country_code, code_system, code, valid_from, valid_to)
+JOIN countries c ON c.country_code = x.country_code AND c.tenant_id = 'default-tenant' AND c.is_deleted = false
+WHERE NOT EXISTS (
+    SELECT 1 FROM country_external_codes e
+    WHERE e.country_id = c.country_id AND e.code_system = x.code_system AND e.code = x.code AND e.is_deleted = false
+);
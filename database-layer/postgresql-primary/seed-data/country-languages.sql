@@ -0,0 +1,78 @@
+-- ============================================================================
+-- COUNTRY LANGUAGE SEEDING
+-- PURPOSE: Official and regional languages of the sample countries
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, migrations/011_country_languages.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+INSERT INTO languages (
+    language_id, language_code, language_name, iso3_code, native_name, direction,
+    is_active, is_deleted, tenant_id, created_at, updated_at, version
+)
+SELECT gen_random_uuid(), l.code, l.name, l.iso3, l.native, 'LTR', true, false, 'default-tenant', NOW(), NOW(), 1
+FROM (VALUES
+    ('it', 'Italian', 'ita', 'Italiano'),
+    ('rm', 'Romansh', 'roh', 'Rumantsch'),
+    ('bn', 'Bengali', 'ben', 'বাংলা'),
+    ('te', 'Telugu', 'tel', 'తెలుగు'),
+    ('ta', 'Tamil', 'tam', 'தமிழ்'),
+    ('ca', 'Catalan', 'cat', 'Català'),
+    ('eu', 'Basque', 'eus', 'Euskara'),
+    ('gl', 'Galician', 'glg', 'Galego'),
+    ('cy', 'Welsh', 'cym', 'Cymraeg')
+) AS l (code, name, iso3, native)
+WHERE NOT EXISTS (SELECT 1 FROM languages WHERE language_code = l.code);
+
+INSERT INTO countries (
+    country_id, country_code, country_name, iso3_code, numeric_code,
+    official_name, capital_city, continent_code, phone_prefix,
+    is_active, is_deleted, tenant_id, created_at, updated_at, version
+)
+SELECT gen_random_uuid(), 'CH', 'Switzerland', 'CHE', 756, 'Swiss Confederation', 'Bern', 'EU', '+41',
+       true, false, 'default-tenant', NOW(), NOW(), 1
+WHERE NOT EXISTS (SELECT 1 FROM countries WHERE country_code = 'CH');
+
+-- Speaker shares are approximate first-language shares
+INSERT INTO country_languages (
+    country_id, language_id, status, speaker_percentage, is_primary, tenant_id, change_reason
+)
+SELECT c.country_id, l.language_id, p.status, p.share, p.is_primary, 'default-tenant', 'Seed: country languages'
+FROM (VALUES
+    ('CH', 'de', 'OFFICIAL', 62.30, true),
+    ('CH', 'fr', 'OFFICIAL', 22.80, false),
+    ('CH', 'it', 'OFFICIAL', 8.00, false),
+    ('CH', 'rm', 'OFFICIAL', 0.50, false),
+    ('IN', 'hi', 'OFFICIAL', 43.60, true),
+    ('IN', 'en', 'OFFICIAL', NULL, false),
+    ('IN', 'bn', 'RECOGNIZED', 8.00, false),
+    ('IN', 'te', 'RECOGNIZED', 6.70, false),
+    ('IN', 'ta', 'RECOGNIZED', 5.70, false),
+    ('ES', 'es', 'OFFICIAL', 82.00, true),
+    ('ES', 'ca', 'REGIONAL', 17.00, false),
+    ('ES', 'gl', 'REGIONAL', 5.00, false),
+    ('ES', 'eu', 'REGIONAL', 2.00, false),
+    ('CA', 'en', 'OFFICIAL', 56.00, true),
+    ('CA', 'fr', 'OFFICIAL', 21.00, false),
+    ('GB', 'en', 'OFFICIAL', 98.00, true),
+    ('GB', 'cy', 'REGIONAL', 0.90, false),
+    ('US', 'en', 'OFFICIAL', 78.00, true),
+    ('US', 'es', 'RECOGNIZED', 13.40, false),
+    ('DE', 'de', 'OFFICIAL', 95.00, true),
+    ('FR', 'fr', 'OFFICIAL', 88.00, true),
+    ('JP', 'ja', 'OFFICIAL', 99.00, true),
+    ('CN', 'zh', 'OFFICIAL', 92.00, true),
+    ('BR', 'pt', 'OFFICIAL', 98.00, true),
+    ('AU', 'en', 'OFFICIAL', 72.00, true)
+) AS p (country_code, language_code, status, share, is_primary)
+JOIN countries c ON c.country_code = p.country_code AND c.tenant_id = 'default-tenant'
+JOIN languages l ON l.language_code = p.language_code AND l.tenant_id = 'default-tenant'
+WHERE NOT EXISTS (
+    SELECT 1 FROM country_languages cl
+    WHERE cl.country_id = c.country_id AND cl.language_id = l.language_id AND cl.is_deleted = false
+)
+AND NOT (p.is_primary AND EXISTS (
+    SELECT 1 FROM country_languages cl
+    WHERE cl.country_id = c.country_id AND cl.is_primary AND cl.is_deleted = false
+));
@@ -0,0 +1,71 @@
+-- ============================================================================
+-- CURRENCY SEEDING
+-- PURPOSE: ISO 4217 currencies of the sample countries and their periods
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, migrations/010_currencies.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+INSERT INTO currencies (
+    currency_id, currency_code, currency_name, numeric_code, minor_units, symbol,
+    is_active, is_deleted, tenant_id, created_at, updated_at, version
+) VALUES
+(gen_random_uuid(), 'USD', 'US Dollar', 840, 2, '$', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'GBP', 'Pound Sterling', 826, 2, '£', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'EUR', 'Euro', 978, 2, '€', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'DEM', 'Deutsche Mark', 276, 2, 'DM', false, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'FRF', 'French Franc', 250, 2, 'F', false, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'ESP', 'Spanish Peseta', 724, 0, 'Pta', false, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'JPY', 'Yen', 392, 0, '¥', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'CNY', 'Yuan Renminbi', 156, 2, '¥', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'INR', 'Indian Rupee', 356, 2, '₹', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'BRL', 'Brazilian Real', 986, 2, 'R$', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'AUD', 'Australian Dollar', 36, 2, '$', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'CAD', 'Canadian Dollar', 124, 2, '$', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'CZK', 'Czech Koruna', 203, 2, 'Kč', true, false, 'default-tenant', NOW(), NOW(), 1)
+ON CONFLICT (tenant_id, currency_code) DO NOTHING;
+
+-- Euro cash replaced the national currencies on 2002-01-01; the euro became
+-- the currency of account on 1999-01-01
+INSERT INTO country_currencies (
+    country_id, currency_id, is_legal_tender, valid_from, valid_to, tenant_id, change_reason
+)
+SELECT c.country_id, cu.currency_id, p.is_legal_tender, p.valid_from::date, p.valid_to::date, 'default-tenant', 'Seed: ISO 4217'
+FROM (VALUES
+    ('US', 'USD', true, NULL, NULL),
+    ('GB', 'GBP', true, NULL, NULL),
+    ('DE', 'DEM', true, '1948-06-21', '2002-01-01'),
+    ('DE', 'EUR', true, '1999-01-01', NULL),
+    ('FR', 'FRF', true, '1960-01-01', '2002-01-01'),
+    ('FR', 'EUR', true, '1999-01-01', NULL),
+    ('ES', 'ESP', true, '1869-01-01', '2002-01-01'),
+    ('ES', 'EUR', true, '1999-01-01', NULL),
+    ('JP', 'JPY', true, NULL, NULL),
+    ('CN', 'CNY', true, NULL, NULL),
+    ('IN', 'INR', true, NULL, NULL),
+    ('BR', 'BRL', true, '1994-07-01', NULL),
+    ('AU', 'AUD', true, '1966-02-14', NULL),
+    ('CA', 'CAD', true, NULL, NULL),
+    -- Not legal tender, but widely accepted near the border
+    ('CA', 'USD', false, NULL, NULL),
+    ('CZ', 'CZK', true, '1993-02-08', NULL)
+) AS p (country_code, currency_code, is_legal_tender, valid_from, valid_to)
+JOIN countries c ON c.country_code = p.country_code AND c.tenant_id = 'default-tenant'
+JOIN currencies cu ON cu.currency_code = p.currency_code AND cu.tenant_id = 'default-tenant'
+WHERE NOT EXISTS (
+    SELECT 1 FROM country_currencies cc
+    WHERE cc.country_id = c.country_id AND cc.currency_id = cu.currency_id
+);
+
+-- The primary currency on the country row
+UPDATE countries c
+SET currency_id = cu.currency_id
+FROM currencies cu
+WHERE cu.tenant_id = c.tenant_id
+  AND c.currency_id IS NULL
+  AND cu.currency_code = CASE c.country_code
+      WHEN 'US' THEN 'USD' WHEN 'GB' THEN 'GBP' WHEN 'DE' THEN 'EUR' WHEN 'FR' THEN 'EUR'
+      WHEN 'ES' THEN 'EUR' WHEN 'JP' THEN 'JPY' WHEN 'CN' THEN 'CNY' WHEN 'IN' THEN 'INR'
+      WHEN 'BR' THEN 'BRL' WHEN 'AU' THEN 'AUD' WHEN 'CA' THEN 'CAD' WHEN 'CZ' THEN 'CZK'
+  END;
@@ -0,0 +1,51 @@
+-- ============================================================================
+-- GEO CENTROID SEEDING
+-- PURPOSE: Centroids of the sample countries and first-level subdivisions
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, migrations/016_geo_centroids.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+-- Area-weighted centroids of the bundled boundaries
+-- (internal/xcut/geo/boundaries). An archipelago's centroid may fall at sea,
+-- as Japan's does. Regenerate these values when outlines change.
+UPDATE countries c
+SET latitude = v.latitude, longitude = v.longitude, updated_at = NOW()
+FROM (VALUES
+    ('US', 45.85165, -112.96332),
+    ('CA', 60.51039, -97.48038),
+    ('GB', 54.07292, -2.94036),
+    ('DE', 51.12268, 10.38402),
+    ('FR', 46.57205, 2.48684),
+    ('ES', 40.29785, -3.61124),
+    ('CH', 46.78821, 8.19182),
+    ('JP', 37.62563, 138.01309),
+    ('CN', 36.48468, 103.92567),
+    ('IN', 23.01419, 79.56288),
+    ('BR', -10.94905, -52.91757),
+    ('AU', -25.63162, 134.42522)
+) AS v (country_code, latitude, longitude)
+WHERE c.country_code = v.country_code
+  AND c.tenant_id = 'default-tenant'
+  AND c.latitude IS NULL;
+
+UPDATE country_subdivisions s
+SET latitude = v.latitude, longitude = v.longitude, updated_at = NOW()
+FROM (VALUES
+    ('US-CA', 37.19320, -119.57514),
+    ('US-NY', 42.92422, -75.59819),
+    ('US-TX', 31.41243, -99.42113),
+    ('GB-ENG', 52.61000, -1.50019),
+    ('GB-SCT', 56.67078, -4.04255),
+    ('DE-BY', 48.93159, 11.37050),
+    ('FR-IDF', 48.67658, 2.47744),
+    ('ES-CT', 41.80801, 1.57894),
+    ('ES-MD', 40.46711, -3.71768),
+    ('JP-13', 35.70813, 139.48598),
+    ('CN-BJ', 40.25026, 116.51298),
+    ('IN-DL', 28.63405, 77.10877)
+) AS v (subdivision_code, latitude, longitude)
+WHERE s.subdivision_code = v.subdivision_code
+  AND s.tenant_id = 'default-tenant'
+  AND s.latitude IS NULL;
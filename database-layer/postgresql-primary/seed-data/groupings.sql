@@ -0,0 +1,67 @@
+-- ============================================================================
+-- GROUPING SEEDING
+-- PURPOSE: EU, EU customs union, euro area, Schengen Area, ASEAN and OECD
+--          with the memberships of the sample countries
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, country-languages.sql,
+--               migrations/020_groupings.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+INSERT INTO groupings (
+    grouping_code, grouping_name, grouping_type, description, tenant_id, change_reason
+)
+SELECT g.code, g.name, g.grouping_type, g.description, 'default-tenant', 'Seed: groupings'
+FROM (VALUES
+    ('EU', 'European Union', 'POLITICAL', 'Membership counts from accession to the European Communities'),
+    ('EU-CU', 'European Union Customs Union', 'CUSTOMS_UNION', 'Goods move between members without customs duties; the United Kingdom stayed in it until the end of the Brexit transition period'),
+    ('EUROZONE', 'Euro area', 'MONETARY_UNION', 'EU members using the euro'),
+    ('SCHENGEN', 'Schengen Area', 'FREE_MOVEMENT', 'Countries without checks at their common borders'),
+    ('ASEAN', 'Association of Southeast Asian Nations', 'POLITICAL', NULL),
+    ('OECD', 'Organisation for Economic Co-operation and Development', 'ECONOMIC', NULL)
+) AS g(code, name, grouping_type, description)
+WHERE NOT EXISTS (
+    SELECT 1 FROM groupings x WHERE x.grouping_code = g.code AND x.tenant_id = 'default-tenant' AND x.is_deleted = false
+);
+
+-- Periods end on the first day outside the grouping. None of the sample
+-- countries belongs to ASEAN.
+INSERT INTO grouping_memberships (
+    grouping_id, country_id, membership_type, valid_from, valid_to, tenant_id, change_reason
+)
+SELECT g.grouping_id, c.country_id, m.membership_type, m.valid_from::date, m.valid_to::date, 'default-tenant', 'Seed: grouping memberships'
+FROM (VALUES
+    ('EU', 'DE', 'MEMBER', '1958-01-01', NULL),
+    ('EU', 'FR', 'MEMBER', '1958-01-01', NULL),
+    ('EU', 'GB', 'MEMBER', '1973-01-01', '2020-02-01'),
+    ('EU', 'ES', 'MEMBER', '1986-01-01', NULL),
+    ('EU-CU', 'DE', 'MEMBER', '1968-07-01', NULL),
+    ('EU-CU', 'FR', 'MEMBER', '1968-07-01', NULL),
+    ('EU-CU', 'GB', 'MEMBER', '1973-01-01', '2021-01-01'),
+    ('EU-CU', 'ES', 'MEMBER', '1986-01-01', NULL),
+    ('EUROZONE', 'DE', 'MEMBER', '1999-01-01', NULL),
+    ('EUROZONE', 'FR', 'MEMBER', '1999-01-01', NULL),
+    ('EUROZONE', 'ES', 'MEMBER', '1999-01-01', NULL),
+    ('SCHENGEN', 'DE', 'MEMBER', '1995-03-26', NULL),
+    ('SCHENGEN', 'FR', 'MEMBER', '1995-03-26', NULL),
+    ('SCHENGEN', 'ES', 'MEMBER', '1995-03-26', NULL),
+    ('SCHENGEN', 'CH', 'ASSOCIATE', '2008-12-12', NULL),
+    ('OECD', 'CA', 'MEMBER', '1961-04-10', NULL),
+    ('OECD', 'US', 'MEMBER', '1961-04-12', NULL),
+    ('OECD', 'GB', 'MEMBER', '1961-05-02', NULL),
+    ('OECD', 'ES', 'MEMBER', '1961-08-03', NULL),
+    ('OECD', 'FR', 'MEMBER', '1961-08-07', NULL),
+    ('OECD', 'DE', 'MEMBER', '1961-09-27', NULL),
+    ('OECD', 'CH', 'MEMBER', '1961-09-28', NULL),
+    ('OECD', 'JP', 'MEMBER', '1964-04-28', NULL),
+    ('OECD', 'AU', 'MEMBER', '1971-06-07', NULL),
+    ('OECD', 'BR', 'CANDIDATE', '2022-01-25', NULL)
+) AS m(grouping_code, country_code, membership_type, valid_from, valid_to)
+JOIN groupings g ON g.grouping_code = m.grouping_code AND g.tenant_id = 'default-tenant' AND g.is_deleted = false
+JOIN countries c ON c.country_code = m.country_code AND c.tenant_id = 'default-tenant' AND c.is_deleted = false
+WHERE NOT EXISTS (
+    SELECT 1 FROM grouping_memberships x
+    WHERE x.grouping_id = g.grouping_id AND x.country_id = c.country_id
+      AND x.tenant_id = 'default-tenant' AND x.is_deleted = false
+);
@@ -0,0 +1,33 @@
+-- ============================================================================
+-- HISTORICAL VALIDITY SEEDING
+-- PURPOSE: Sample valid-time periods for as-of queries
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: migrations/003_bitemporal_validity.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+-- Czechoslovakia existed until the dissolution on 1993-01-01
+INSERT INTO countries (
+    country_id, country_code, country_name, iso3_code, numeric_code,
+    official_name, capital_city, continent_code, phone_prefix,
+    valid_from, valid_to, is_active, is_deleted, tenant_id, created_at, updated_at, version
+) VALUES
+(gen_random_uuid(), 'CS', 'Czechoslovakia', 'CSK', 200, 'Czech and Slovak Federative Republic', 'Prague', 'EU', '+42',
+ '1918-10-28', '1993-01-01', true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'CZ', 'Czechia', 'CZE', 203, 'Czech Republic', 'Prague', 'EU', '+420',
+ '1993-01-01', NULL, true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'SK', 'Slovakia', 'SVK', 703, 'Slovak Republic', 'Bratislava', 'EU', '+421',
+ '1993-01-01', NULL, true, false, 'default-tenant', NOW(), NOW(), 1),
+(gen_random_uuid(), 'SZ', 'Eswatini', 'SWZ', 748, 'Kingdom of Eswatini', 'Mbabane', 'AF', '+268',
+ '1968-09-06', NULL, true, false, 'default-tenant', NOW(), NOW(), 1)
+ON CONFLICT (country_code) DO NOTHING;
+
+-- Swaziland was renamed Eswatini on 2018-04-19
+INSERT INTO country_periods (
+    country_id, country_name, official_name, capital_city, valid_from, valid_to, tenant_id, change_reason
+) VALUES
+((SELECT country_id FROM countries WHERE country_code = 'SZ'), 'Swaziland', 'Kingdom of Swaziland', 'Mbabane',
+ '1968-09-06', '2018-04-19', 'default-tenant', 'Independence'),
+((SELECT country_id FROM countries WHERE country_code = 'SZ'), 'Eswatini', 'Kingdom of Eswatini', 'Mbabane',
+ '2018-04-19', NULL, 'default-tenant', 'Renamed by royal decree');
@@ -0,0 +1,127 @@
+-- ============================================================================
+-- NAME TRANSLATION SEEDING
+-- PURPOSE: Arabic and Japanese names of the sample countries, regions and
+--          languages, and the Arabic locale of the Arabic storefront
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, country-languages.sql,
+--               migrations/019_name_translations.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+INSERT INTO locales (
+    locale_id, locale_code, locale_name, language_id, country_id,
+    is_active, is_deleted, tenant_id, created_at, updated_at, version
+)
+SELECT gen_random_uuid(), 'ar-SA', 'Arabic (Saudi Arabia)', l.language_id,
+       (SELECT country_id FROM countries WHERE country_code = 'SA' AND tenant_id = 'default-tenant'),
+       true, false, 'default-tenant', NOW(), NOW(), 1
+FROM languages l
+WHERE l.language_code = 'ar' AND l.tenant_id = 'default-tenant'
+  AND NOT EXISTS (SELECT 1 FROM locales WHERE locale_code = 'ar-SA' AND tenant_id = 'default-tenant');
+
+-- Names are kept under the language tag so that every locale of the
+-- language finds them. Japanese sort names are the hiragana readings, by
+-- which Japanese lists are ordered.
+INSERT INTO name_translations (
+    entity_type, entity_id, locale_code, name, short_name, official_name, sort_name, tenant_id, change_reason
+)
+SELECT 'country', c.country_id, t.locale_code, t.name, t.short_name, t.official_name, t.sort_name, 'default-tenant', 'Seed: name translations'
+FROM (VALUES
+    ('US', 'ja', 'アメリカ合衆国', 'アメリカ', 'アメリカ合衆国', 'あめりかがっしゅうこく'),
+    ('GB', 'ja', 'イギリス', NULL, 'グレートブリテン及び北アイルランド連合王国', 'いぎりす'),
+    ('DE', 'ja', 'ドイツ', NULL, 'ドイツ連邦共和国', 'どいつ'),
+    ('FR', 'ja', 'フランス', NULL, 'フランス共和国', 'ふらんす'),
+    ('JP', 'ja', '日本', NULL, '日本国', 'にほん'),
+    ('CN', 'ja', '中国', NULL, '中華人民共和国', 'ちゅうごく'),
+    ('IN', 'ja', 'インド', NULL, 'インド共和国', 'いんど'),
+    ('BR', 'ja', 'ブラジル', NULL, 'ブラジル連邦共和国', 'ぶらじる'),
+    ('AU', 'ja', 'オーストラリア', NULL, 'オーストラリア連邦', 'おーすとらりあ'),
+    ('CA', 'ja', 'カナダ', NULL, NULL, 'かなだ'),
+    ('ES', 'ja', 'スペイン', NULL, 'スペイン王国', 'すぺいん'),
+    ('CH', 'ja', 'スイス', NULL, 'スイス連邦', 'すいす'),
+    ('US', 'ar', 'الولايات المتحدة', NULL, 'الولايات المتحدة الأمريكية', NULL),
+    ('GB', 'ar', 'المملكة المتحدة', NULL, 'المملكة المتحدة لبريطانيا العظمى وأيرلندا الشمالية', NULL),
+    ('DE', 'ar', 'ألمانيا', NULL, 'جمهورية ألمانيا الاتحادية', NULL),
+    ('FR', 'ar', 'فرنسا', NULL, 'الجمهورية الفرنسية', NULL),
+    ('JP', 'ar', 'اليابان', NULL, NULL, NULL),
+    ('CN', 'ar', 'الصين', NULL, 'جمهورية الصين الشعبية', NULL),
+    ('IN', 'ar', 'الهند', NULL, 'جمهورية الهند', NULL),
+    ('BR', 'ar', 'البرازيل', NULL, 'جمهورية البرازيل الاتحادية', NULL),
+    ('AU', 'ar', 'أستراليا', NULL, 'كومنولث أستراليا', NULL),
+    ('CA', 'ar', 'كندا', NULL, NULL, NULL),
+    ('ES', 'ar', 'إسبانيا', NULL, 'مملكة إسبانيا', NULL),
+    ('CH', 'ar', 'سويسرا', NULL, 'الاتحاد السويسري', NULL)
+) AS t(country_code, locale_code, name, short_name, official_name, sort_name)
+JOIN countries c ON c.country_code = t.country_code AND c.tenant_id = 'default-tenant' AND c.is_deleted = false
+WHERE NOT EXISTS (
+    SELECT 1 FROM name_translations n
+    WHERE n.entity_type = 'country' AND n.entity_id = c.country_id AND n.locale_code = t.locale_code
+      AND n.tenant_id = 'default-tenant' AND n.is_deleted = false
+);
+
+INSERT INTO name_translations (
+    entity_type, entity_id, locale_code, name, sort_name, tenant_id, change_reason
+)
+SELECT 'region', r.region_id, t.locale_code, t.name, t.sort_name, 'default-tenant', 'Seed: name translations'
+FROM (VALUES
+    ('NA', 'ja', '北アメリカ', 'きたあめりか'),
+    ('EU', 'ja', 'ヨーロッパ', 'よーろっぱ'),
+    ('AS', 'ja', 'アジア', 'あじあ'),
+    ('SA', 'ja', '南アメリカ', 'みなみあめりか'),
+    ('AF', 'ja', 'アフリカ', 'あふりか'),
+    ('OC', 'ja', 'オセアニア', 'おせあにあ'),
+    ('WE', 'ja', '西ヨーロッパ', 'にしよーろっぱ'),
+    ('EE', 'ja', '東ヨーロッパ', 'ひがしよーろっぱ'),
+    ('SEA', 'ja', '東南アジア', 'とうなんあじあ'),
+    ('ME', 'ja', '中東', 'ちゅうとう'),
+    ('NA', 'ar', 'أمريكا الشمالية', NULL),
+    ('EU', 'ar', 'أوروبا', NULL),
+    ('AS', 'ar', 'آسيا', NULL),
+    ('SA', 'ar', 'أمريكا الجنوبية', NULL),
+    ('AF', 'ar', 'أفريقيا', NULL),
+    ('OC', 'ar', 'أوقيانوسيا', NULL),
+    ('WE', 'ar', 'أوروبا الغربية', NULL),
+    ('EE', 'ar', 'أوروبا الشرقية', NULL),
+    ('SEA', 'ar', 'جنوب شرق آسيا', NULL),
+    ('ME', 'ar', 'الشرق الأوسط', NULL)
+) AS t(region_code, locale_code, name, sort_name)
+JOIN regions r ON r.region_code = t.region_code AND r.tenant_id = 'default-tenant' AND r.is_deleted = false
+WHERE NOT EXISTS (
+    SELECT 1 FROM name_translations n
+    WHERE n.entity_type = 'region' AND n.entity_id = r.region_id AND n.locale_code = t.locale_code
+      AND n.tenant_id = 'default-tenant' AND n.is_deleted = false
+);
+
+INSERT INTO name_translations (
+    entity_type, entity_id, locale_code, name, sort_name, tenant_id, change_reason
+)
+SELECT 'language', l.language_id, t.locale_code, t.name, t.sort_name, 'default-tenant', 'Seed: name translations'
+FROM (VALUES
+    ('en', 'ja', '英語', 'えいご'),
+    ('es', 'ja', 'スペイン語', 'すぺいんご'),
+    ('fr', 'ja', 'フランス語', 'ふらんすご'),
+    ('de', 'ja', 'ドイツ語', 'どいつご'),
+    ('zh', 'ja', '中国語', 'ちゅうごくご'),
+    ('ja', 'ja', '日本語', 'にほんご'),
+    ('ar', 'ja', 'アラビア語', 'あらびあご'),
+    ('hi', 'ja', 'ヒンディー語', 'ひんでぃーご'),
+    ('pt', 'ja', 'ポルトガル語', 'ぽるとがるご'),
+    ('ru', 'ja', 'ロシア語', 'ろしあご'),
+    ('en', 'ar', 'الإنجليزية', NULL),
+    ('es', 'ar', 'الإسبانية', NULL),
+    ('fr', 'ar', 'الفرنسية', NULL),
+    ('de', 'ar', 'الألمانية', NULL),
+    ('zh', 'ar', 'الصينية', NULL),
+    ('ja', 'ar', 'اليابانية', NULL),
+    ('ar', 'ar', 'العربية', NULL),
+    ('hi', 'ar', 'الهندية', NULL),
+    ('pt', 'ar', 'البرتغالية', NULL),
+    ('ru', 'ar', 'الروسية', NULL)
+) AS t(language_code, locale_code, name, sort_name)
+JOIN languages l ON l.language_code = t.language_code AND l.tenant_id = 'default-tenant' AND l.is_deleted = false
+WHERE NOT EXISTS (
+    SELECT 1 FROM name_translations n
+    WHERE n.entity_type = 'language' AND n.entity_id = l.language_id AND n.locale_code = t.locale_code
+      AND n.tenant_id = 'default-tenant' AND n.is_deleted = false
+);
@@ -0,0 +1,47 @@
+-- ============================================================================
+-- PHONE NUMBERING PLAN SEEDING
+-- PURPOSE: E.164 numbering rules of the sample countries
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, country-languages.sql,
+--               migrations/013_phone_numbering_plans.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+-- Lengths are those of the national significant number, without trunk
+-- prefix. Canada shares +1 with the United States and is told apart by its
+-- area codes.
+INSERT INTO phone_numbering_plans (
+    country_id, calling_code, trunk_prefix, international_prefix,
+    min_length, max_length, leading_digits, tenant_id, change_reason
+)
+SELECT c.country_id, p.calling_code, p.trunk_prefix, p.international_prefix,
+       p.min_length, p.max_length, p.leading_digits, 'default-tenant', 'Seed: phone numbering plans'
+FROM (VALUES
+    ('US', '1', '1', '011', 10, 10, NULL),
+    ('CA', '1', '1', '011', 10, 10,
+     '204,226,236,249,250,263,289,306,343,354,365,367,368,382,403,416,418,428,431,437,438,450,468,474,506,514,519,548,579,581,584,587,604,613,639,647,672,683,705,709,742,753,778,780,782,807,819,825,867,873,879,902,905'),
+    ('GB', '44', '0', '00', 9, 10, NULL),
+    ('DE', '49', '0', '00', 6, 13, NULL),
+    ('FR', '33', '0', '00', 9, 9, NULL),
+    ('ES', '34', NULL, '00', 9, 9, NULL),
+    ('CH', '41', '0', '00', 9, 9, NULL),
+    ('JP', '81', '0', '010', 9, 10, NULL),
+    ('CN', '86', '0', '00', 9, 11, NULL),
+    ('IN', '91', '0', '00', 10, 10, NULL),
+    ('BR', '55', '0', '00', 10, 11, NULL),
+    ('AU', '61', '0', '0011', 9, 9, NULL)
+) AS p (country_code, calling_code, trunk_prefix, international_prefix, min_length, max_length, leading_digits)
+JOIN countries c ON c.country_code = p.country_code AND c.tenant_id = 'default-tenant'
+WHERE NOT EXISTS (
+    SELECT 1 FROM phone_numbering_plans pp
+    WHERE pp.country_id = c.country_id AND pp.is_deleted = false
+);
+
+-- Keep the legacy display column consistent with the plans
+UPDATE countries c
+SET phone_prefix = '+' || pp.calling_code
+FROM phone_numbering_plans pp
+WHERE pp.country_id = c.country_id
+  AND pp.is_deleted = false
+  AND c.phone_prefix IS DISTINCT FROM '+' || pp.calling_code;
@@ -0,0 +1,59 @@
+-- ============================================================================
+-- POSTAL CODE FORMAT SEEDING
+-- PURPOSE: Postal code rules of the sample countries and of subdivisions
+--          whose codes can be told from the postal code
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, country-languages.sql,
+--               migrations/014_postal_code_formats.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+-- Patterns match the compact code: uppercase, letters and digits only
+INSERT INTO postal_code_formats (
+    country_id, subdivision_id, pattern, format, example, is_required, tenant_id, change_reason
+)
+SELECT c.country_id, s.subdivision_id, f.pattern, f.format, f.example, f.is_required,
+       'default-tenant', 'Seed: postal code formats'
+FROM (VALUES
+    ('US', NULL, '^([0-9]{5})$', NULL, '20500', true),
+    ('US', NULL, '^([0-9]{5})([0-9]{4})$', '$1-$2', '20500-0003', true),
+    ('CA', NULL, '^([ABCEGHJ-NPRSTVXY][0-9][ABCEGHJ-NPRSTV-Z])([0-9][ABCEGHJ-NPRSTV-Z][0-9])$', '$1 $2', 'K1A 0B1', true),
+    ('GB', NULL, '^([A-Z]{1,2}[0-9][A-Z0-9]?)([0-9][A-Z]{2})$', '$1 $2', 'SW1A 1AA', true),
+    ('DE', NULL, '^[0-9]{5}$', NULL, '10117', true),
+    ('FR', NULL, '^[0-9]{5}$', NULL, '75008', true),
+    ('ES', NULL, '^(0[1-9]|[1-4][0-9]|5[0-2])[0-9]{3}$', NULL, '28013', true),
+    ('CH', NULL, '^[1-9][0-9]{3}$', NULL, '3003', true),
+    ('JP', NULL, '^([0-9]{3})([0-9]{4})$', '$1-$2', '100-0001', true),
+    ('CN', NULL, '^[0-9]{6}$', NULL, '100000', true),
+    ('IN', NULL, '^[1-9][0-9]{5}$', NULL, '110001', true),
+    ('BR', NULL, '^([0-9]{5})([0-9]{3})$', '$1-$2', '70150-900', true),
+    ('AU', NULL, '^[0-9]{4}$', NULL, '2600', true),
+    -- Subdivisions identified by the leading digits of the code
+    ('US', 'US-CA', '^9[0-6][0-9]{3}([0-9]{4})?$', NULL, NULL, false),
+    ('US', 'US-NY', '^(1[0-4][0-9]|005)[0-9]{2}([0-9]{4})?$', NULL, NULL, false),
+    ('US', 'US-TX', '^(7[5-9][0-9]|885)[0-9]{2}([0-9]{4})?$', NULL, NULL, false),
+    ('ES', 'ES-CT', '^(08|17|25|43)[0-9]{3}$', NULL, NULL, false),
+    ('ES', 'ES-B', '^08[0-9]{3}$', NULL, NULL, false),
+    ('ES', 'ES-GI', '^17[0-9]{3}$', NULL, NULL, false),
+    ('ES', 'ES-L', '^25[0-9]{3}$', NULL, NULL, false),
+    ('ES', 'ES-T', '^43[0-9]{3}$', NULL, NULL, false),
+    ('ES', 'ES-MD', '^28[0-9]{3}$', NULL, NULL, false),
+    ('ES', 'ES-M', '^28[0-9]{3}$', NULL, NULL, false),
+    ('FR', 'FR-IDF', '^(75|77|78|91|92|93|94|95)[0-9]{3}$', NULL, NULL, false),
+    ('FR', 'FR-75C', '^75[0-9]{3}$', NULL, NULL, false),
+    ('FR', 'FR-92', '^92[0-9]{3}$', NULL, NULL, false),
+    ('FR', 'FR-93', '^93[0-9]{3}$', NULL, NULL, false),
+    ('FR', 'FR-94', '^94[0-9]{3}$', NULL, NULL, false),
+    ('CN', 'CN-BJ', '^10[0-2][0-9]{3}$', NULL, NULL, false),
+    ('IN', 'IN-DL', '^11[0-9]{4}$', NULL, NULL, false)
+) AS f (country_code, subdivision_code, pattern, format, example, is_required)
+JOIN countries c ON c.country_code = f.country_code AND c.tenant_id = 'default-tenant'
+LEFT JOIN country_subdivisions s ON s.subdivision_code = f.subdivision_code AND s.country_id = c.country_id
+WHERE (f.subdivision_code IS NULL OR s.subdivision_id IS NOT NULL)
+  AND NOT EXISTS (
+      SELECT 1 FROM postal_code_formats pf
+      WHERE pf.country_id = c.country_id
+        AND pf.subdivision_id IS NOT DISTINCT FROM s.subdivision_id
+        AND pf.pattern = f.pattern AND pf.is_deleted = false
+  );
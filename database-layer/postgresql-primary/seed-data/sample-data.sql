@@ -21,7 +21,8 @@ INSERT INTO countries (
 (gen_random_uuid(), 'IN', 'India', 'IND', 356, 'Republic of India', 'New Delhi', 'AS', '+91', true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
 (gen_random_uuid(), 'BR', 'Brazil', 'BRA', 76, 'Federative Republic of Brazil', 'Brasília', 'SA', '+55', true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
 (gen_random_uuid(), 'AU', 'Australia', 'AUS', 36, 'Commonwealth of Australia', 'Canberra', 'OC', '+61', true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
-(gen_random_uuid(), 'CA', 'Canada', 'CAN', 124, 'Canada', 'Ottawa', 'NA', '+1', true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1);
+(gen_random_uuid(), 'CA', 'Canada', 'CAN', 124, 'Canada', 'Ottawa', 'NA', '+1', true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES', 'Spain', 'ESP', 724, 'Kingdom of Spain', 'Madrid', 'EU', '+34', true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1);
 
 -- Sample Regions
 INSERT INTO regions (
@@ -86,7 +87,24 @@ INSERT INTO country_subdivisions (
 (gen_random_uuid(), 'FR-IDF', 'Île-de-France', (SELECT country_id FROM countries WHERE country_code = 'FR'), 'REGION', NULL, true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
 (gen_random_uuid(), 'JP-13', 'Tokyo', (SELECT country_id FROM countries WHERE country_code = 'JP'), 'PREFECTURE', NULL, true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
 (gen_random_uuid(), 'CN-BJ', 'Beijing', (SELECT country_id FROM countries WHERE country_code = 'CN'), 'MUNICIPALITY', NULL, true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
-(gen_random_uuid(), 'IN-DL', 'Delhi', (SELECT country_id FROM countries WHERE country_code = 'IN'), 'TERRITORY', NULL, true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1);
+(gen_random_uuid(), 'IN-DL', 'Delhi', (SELECT country_id FROM countries WHERE country_code = 'IN'), 'TERRITORY', NULL, true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES-MD', 'Comunidad de Madrid', (SELECT country_id FROM countries WHERE country_code = 'ES'), 'AUTONOMOUS_COMMUNITY', NULL, true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES-CT', 'Catalunya', (SELECT country_id FROM countries WHERE country_code = 'ES'), 'AUTONOMOUS_COMMUNITY', NULL, true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1);
+
+-- Second-level subdivisions: French departments and Spanish provinces
+INSERT INTO country_subdivisions (
+    subdivision_id, subdivision_code, subdivision_name, country_id, subdivision_type, parent_subdivision_id,
+    is_active, is_deleted, tenant_id, created_at, created_by, updated_at, updated_by, version
+) VALUES 
+(gen_random_uuid(), 'FR-75C', 'Paris', (SELECT country_id FROM countries WHERE country_code = 'FR'), 'DEPARTMENT', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'FR-IDF'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'FR-92', 'Hauts-de-Seine', (SELECT country_id FROM countries WHERE country_code = 'FR'), 'DEPARTMENT', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'FR-IDF'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'FR-93', 'Seine-Saint-Denis', (SELECT country_id FROM countries WHERE country_code = 'FR'), 'DEPARTMENT', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'FR-IDF'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'FR-94', 'Val-de-Marne', (SELECT country_id FROM countries WHERE country_code = 'FR'), 'DEPARTMENT', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'FR-IDF'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES-M', 'Madrid', (SELECT country_id FROM countries WHERE country_code = 'ES'), 'PROVINCE', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'ES-MD'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES-B', 'Barcelona', (SELECT country_id FROM countries WHERE country_code = 'ES'), 'PROVINCE', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'ES-CT'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES-GI', 'Girona', (SELECT country_id FROM countries WHERE country_code = 'ES'), 'PROVINCE', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'ES-CT'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES-L', 'Lleida', (SELECT country_id FROM countries WHERE country_code = 'ES'), 'PROVINCE', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'ES-CT'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1),
+(gen_random_uuid(), 'ES-T', 'Tarragona', (SELECT country_id FROM countries WHERE country_code = 'ES'), 'PROVINCE', (SELECT subdivision_id FROM country_subdivisions WHERE subdivision_code = 'ES-CT'), true, false, 'default-tenant', NOW(), 'system', NOW(), 'system', 1);
 
 -- Sample Locales
 INSERT INTO locales (
@@ -0,0 +1,61 @@
+-- ============================================================================
+-- TIME ZONE SEEDING
+-- PURPOSE: IANA zones of the sample countries and their subdivisions
+-- SCHEMA: domain_reference_master_geopolitical
+-- DEPENDENCIES: sample-data.sql, migrations/012_iana_timezones.sql
+-- ============================================================================
+
+SET search_path TO domain_reference_master_geopolitical, public;
+
+INSERT INTO timezones (
+    timezone_id, timezone_code, timezone_name, utc_offset_hours, utc_offset_minutes,
+    supports_dst, dst_offset_hours, is_active, is_deleted, tenant_id, created_at, updated_at, version
+)
+SELECT gen_random_uuid(), z.code, z.name, z.hours, z.minutes, z.dst, CASE WHEN z.dst THEN 1 END,
+       true, false, 'default-tenant', NOW(), NOW(), 1
+FROM (VALUES
+    ('America/Chicago', 'Central Time (US)', -6, 0, true),
+    ('America/Denver', 'Mountain Time (US)', -7, 0, true),
+    ('America/Toronto', 'Eastern Time (Canada)', -5, 0, true),
+    ('America/Vancouver', 'Pacific Time (Canada)', -8, 0, true),
+    ('Europe/Paris', 'Central European Time (France)', 1, 0, true),
+    ('Europe/Madrid', 'Central European Time (Spain)', 1, 0, true),
+    ('Atlantic/Canary', 'Western European Time (Canary Islands)', 0, 0, true),
+    ('Australia/Perth', 'Australian Western Time', 8, 0, false)
+) AS z (code, name, hours, minutes, dst)
+WHERE NOT EXISTS (SELECT 1 FROM timezones WHERE timezone_code = z.code);
+
+INSERT INTO country_timezones (country_id, subdivision_id, timezone_id, tenant_id, change_reason)
+SELECT c.country_id, s.subdivision_id, tz.timezone_id, 'default-tenant', 'Seed: IANA zones'
+FROM (VALUES
+    ('US', NULL, 'America/New_York'),
+    ('US', NULL, 'America/Chicago'),
+    ('US', NULL, 'America/Denver'),
+    ('US', NULL, 'America/Los_Angeles'),
+    ('US', 'US-CA', 'America/Los_Angeles'),
+    ('US', 'US-NY', 'America/New_York'),
+    ('US', 'US-TX', 'America/Chicago'),
+    ('US', 'US-TX', 'America/Denver'),
+    ('CA', NULL, 'America/Toronto'),
+    ('CA', NULL, 'America/Vancouver'),
+    ('GB', NULL, 'Europe/London'),
+    ('DE', NULL, 'Europe/Berlin'),
+    ('FR', NULL, 'Europe/Paris'),
+    ('ES', NULL, 'Europe/Madrid'),
+    ('ES', NULL, 'Atlantic/Canary'),
+    ('JP', NULL, 'Asia/Tokyo'),
+    ('CN', NULL, 'Asia/Shanghai'),
+    ('IN', NULL, 'Asia/Kolkata'),
+    ('BR', NULL, 'America/Sao_Paulo'),
+    ('AU', NULL, 'Australia/Sydney'),
+    ('AU', NULL, 'Australia/Perth')
+) AS l (country_code, subdivision_code, timezone_code)
+JOIN countries c ON c.country_code = l.country_code AND c.tenant_id = 'default-tenant'
+JOIN timezones tz ON tz.timezone_code = l.timezone_code AND tz.tenant_id = 'default-tenant'
+LEFT JOIN country_subdivisions s ON s.subdivision_code = l.subdivision_code AND s.country_id = c.country_id
+WHERE (l.subdivision_code IS NULL OR s.subdivision_id IS NOT NULL)
+  AND NOT EXISTS (
+      SELECT 1 FROM country_timezones ct
+      WHERE ct.country_id = c.country_id AND ct.timezone_id = tz.timezone_id
+        AND ct.subdivision_id IS NOT DISTINCT FROM s.subdivision_id AND ct.is_deleted = false
+  );
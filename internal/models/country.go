@@ -28,6 +28,10 @@ type Country struct {
 	IsActive          bool       `json:"is_active" gorm:"default:true;not null"`
 	IsDeleted         bool       `json:"is_deleted" gorm:"default:false;not null"`
 	
+	// Valid-time period [valid_from, valid_to); NULL bounds are open
+	ValidFrom         *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo           *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
+	
 	// LASANI Audit Fields (27 fields)
 	TenantID          string     `json:"tenant_id" gorm:"type:varchar(100);default:'default-tenant';index"`
 	CreatedAt         *time.Time `json:"created_at,omitempty" gorm:"type:timestamptz;default:now()"`
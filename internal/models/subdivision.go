@@ -14,6 +14,8 @@ type CountrySubdivision struct {
 	ParentSubdivisionID *uuid.UUID `json:"parent_subdivision_id,omitempty" gorm:"type:uuid"`
 	IsActive            bool      `json:"is_active" gorm:"default:true;not null"`
 	IsDeleted           bool      `json:"is_deleted" gorm:"default:false;not null"`
+	ValidFrom           *time.Time `json:"valid_from,omitempty" gorm:"type:date"`
+	ValidTo             *time.Time `json:"valid_to,omitempty" gorm:"type:date"`
 	TenantID            string    `json:"tenant_id" gorm:"type:varchar(100);not null;index"`
 	CreatedAt           time.Time `json:"created_at" gorm:"type:timestamptz;default:now()"`
 	CreatedBy           *uuid.UUID `json:"created_by,omitempty" gorm:"type:uuid"`
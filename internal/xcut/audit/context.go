@@ -0,0 +1,31 @@
+// Package audit carries who changed reference data, and why, from the
+// request down to the data layer
+package audit
+
+import (
+	"context"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+)
+
+type contextKey string
+
+const changeReasonKey contextKey = "change_reason"
+
+// WithChangeReason attaches the reason given for a change to ctx
+func WithChangeReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, changeReasonKey, reason)
+}
+
+// ChangeReason returns the reason attached to ctx, or ""
+func ChangeReason(ctx context.Context) string {
+	if reason, ok := ctx.Value(changeReasonKey).(string); ok {
+		return reason
+	}
+	return ""
+}
+
+// Actor returns the authenticated user making the change, or ""
+func Actor(ctx context.Context) string {
+	return logging.GetUserID(ctx)
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+const (
+	requestKey contextKey = "request"
+	sessionKey contextKey = "session_id"
+)
+
+// Device describes the client a request came from, parsed from its User-Agent
+type Device struct {
+	UserAgent string `json:"user_agent"`
+	Browser   string `json:"browser,omitempty"`
+	OS        string `json:"os,omitempty"`
+	Type      string `json:"type"`
+}
+
+// Location is where a request came from, as reported by the edge proxy
+type Location struct {
+	CountryCode string `json:"country_code,omitempty"`
+	Region      string `json:"region,omitempty"`
+	City        string `json:"city,omitempty"`
+}
+
+// RequestContext is who made a request and from where. It feeds the
+// created_*, updated_* and deleted_* audit columns.
+type RequestContext struct {
+	UserID    string
+	IP        net.IP
+	SessionID string
+	Device    *Device
+	Location  *Location
+}
+
+// WithRequest attaches the captured request to ctx
+func WithRequest(ctx context.Context, request RequestContext) context.Context {
+	return context.WithValue(ctx, requestKey, request)
+}
+
+// WithSessionID attaches the session of an authenticated user to ctx. It takes
+// precedence over the session captured from the request headers.
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionKey, sessionID)
+}
+
+// Request returns the request attached to ctx with the authenticated user and
+// session filled in. All fields are empty outside of a request.
+func Request(ctx context.Context) RequestContext {
+	request, _ := ctx.Value(requestKey).(RequestContext)
+	if actor := Actor(ctx); actor != "" {
+		request.UserID = actor
+	}
+	if sessionID, ok := ctx.Value(sessionKey).(string); ok && sessionID != "" {
+		request.SessionID = sessionID
+	}
+	return request
+}
+
+// ParseDevice classifies a User-Agent. Unknown agents keep only the raw string.
+func ParseDevice(userAgent string) *Device {
+	if userAgent == "" {
+		return nil
+	}
+	ua := strings.ToLower(userAgent)
+	device := &Device{UserAgent: userAgent, Type: "desktop"}
+
+	switch {
+	case strings.Contains(ua, "bot"), strings.Contains(ua, "spider"), strings.Contains(ua, "crawl"):
+		device.Type = "bot"
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"):
+		device.Type = "tablet"
+	case strings.Contains(ua, "mobi"), strings.Contains(ua, "iphone"), strings.Contains(ua, "android"):
+		device.Type = "mobile"
+	case strings.HasPrefix(ua, "curl/"), strings.HasPrefix(ua, "go-http-client"),
+		strings.HasPrefix(ua, "postmanruntime"), strings.HasPrefix(ua, "python-"):
+		device.Type = "api-client"
+	}
+
+	// Order matters: Chrome agents also mention Safari, Edge agents mention Chrome
+	for _, browser := range []struct{ token, name string }{
+		{"edg/", "Edge"},
+		{"opr/", "Opera"},
+		{"firefox/", "Firefox"},
+		{"chrome/", "Chrome"},
+		{"safari/", "Safari"},
+	} {
+		if strings.Contains(ua, browser.token) {
+			device.Browser = browser.name
+			break
+		}
+	}
+
+	for _, os := range []struct{ token, name string }{
+		{"windows", "Windows"},
+		{"iphone", "iOS"},
+		{"ipad", "iOS"},
+		{"android", "Android"},
+		{"mac os x", "macOS"},
+		{"linux", "Linux"},
+	} {
+		if strings.Contains(ua, os.token) {
+			device.OS = os.name
+			break
+		}
+	}
+	return device
+}
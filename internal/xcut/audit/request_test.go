@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+)
+
+func TestParseDevice(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      *Device
+	}{
+		{"empty", "", nil},
+		{
+			"chrome on windows",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			&Device{Browser: "Chrome", OS: "Windows", Type: "desktop"},
+		},
+		{
+			"edge mentions chrome",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
+			&Device{Browser: "Edge", OS: "Windows", Type: "desktop"},
+		},
+		{
+			"safari on iphone",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			&Device{Browser: "Safari", OS: "iOS", Type: "mobile"},
+		},
+		{
+			"ipad is a tablet",
+			"Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			&Device{Browser: "Safari", OS: "iOS", Type: "tablet"},
+		},
+		{
+			"firefox on android",
+			"Mozilla/5.0 (Android 14; Mobile; rv:121.0) Gecko/121.0 Firefox/121.0",
+			&Device{Browser: "Firefox", OS: "Android", Type: "mobile"},
+		},
+		{
+			"crawler",
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			&Device{Type: "bot"},
+		},
+		{"curl", "curl/8.4.0", &Device{Type: "api-client"}},
+		{"unknown agent", "geo-sync", &Device{Type: "desktop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want != nil {
+				tt.want.UserAgent = tt.userAgent
+			}
+			if got := ParseDevice(tt.userAgent); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDevice() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequest(t *testing.T) {
+	captured := RequestContext{UserID: "anonymous", SessionID: "header-session"}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want RequestContext
+	}{
+		{"outside a request", context.Background(), RequestContext{}},
+		{"captured request", WithRequest(context.Background(), captured), captured},
+		{
+			"authenticated user and session win",
+			WithSessionID(logging.WithUserID(WithRequest(context.Background(), captured), "u-1"), "token-session"),
+			RequestContext{UserID: "u-1", SessionID: "token-session"},
+		},
+		{
+			"empty token session keeps the captured one",
+			WithSessionID(WithRequest(context.Background(), captured), ""),
+			captured,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Request(tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Request() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
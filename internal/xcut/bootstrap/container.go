@@ -27,6 +27,7 @@ type Container struct {
 	CountryAppService *applicationservices.CountryAppService
 	RegionRepository  *repositories.RegionRepository
 	LanguageRepository *repositories.LanguageRepository
+	LocalizationAppService *applicationservices.LocalizationAppService
 }
 
 // NewContainer creates and initializes the application container
@@ -70,6 +71,8 @@ func NewContainer(cfg *config.Config, logger logging.Logger) (*Container, error)
 
 	// Initialize application services
 	countryAppService := applicationservices.NewCountryAppService(countryRepo, logger, tracer)
+	localizationAppService := applicationservices.NewLocalizationAppService(
+		repositories.NewNameTranslationRepository(dbManager.DB), repositories.NewLocaleRepository(dbManager.DB), tracer)
 
 	return &Container{
 		Config:            cfg,
@@ -82,6 +85,7 @@ func NewContainer(cfg *config.Config, logger logging.Logger) (*Container, error)
 		CountryAppService: countryAppService,
 		RegionRepository:  regionRepo,
 		LanguageRepository: languageRepo,
+		LocalizationAppService: localizationAppService,
 	}, nil
 }
 
@@ -3,6 +3,8 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 )
 
 type LayerError struct {
@@ -70,6 +72,41 @@ func NewValidationError(field, message string) *LayerError {
 	}
 }
 
+// NewValidationErrors reports several failed fields at once, in field order
+func NewValidationErrors(failures map[string]string) *LayerError {
+	fields := make([]string, 0, len(failures))
+	for field := range failures {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = fmt.Sprintf("Field '%s': %s", field, failures[field])
+	}
+	return &LayerError{
+		Layer:   "validation",
+		Code:    "VALIDATION_FAILED",
+		Message: strings.Join(messages, "; "),
+	}
+}
+
+// Optimistic concurrency codes. GEO-1009 is the catalogued version conflict,
+// ErrCountryVersionConflict of the data access layer.
+const (
+	CodeVersionConflict      = "GEO-1009"
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"
+)
+
+// NewVersionConflictError reports a write against a stale version
+func NewVersionConflictError(entity string, expected, actual int) *LayerError {
+	return &LayerError{
+		Layer:   "repository",
+		Code:    CodeVersionConflict,
+		Message: fmt.Sprintf("Version conflict: %s is at version %d, not %d", entity, actual, expected),
+	}
+}
+
 // Schema Errors
 func NewSchemaError(message string, cause error) *LayerError {
 	return &LayerError{
@@ -101,6 +138,10 @@ func (e *LayerError) HTTPStatus() int {
 		return http.StatusUnauthorized
 	case "FORBIDDEN":
 		return http.StatusForbidden
+	case CodeVersionConflict:
+		return http.StatusPreconditionFailed
+	case CodePreconditionRequired:
+		return http.StatusPreconditionRequired
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *LayerError
+		want int
+	}{
+		{"validation", NewValidationError("limit", "must be a positive integer"), http.StatusBadRequest},
+		{"version conflict", NewVersionConflictError("country", 2, 3), http.StatusPreconditionFailed},
+		{"precondition required", &LayerError{Code: CodePreconditionRequired}, http.StatusPreconditionRequired},
+		{"not found", &LayerError{Code: "NOT_FOUND"}, http.StatusNotFound},
+		{"duplicate", &LayerError{Code: "DUPLICATE_KEY"}, http.StatusConflict},
+		{"repository failure", NewRepositoryError("QUERY_FAILED", "Failed to retrieve regions", nil), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.HTTPStatus(); got != tt.want {
+				t.Errorf("HTTPStatus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVersionConflictError(t *testing.T) {
+	err := NewVersionConflictError("country", 2, 3)
+	if err.Code != "GEO-1009" {
+		t.Errorf("Code = %s, want GEO-1009", err.Code)
+	}
+	if want := "Version conflict: country is at version 3, not 2"; err.Message != want {
+		t.Errorf("Message = %q, want %q", err.Message, want)
+	}
+}
@@ -0,0 +1,121 @@
+package geo
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sync"
+)
+
+// The bundled boundaries are simplified outlines of the sample countries and
+// their first-level subdivisions, as GeoJSON FeatureCollections whose
+// features carry code, name and, for subdivisions, country properties.
+//
+//go:embed boundaries
+var bundledBoundaries embed.FS
+
+// Files read by LoadIndex
+const (
+	CountriesFile    = "countries.geojson"
+	SubdivisionsFile = "subdivisions.geojson"
+)
+
+var (
+	defaultIndex     *Index
+	defaultIndexErr  error
+	defaultIndexOnce sync.Once
+)
+
+// DefaultIndex returns the index of the boundaries bundled with the binary
+func DefaultIndex() (*Index, error) {
+	defaultIndexOnce.Do(func() {
+		sub, err := fs.Sub(bundledBoundaries, "boundaries")
+		if err != nil {
+			defaultIndexErr = err
+			return
+		}
+		defaultIndex, defaultIndexErr = LoadIndex(sub)
+	})
+	return defaultIndex, defaultIndexErr
+}
+
+// LoadIndex reads the country and subdivision boundaries of fsys and
+// indexes them
+func LoadIndex(fsys fs.FS) (*Index, error) {
+	countries, err := readBoundaries(fsys, CountriesFile, false)
+	if err != nil {
+		return nil, err
+	}
+	subdivisions, err := readBoundaries(fsys, SubdivisionsFile, true)
+	if err != nil {
+		return nil, err
+	}
+	return NewIndex(countries, subdivisions), nil
+}
+
+type featureCollection struct {
+	Type     string `json:"type"`
+	Features []struct {
+		Properties struct {
+			Code    string `json:"code"`
+			Name    string `json:"name"`
+			Country string `json:"country"`
+		} `json:"properties"`
+		Geometry struct {
+			Type        string          `json:"type"`
+			Coordinates json.RawMessage `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"features"`
+}
+
+func readBoundaries(fsys fs.FS, name string, subdivisions bool) ([]*Boundary, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("geo: %w", err)
+	}
+	var fc featureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, fmt.Errorf("geo: %s: %w", name, err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("geo: %s is not a FeatureCollection", name)
+	}
+
+	boundaries := make([]*Boundary, 0, len(fc.Features))
+	seen := make(map[string]bool, len(fc.Features))
+	for i, f := range fc.Features {
+		b := &Boundary{Code: f.Properties.Code, Name: f.Properties.Name, Country: f.Properties.Country}
+		if b.Code == "" || seen[b.Code] {
+			return nil, fmt.Errorf("geo: %s: feature %d has a missing or repeated code", name, i)
+		}
+		if subdivisions && b.Country == "" {
+			return nil, fmt.Errorf("geo: %s: subdivision %s has no country", name, b.Code)
+		}
+		seen[b.Code] = true
+
+		switch f.Geometry.Type {
+		case "Polygon":
+			var polygon Polygon
+			err = json.Unmarshal(f.Geometry.Coordinates, &polygon)
+			b.Polygons = []Polygon{polygon}
+		case "MultiPolygon":
+			err = json.Unmarshal(f.Geometry.Coordinates, &b.Polygons)
+		default:
+			err = fmt.Errorf("unsupported geometry type %q", f.Geometry.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geo: %s: %s: %w", name, b.Code, err)
+		}
+		for _, polygon := range b.Polygons {
+			for _, ring := range polygon {
+				if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+					return nil, fmt.Errorf("geo: %s: %s has a ring that is not closed", name, b.Code)
+				}
+			}
+		}
+		b.measure()
+		boundaries = append(boundaries, b)
+	}
+	return boundaries, nil
+}
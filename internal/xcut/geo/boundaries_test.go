@@ -0,0 +1,86 @@
+package geo
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+const validSubdivisions = `{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"code":"AA-1","name":"One","country":"AA"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}]}`
+
+func TestLoadIndex(t *testing.T) {
+	tests := []struct {
+		name      string
+		countries string
+		wantErr   bool
+	}{
+		{
+			name:      "polygon and multipolygon",
+			countries: `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"code":"AA","name":"Aa"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}},{"type":"Feature","properties":{"code":"BB","name":"Bb"},"geometry":{"type":"MultiPolygon","coordinates":[[[[3,0],[4,0],[4,1],[3,0]]]]}}]}`,
+		},
+		{name: "not a feature collection", countries: `{"type":"Feature"}`, wantErr: true},
+		{name: "malformed json", countries: `{"type":`, wantErr: true},
+		{
+			name:      "missing code",
+			countries: `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"name":"Aa"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,0]]]}}]}`,
+			wantErr:   true,
+		},
+		{
+			name:      "repeated code",
+			countries: `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"code":"AA"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,0]]]}},{"type":"Feature","properties":{"code":"AA"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,0]]]}}]}`,
+			wantErr:   true,
+		},
+		{
+			name:      "ring not closed",
+			countries: `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"code":"AA"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2]]]}}]}`,
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported geometry",
+			countries: `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"code":"AA"},"geometry":{"type":"Point","coordinates":[0,0]}}]}`,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				CountriesFile:    {Data: []byte(tt.countries)},
+				SubdivisionsFile: {Data: []byte(validSubdivisions)},
+			}
+			ix, err := LoadIndex(fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadIndex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (ix.Boundary("AA") == nil || ix.Boundary("AA-1") == nil) {
+				t.Error("LoadIndex() did not index AA and AA-1")
+			}
+		})
+	}
+}
+
+func TestLoadIndexSubdivisions(t *testing.T) {
+	countries := `{"type":"FeatureCollection","features":[]}`
+
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"missing file", fstest.MapFS{CountriesFile: {Data: []byte(countries)}}},
+		{
+			"subdivision without country",
+			fstest.MapFS{
+				CountriesFile:    {Data: []byte(countries)},
+				SubdivisionsFile: {Data: []byte(`{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"code":"AA-1"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}}]}`)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadIndex(tt.files); err == nil {
+				t.Error("LoadIndex() succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Package geo answers point-in-boundary questions offline, from simplified
+// country and subdivision polygons bundled with the binary.
+//
+// Coordinates are WGS84 degrees and computations are planar in longitude and
+// latitude, which is accurate enough for simplified boundaries. Polygons must
+// not cross the antimeridian; split them into a MultiPolygon instead.
+package geo
+
+import "math"
+
+// Point is a WGS84 position
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Valid reports whether p lies within the latitude and longitude ranges
+func (p Point) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180 &&
+		!math.IsNaN(p.Latitude) && !math.IsNaN(p.Longitude)
+}
+
+// BBox is a bounding box in degrees
+type BBox struct {
+	South float64 `json:"south"`
+	West  float64 `json:"west"`
+	North float64 `json:"north"`
+	East  float64 `json:"east"`
+}
+
+// Contains reports whether p lies in b, edges included
+func (b BBox) Contains(p Point) bool {
+	return p.Latitude >= b.South && p.Latitude <= b.North && p.Longitude >= b.West && p.Longitude <= b.East
+}
+
+// Ring is a closed linear ring of [longitude, latitude] positions, in GeoJSON
+// order
+type Ring [][2]float64
+
+// Polygon is an exterior ring followed by its holes
+type Polygon []Ring
+
+// Boundary is the simplified outline of a country or subdivision. Country is
+// the ISO 3166-1 code of the country of a subdivision and empty for a
+// country.
+type Boundary struct {
+	Code     string
+	Name     string
+	Country  string
+	Polygons []Polygon
+	BBox     BBox
+	Centroid Point
+	// Area in square degrees, used to prefer the smaller of overlapping
+	// boundaries
+	Area float64
+}
+
+// Contains reports whether p lies inside one of the polygons of b, outside
+// their holes
+func (b *Boundary) Contains(p Point) bool {
+	if !b.BBox.Contains(p) {
+		return false
+	}
+	for _, polygon := range b.Polygons {
+		if len(polygon) == 0 || !polygon[0].contains(p) {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if hole.contains(p) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// contains tests p against the ring by ray casting
+func (r Ring) contains(p Point) bool {
+	inside := false
+	x, y := p.Longitude, p.Latitude
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		xi, yi := r[i][0], r[i][1]
+		xj, yj := r[j][0], r[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// signedArea returns the shoelace area of the ring and the moments used for
+// its centroid
+func (r Ring) signedArea() (area, cx, cy float64) {
+	for i := 0; i+1 < len(r); i++ {
+		x0, y0 := r[i][0], r[i][1]
+		x1, y1 := r[i+1][0], r[i+1][1]
+		cross := x0*y1 - x1*y0
+		area += cross
+		cx += (x0 + x1) * cross
+		cy += (y0 + y1) * cross
+	}
+	return area / 2, cx / 6, cy / 6
+}
+
+// measure computes the bounding box, area and area-weighted centroid of b.
+// Holes count negatively whatever the winding of their rings.
+func (b *Boundary) measure() {
+	b.BBox = BBox{South: 90, West: 180, North: -90, East: -180}
+	var total, mx, my float64
+	for _, polygon := range b.Polygons {
+		for i, ring := range polygon {
+			for _, position := range ring {
+				b.BBox.West = math.Min(b.BBox.West, position[0])
+				b.BBox.East = math.Max(b.BBox.East, position[0])
+				b.BBox.South = math.Min(b.BBox.South, position[1])
+				b.BBox.North = math.Max(b.BBox.North, position[1])
+			}
+			area, cx, cy := ring.signedArea()
+			sign := 1.0
+			if (area < 0) != (i > 0) {
+				sign = -1
+			}
+			total += sign * area
+			mx += sign * cx
+			my += sign * cy
+		}
+	}
+	b.Area = total
+	if total != 0 {
+		b.Centroid = Point{Latitude: round5(my / total), Longitude: round5(mx / total)}
+	}
+}
+
+func round5(v float64) float64 {
+	return math.Round(v*1e5) / 1e5
+}
+
+// Feature is a boundary as a GeoJSON Feature, with its bounding box as the
+// RFC 7946 bbox member
+type Feature struct {
+	Type       string                 `json:"type"`
+	BBox       [4]float64             `json:"bbox"`
+	Properties map[string]interface{} `json:"properties"`
+	Geometry   struct {
+		Type        string    `json:"type"`
+		Coordinates []Polygon `json:"coordinates"`
+	} `json:"geometry"`
+}
+
+// Feature returns b as a GeoJSON MultiPolygon feature
+func (b *Boundary) Feature() Feature {
+	f := Feature{
+		Type: "Feature",
+		BBox: [4]float64{b.BBox.West, b.BBox.South, b.BBox.East, b.BBox.North},
+		Properties: map[string]interface{}{
+			"code":     b.Code,
+			"name":     b.Name,
+			"centroid": b.Centroid,
+		},
+	}
+	if b.Country != "" {
+		f.Properties["country"] = b.Country
+	}
+	f.Geometry.Type = "MultiPolygon"
+	f.Geometry.Coordinates = b.Polygons
+	return f
+}
@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"testing"
+)
+
+// square returns a closed counterclockwise ring of the box from (west,
+// south) to (east, north)
+func square(west, south, east, north float64) Ring {
+	return Ring{{west, south}, {east, south}, {east, north}, {west, north}, {west, south}}
+}
+
+// boundary builds a measured boundary of the given polygons
+func boundary(code, country string, polygons ...Polygon) *Boundary {
+	b := &Boundary{Code: code, Name: code, Country: country, Polygons: polygons}
+	b.measure()
+	return b
+}
+
+func TestBoundaryContains(t *testing.T) {
+	// A 10° square with a hole, and a separate island to the east
+	b := boundary("AA", "",
+		Polygon{square(0, 0, 10, 10), square(6, 6, 8, 8)},
+		Polygon{square(20, 0, 22, 2)},
+	)
+
+	tests := []struct {
+		name  string
+		point Point
+		want  bool
+	}{
+		{"inside", Point{Latitude: 5, Longitude: 5}, true},
+		{"inside the island", Point{Latitude: 1, Longitude: 21}, true},
+		{"in the hole", Point{Latitude: 7, Longitude: 7}, false},
+		{"between the polygons", Point{Latitude: 1, Longitude: 15}, false},
+		{"outside the bounding box", Point{Latitude: 11, Longitude: 5}, false},
+		{"south of the equator", Point{Latitude: -1, Longitude: 5}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundaryMeasure(t *testing.T) {
+	clockwise := Ring{{0, 0}, {0, 4}, {4, 4}, {4, 0}, {0, 0}}
+
+	tests := []struct {
+		name     string
+		polygons []Polygon
+		bbox     BBox
+		area     float64
+		centroid Point
+	}{
+		{
+			name:     "square",
+			polygons: []Polygon{{square(0, 0, 4, 2)}},
+			bbox:     BBox{South: 0, West: 0, North: 2, East: 4},
+			area:     8,
+			centroid: Point{Latitude: 1, Longitude: 2},
+		},
+		{
+			name:     "clockwise exterior ring",
+			polygons: []Polygon{{clockwise}},
+			bbox:     BBox{South: 0, West: 0, North: 4, East: 4},
+			area:     16,
+			centroid: Point{Latitude: 2, Longitude: 2},
+		},
+		{
+			name:     "hole is subtracted",
+			polygons: []Polygon{{square(0, 0, 4, 4), square(0, 0, 2, 2)}},
+			bbox:     BBox{South: 0, West: 0, North: 4, East: 4},
+			area:     12,
+			centroid: Point{Latitude: 2.33333, Longitude: 2.33333},
+		},
+		{
+			name:     "two polygons",
+			polygons: []Polygon{{square(0, 0, 2, 2)}, {square(-10, -10, -8, -8)}},
+			bbox:     BBox{South: -10, West: -10, North: 2, East: 2},
+			area:     8,
+			centroid: Point{Latitude: -4, Longitude: -4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boundary("AA", "", tt.polygons...)
+			if b.BBox != tt.bbox || b.Area != tt.area || b.Centroid != tt.centroid {
+				t.Errorf("measure() = %+v, area %v, centroid %+v; want %+v, %v, %+v", b.BBox, b.Area, b.Centroid, tt.bbox, tt.area, tt.centroid)
+			}
+		})
+	}
+}
+
+func TestPointValid(t *testing.T) {
+	tests := []struct {
+		point Point
+		want  bool
+	}{
+		{Point{Latitude: 48.8566, Longitude: 2.3522}, true},
+		{Point{Latitude: -90, Longitude: 180}, true},
+		{Point{Latitude: 90.5, Longitude: 0}, false},
+		{Point{Latitude: 0, Longitude: -180.1}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.point.Valid(); got != tt.want {
+			t.Errorf("Valid(%v) = %v, want %v", tt.point, got, tt.want)
+		}
+	}
+}
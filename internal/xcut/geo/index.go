@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// gridCell is a 1° by 1° cell of the index grid, by floored latitude and
+// longitude
+type gridCell struct {
+	lat, lon int
+}
+
+// grid maps each cell to the boundaries whose bounding box overlaps it,
+// smallest first
+type grid map[gridCell][]*Boundary
+
+func newGrid(boundaries []*Boundary) grid {
+	g := grid{}
+	for _, b := range boundaries {
+		for lat := int(math.Floor(b.BBox.South)); lat <= int(math.Floor(b.BBox.North)); lat++ {
+			for lon := int(math.Floor(b.BBox.West)); lon <= int(math.Floor(b.BBox.East)); lon++ {
+				cell := gridCell{lat, lon}
+				g[cell] = append(g[cell], b)
+			}
+		}
+	}
+	for _, candidates := range g {
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Area < candidates[j].Area })
+	}
+	return g
+}
+
+// find returns the smallest boundary containing p that keep accepts
+func (g grid) find(p Point, keep func(*Boundary) bool) *Boundary {
+	for _, b := range g[gridCell{int(math.Floor(p.Latitude)), int(math.Floor(p.Longitude))}] {
+		if keep(b) && b.Contains(p) {
+			return b
+		}
+	}
+	return nil
+}
+
+// Index is an in-memory spatial index of country and first-level
+// subdivision boundaries. It is read-only and safe for concurrent use.
+type Index struct {
+	countries    grid
+	subdivisions grid
+	byCode       map[string]*Boundary
+}
+
+// NewIndex indexes country and subdivision boundaries
+func NewIndex(countries, subdivisions []*Boundary) *Index {
+	ix := &Index{
+		countries:    newGrid(countries),
+		subdivisions: newGrid(subdivisions),
+		byCode:       make(map[string]*Boundary, len(countries)+len(subdivisions)),
+	}
+	for _, b := range countries {
+		ix.byCode[b.Code] = b
+	}
+	for _, b := range subdivisions {
+		ix.byCode[b.Code] = b
+	}
+	return ix
+}
+
+// Lookup returns the country and subdivision containing p; either is nil
+// when none does. A point inside a subdivision but just outside the
+// simplified outline of its country reports that country.
+func (ix *Index) Lookup(p Point) (country, subdivision *Boundary) {
+	country = ix.countries.find(p, func(*Boundary) bool { return true })
+	if country != nil {
+		subdivision = ix.subdivisions.find(p, func(b *Boundary) bool { return b.Country == country.Code })
+		return country, subdivision
+	}
+	subdivision = ix.subdivisions.find(p, func(*Boundary) bool { return true })
+	if subdivision != nil {
+		country = ix.byCode[subdivision.Country]
+	}
+	return country, subdivision
+}
+
+// Boundary returns the boundary of an ISO 3166-1 or 3166-2 code, or nil
+func (ix *Index) Boundary(code string) *Boundary {
+	return ix.byCode[code]
+}
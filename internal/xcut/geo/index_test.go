@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"testing"
+)
+
+func TestIndexLookup(t *testing.T) {
+	countries := []*Boundary{
+		boundary("AA", "", Polygon{square(0, 0, 10, 10), square(6, 6, 8, 8)}),
+		// An enclave inside AA
+		boundary("BB", "", Polygon{square(2, 2, 4, 4)}),
+		boundary("CC", "", Polygon{square(10, 0, 20, 10)}),
+	}
+	subdivisions := []*Boundary{
+		// Reaches past the simplified eastern edge of AA into CC
+		boundary("AA-E", "AA", Polygon{square(8, 0, 10.5, 5)}),
+		boundary("AA-N", "AA", Polygon{square(0, 8.5, 10, 10)}),
+	}
+	ix := NewIndex(countries, subdivisions)
+
+	tests := []struct {
+		name        string
+		point       Point
+		country     string
+		subdivision string
+	}{
+		{"country without subdivision", Point{Latitude: 1, Longitude: 1}, "AA", ""},
+		{"country and subdivision", Point{Latitude: 9, Longitude: 1}, "AA", "AA-N"},
+		{"smallest of overlapping countries", Point{Latitude: 3, Longitude: 3}, "BB", ""},
+		{"hole of a country", Point{Latitude: 7, Longitude: 7}, "", ""},
+		{"subdivision of another country is ignored", Point{Latitude: 1, Longitude: 10.2}, "CC", ""},
+		{"subdivision beyond its country outline", Point{Latitude: 1, Longitude: 8.5}, "AA", "AA-E"},
+		{"nothing", Point{Latitude: -5, Longitude: -5}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			country, subdivision := ix.Lookup(tt.point)
+			if code(country) != tt.country || code(subdivision) != tt.subdivision {
+				t.Errorf("Lookup(%v) = %q, %q; want %q, %q", tt.point, code(country), code(subdivision), tt.country, tt.subdivision)
+			}
+		})
+	}
+}
+
+func TestDefaultIndexLookup(t *testing.T) {
+	ix, err := DefaultIndex()
+	if err != nil {
+		t.Fatalf("DefaultIndex() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		point       Point
+		country     string
+		subdivision string
+	}{
+		{"Washington", Point{Latitude: 38.8977, Longitude: -77.0365}, "US", ""},
+		{"Los Angeles", Point{Latitude: 34.0522, Longitude: -118.2437}, "US", "US-CA"},
+		{"New York", Point{Latitude: 40.7128, Longitude: -74.006}, "US", "US-NY"},
+		{"Ottawa", Point{Latitude: 45.4215, Longitude: -75.6972}, "CA", ""},
+		{"London", Point{Latitude: 51.5074, Longitude: -0.1278}, "GB", "GB-ENG"},
+		{"Edinburgh", Point{Latitude: 55.9533, Longitude: -3.1883}, "GB", "GB-SCT"},
+		{"Paris", Point{Latitude: 48.8566, Longitude: 2.3522}, "FR", "FR-IDF"},
+		{"Munich", Point{Latitude: 48.1351, Longitude: 11.582}, "DE", "DE-BY"},
+		{"Berlin", Point{Latitude: 52.52, Longitude: 13.405}, "DE", ""},
+		{"Madrid", Point{Latitude: 40.4168, Longitude: -3.7038}, "ES", "ES-MD"},
+		{"Barcelona", Point{Latitude: 41.3874, Longitude: 2.1686}, "ES", "ES-CT"},
+		{"Bern", Point{Latitude: 46.948, Longitude: 7.4474}, "CH", ""},
+		{"Tokyo", Point{Latitude: 35.6762, Longitude: 139.6503}, "JP", "JP-13"},
+		{"Beijing", Point{Latitude: 39.9042, Longitude: 116.4074}, "CN", "CN-BJ"},
+		{"New Delhi", Point{Latitude: 28.6139, Longitude: 77.209}, "IN", "IN-DL"},
+		{"Brasília", Point{Latitude: -15.7939, Longitude: -47.8828}, "BR", ""},
+		{"Sydney", Point{Latitude: -33.8688, Longitude: 151.2093}, "AU", ""},
+		{"Atlantic Ocean", Point{Latitude: 30, Longitude: -40}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			country, subdivision := ix.Lookup(tt.point)
+			if code(country) != tt.country || code(subdivision) != tt.subdivision {
+				t.Errorf("Lookup(%v) = %q, %q; want %q, %q", tt.point, code(country), code(subdivision), tt.country, tt.subdivision)
+			}
+		})
+	}
+}
+
+func code(b *Boundary) string {
+	if b == nil {
+		return ""
+	}
+	return b.Code
+}
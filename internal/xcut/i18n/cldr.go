@@ -0,0 +1,410 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The bundled CLDR data is a subset of the cldr-json packages (numbers,
+// ca-gregorian, currencies and the plurals, weekData and likelySubtags
+// supplements) in their upstream layout, so files can be refreshed by
+// copying them from a CLDR release.
+//
+//go:embed cldr
+var bundledCLDR embed.FS
+
+// Date and time styles of CLDR patterns
+var formatStyles = []string{"full", "long", "medium", "short"}
+
+// pluralOrder is the CLDR order of plural categories
+var pluralOrder = []string{"zero", "one", "two", "few", "many", "other"}
+
+// CLDR holds locale data loaded from CLDR JSON files
+type CLDR struct {
+	fsys       fs.FS
+	firstDay   map[string]string
+	likely     map[string]string
+	plurals    map[string][]pluralRule
+	mu         sync.Mutex
+	localeData map[string]*LocaleFormat
+}
+
+var (
+	defaultCLDR     *CLDR
+	defaultCLDRErr  error
+	defaultCLDROnce sync.Once
+)
+
+// DefaultCLDR returns the CLDR data bundled with the binary
+func DefaultCLDR() (*CLDR, error) {
+	defaultCLDROnce.Do(func() {
+		sub, err := fs.Sub(bundledCLDR, "cldr")
+		if err != nil {
+			defaultCLDRErr = err
+			return
+		}
+		defaultCLDR, defaultCLDRErr = LoadCLDR(sub)
+	})
+	return defaultCLDR, defaultCLDRErr
+}
+
+// LoadCLDR reads the supplemental data of a CLDR JSON tree with main/ and
+// supplemental/ directories. Locale data is read on first use.
+func LoadCLDR(fsys fs.FS) (*CLDR, error) {
+	c := &CLDR{fsys: fsys, localeData: make(map[string]*LocaleFormat)}
+
+	var week struct {
+		Supplemental struct {
+			WeekData struct {
+				FirstDay map[string]string `json:"firstDay"`
+			} `json:"weekData"`
+		} `json:"supplemental"`
+	}
+	if err := readJSON(fsys, "supplemental/weekData.json", &week); err != nil {
+		return nil, err
+	}
+	c.firstDay = week.Supplemental.WeekData.FirstDay
+
+	var likely struct {
+		Supplemental struct {
+			LikelySubtags map[string]string `json:"likelySubtags"`
+		} `json:"supplemental"`
+	}
+	if err := readJSON(fsys, "supplemental/likelySubtags.json", &likely); err != nil {
+		return nil, err
+	}
+	c.likely = likely.Supplemental.LikelySubtags
+
+	var plurals struct {
+		Supplemental struct {
+			Cardinal map[string]map[string]string `json:"plurals-type-cardinal"`
+		} `json:"supplemental"`
+	}
+	if err := readJSON(fsys, "supplemental/plurals.json", &plurals); err != nil {
+		return nil, err
+	}
+	c.plurals = make(map[string][]pluralRule, len(plurals.Supplemental.Cardinal))
+	for language, rules := range plurals.Supplemental.Cardinal {
+		parsed, err := parsePluralRules(rules)
+		if err != nil {
+			return nil, fmt.Errorf("plural rules of %s: %w", language, err)
+		}
+		c.plurals[language] = parsed
+	}
+	return c, nil
+}
+
+// Locale returns the formats of a BCP 47 locale such as de-CH. Data missing
+// for the exact locale is taken from its parent, de-CH from de.
+func (c *CLDR) Locale(tag string) (*LocaleFormat, error) {
+	tag, err := CanonicalTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if format, ok := c.localeData[tag]; ok {
+		return format, nil
+	}
+	format, err := c.load(tag)
+	if err != nil {
+		return nil, err
+	}
+	c.localeData[tag] = format
+	return format, nil
+}
+
+func (c *CLDR) load(tag string) (*LocaleFormat, error) {
+	chain := parentChain(tag)
+	format := &LocaleFormat{Locale: tag, currencySymbols: make(map[string]string)}
+
+	var numbers numbersFile
+	dataLocale, err := c.readMain(chain, "numbers.json", &numbers)
+	if err != nil {
+		return nil, err
+	}
+	format.DataLocale = dataLocale
+	data := numbers.Main[dataLocale].Numbers
+	system := data.DefaultNumberingSystem
+	if system == "" {
+		system = "latn"
+	}
+	symbols := data.symbols(system)
+	format.Decimal = symbols.Decimal
+	format.Group = symbols.Group
+	format.MinusSign = symbols.MinusSign
+	format.PercentSign = symbols.PercentSign
+	format.DecimalPattern = data.formats("decimalFormats", system)
+	format.PercentPattern = data.formats("percentFormats", system)
+	format.CurrencyPattern = data.formats("currencyFormats", system)
+
+	var calendar gregorianFile
+	calendarLocale, err := c.readMain(chain, "ca-gregorian.json", &calendar)
+	if err != nil {
+		return nil, err
+	}
+	gregorian := calendar.Main[calendarLocale].Dates.Calendars.Gregorian
+	format.DatePatterns = gregorian.DateFormats
+	format.TimePatterns = gregorian.TimeFormats
+	format.DateTimePatterns = gregorian.DateTimeFormats
+	format.months = [2][12]string{months(gregorian.Months.Format.Abbreviated), months(gregorian.Months.Format.Wide)}
+	format.days = [2][7]string{weekdays(gregorian.Days.Format.Abbreviated), weekdays(gregorian.Days.Format.Wide)}
+	format.dayPeriods = [2]string{gregorian.DayPeriods.Format.Abbreviated["am"], gregorian.DayPeriods.Format.Abbreviated["pm"]}
+	for _, style := range formatStyles {
+		if format.DatePatterns[style] == "" || format.TimePatterns[style] == "" || format.DateTimePatterns[style] == "" {
+			return nil, fmt.Errorf("CLDR %s calendar lacks %s patterns", calendarLocale, style)
+		}
+	}
+
+	// Currency symbols are looked up per currency along the whole chain
+	for i := len(chain) - 1; i >= 0; i-- {
+		var currencies currenciesFile
+		if err := readJSON(c.fsys, path.Join("main", chain[i], "currencies.json"), &currencies); err != nil {
+			if isNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for code, currency := range currencies.Main[chain[i]].Numbers.Currencies {
+			format.currencySymbols[code] = currency.Symbol
+		}
+	}
+
+	language := chain[len(chain)-1]
+	format.FirstDayOfWeek = c.firstDayOf(tag, language)
+	format.plurals = c.plurals[language]
+	for _, rule := range format.plurals {
+		format.PluralCategories = append(format.PluralCategories, rule.category)
+	}
+	format.PluralCategories = append(format.PluralCategories, "other")
+	sort.SliceStable(format.PluralCategories, func(i, j int) bool {
+		return pluralRank(format.PluralCategories[i]) < pluralRank(format.PluralCategories[j])
+	})
+	return format, nil
+}
+
+// readMain decodes the first main/<locale>/<file> found along chain and
+// returns the locale it was read from
+func (c *CLDR) readMain(chain []string, file string, v interface{}) (string, error) {
+	for _, locale := range chain {
+		err := readJSON(c.fsys, path.Join("main", locale, file), v)
+		if err == nil {
+			return locale, nil
+		}
+		if !isNotExist(err) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no CLDR data for locale %s", chain[0])
+}
+
+// firstDayOf returns the first day of the week in the region of tag, or in
+// the likely region of its language
+func (c *CLDR) firstDayOf(tag, language string) string {
+	region := regionOf(tag)
+	if region == "" {
+		region = regionOf(c.likely[language])
+	}
+	if day, ok := c.firstDay[region]; ok {
+		return day
+	}
+	return c.firstDay["001"]
+}
+
+// CanonicalTag normalizes a BCP 47 language tag: de_ch becomes de-CH and
+// zh-hans-cn becomes zh-Hans-CN
+func CanonicalTag(tag string) (string, error) {
+	parts := strings.Split(strings.ReplaceAll(tag, "_", "-"), "-")
+	if len(parts[0]) < 2 || len(parts[0]) > 3 || !isAlpha(parts[0]) {
+		return "", fmt.Errorf("invalid locale %q", tag)
+	}
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		part := parts[i]
+		switch {
+		case len(part) == 4 && isAlpha(part) && i == 1:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		case len(part) == 2 && isAlpha(part), len(part) == 3 && isDigits(part):
+			parts[i] = strings.ToUpper(part)
+		case len(part) >= 4 && len(part) <= 8:
+			parts[i] = strings.ToLower(part)
+		default:
+			return "", fmt.Errorf("invalid locale %q", tag)
+		}
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// parentChain returns tag and its truncation parents, de-Latn-CH, de-Latn,
+// de, also trying the tag without its script
+func parentChain(tag string) []string {
+	parts := strings.Split(tag, "-")
+	var chain []string
+	for n := len(parts); n > 0; n-- {
+		chain = append(chain, strings.Join(parts[:n], "-"))
+	}
+	if len(parts) > 2 && len(parts[1]) == 4 {
+		chain = append([]string{parts[0] + "-" + strings.Join(parts[2:], "-")}, chain...)
+	}
+	return chain
+}
+
+// regionOf returns the region subtag of a canonical tag
+func regionOf(tag string) string {
+	for _, part := range strings.Split(tag, "-")[1:] {
+		if len(part) == 2 || (len(part) == 3 && isDigits(part)) {
+			return part
+		}
+	}
+	return ""
+}
+
+func pluralRank(category string) int {
+	for i, c := range pluralOrder {
+		if c == category {
+			return i
+		}
+	}
+	return len(pluralOrder)
+}
+
+// months returns the values of a CLDR map keyed "1".."12"
+func months(values map[string]string) [12]string {
+	var out [12]string
+	for i := range out {
+		out[i] = values[fmt.Sprint(i+1)]
+	}
+	return out
+}
+
+// weekdays returns the values of a CLDR map keyed sun..sat in time.Weekday
+// order
+func weekdays(values map[string]string) [7]string {
+	var out [7]string
+	for i, key := range []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"} {
+		out[i] = values[key]
+	}
+	return out
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func readJSON(fsys fs.FS, name string, v interface{}) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("CLDR %s: %w", name, err)
+	}
+	return nil
+}
+
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// numbersFile is main/<locale>/numbers.json
+type numbersFile struct {
+	Main map[string]struct {
+		Numbers numbersData `json:"numbers"`
+	} `json:"main"`
+}
+
+// numbersData keeps the numbering-system specific entries raw, their keys
+// embed the system name, e.g. symbols-numberSystem-latn
+type numbersData struct {
+	DefaultNumberingSystem string
+	raw                    map[string]json.RawMessage
+}
+
+func (n *numbersData) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &n.raw); err != nil {
+		return err
+	}
+	if system, ok := n.raw["defaultNumberingSystem"]; ok {
+		return json.Unmarshal(system, &n.DefaultNumberingSystem)
+	}
+	return nil
+}
+
+type numberSymbols struct {
+	Decimal     string `json:"decimal"`
+	Group       string `json:"group"`
+	PercentSign string `json:"percentSign"`
+	MinusSign   string `json:"minusSign"`
+}
+
+func (n numbersData) symbols(system string) numberSymbols {
+	symbols := numberSymbols{Decimal: ".", Group: ",", PercentSign: "%", MinusSign: "-"}
+	_ = json.Unmarshal(n.raw["symbols-numberSystem-"+system], &symbols)
+	return symbols
+}
+
+func (n numbersData) formats(kind, system string) string {
+	var formats struct {
+		Standard string `json:"standard"`
+	}
+	_ = json.Unmarshal(n.raw[kind+"-numberSystem-"+system], &formats)
+	return formats.Standard
+}
+
+// gregorianFile is main/<locale>/ca-gregorian.json
+type gregorianFile struct {
+	Main map[string]struct {
+		Dates struct {
+			Calendars struct {
+				Gregorian struct {
+					Months          contextNames      `json:"months"`
+					Days            contextNames      `json:"days"`
+					DayPeriods      contextNames      `json:"dayPeriods"`
+					DateFormats     map[string]string `json:"dateFormats"`
+					TimeFormats     map[string]string `json:"timeFormats"`
+					DateTimeFormats map[string]string `json:"dateTimeFormats"`
+				} `json:"gregorian"`
+			} `json:"calendars"`
+		} `json:"dates"`
+	} `json:"main"`
+}
+
+type contextNames struct {
+	Format struct {
+		Abbreviated map[string]string `json:"abbreviated"`
+		Wide        map[string]string `json:"wide"`
+	} `json:"format"`
+}
+
+// currenciesFile is main/<locale>/currencies.json
+type currenciesFile struct {
+	Main map[string]struct {
+		Numbers struct {
+			Currencies map[string]struct {
+				Symbol string `json:"symbol"`
+			} `json:"currencies"`
+		} `json:"numbers"`
+	} `json:"main"`
+}
@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+// locale returns the bundled formats of tag
+func locale(t *testing.T, tag string) *LocaleFormat {
+	t.Helper()
+	c, err := DefaultCLDR()
+	if err != nil {
+		t.Fatalf("DefaultCLDR() error = %v", err)
+	}
+	format, err := c.Locale(tag)
+	if err != nil {
+		t.Fatalf("Locale(%q) error = %v", tag, err)
+	}
+	return format
+}
+
+func TestCanonicalTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{tag: "EN", want: "en"},
+		{tag: "de_ch", want: "de-CH"},
+		{tag: "zh-hans-cn", want: "zh-Hans-CN"},
+		{tag: "sr-latn-rs", want: "sr-Latn-RS"},
+		{tag: "x", wantErr: true},
+		{tag: "en-", wantErr: true},
+		{tag: "en--US", wantErr: true},
+		{tag: "-en", wantErr: true},
+		{tag: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := CanonicalTag(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CanonicalTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	tests := []struct {
+		tag        string
+		dataLocale string
+		firstDay   string
+		categories []string
+	}{
+		{"en", "en", "sun", []string{"one", "other"}},
+		{"en-GB", "en", "mon", []string{"one", "other"}},
+		{"de", "de", "mon", []string{"one", "other"}},
+		{"de-AT", "de", "mon", []string{"one", "other"}},
+		{"de_ch", "de-CH", "mon", []string{"one", "other"}},
+		{"fr", "fr", "mon", []string{"one", "many", "other"}},
+		{"ja", "ja", "sun", []string{"other"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got := locale(t, tt.tag)
+			if got.DataLocale != tt.dataLocale {
+				t.Errorf("DataLocale = %q, want %q", got.DataLocale, tt.dataLocale)
+			}
+			if got.FirstDayOfWeek != tt.firstDay {
+				t.Errorf("FirstDayOfWeek = %q, want %q", got.FirstDayOfWeek, tt.firstDay)
+			}
+			if !reflect.DeepEqual(got.PluralCategories, tt.categories) {
+				t.Errorf("PluralCategories = %v, want %v", got.PluralCategories, tt.categories)
+			}
+		})
+	}
+}
+
+func TestLocaleWithoutData(t *testing.T) {
+	c, err := DefaultCLDR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"ru", "tlh", "x"} {
+		if _, err := c.Locale(tag); err == nil {
+			t.Errorf("Locale(%q) succeeded, want error", tag)
+		}
+	}
+}
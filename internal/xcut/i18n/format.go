@@ -0,0 +1,366 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LocaleFormat is the CLDR formatting data of one locale
+type LocaleFormat struct {
+	Locale string `json:"locale"`
+	// DataLocale is the CLDR locale the number data came from, e.g. de for de-AT
+	DataLocale       string            `json:"data_locale"`
+	Decimal          string            `json:"decimal_separator"`
+	Group            string            `json:"grouping_separator"`
+	MinusSign        string            `json:"minus_sign"`
+	PercentSign      string            `json:"percent_sign"`
+	DecimalPattern   string            `json:"decimal_pattern"`
+	PercentPattern   string            `json:"percent_pattern"`
+	CurrencyPattern  string            `json:"currency_pattern"`
+	DatePatterns     map[string]string `json:"date_patterns"`
+	TimePatterns     map[string]string `json:"time_patterns"`
+	DateTimePatterns map[string]string `json:"datetime_patterns"`
+	// FirstDayOfWeek is sun, mon, ... as in CLDR weekData
+	FirstDayOfWeek   string   `json:"first_day_of_week"`
+	PluralCategories []string `json:"plural_categories"`
+
+	// months and days hold abbreviated and wide names; days in time.Weekday order
+	months          [2][12]string
+	days            [2][7]string
+	dayPeriods      [2]string
+	currencySymbols map[string]string
+	plurals         []pluralRule
+}
+
+// CurrencySymbol returns the symbol of an ISO 4217 currency in the locale,
+// or the code itself when the locale has none
+func (f *LocaleFormat) CurrencySymbol(code string) string {
+	if symbol, ok := f.currencySymbols[code]; ok && symbol != "" {
+		return symbol
+	}
+	return code
+}
+
+// CurrencyPlacement tells whether the currency symbol goes before or after
+// the amount
+func (f *LocaleFormat) CurrencyPlacement() string {
+	pattern := parseNumberPattern(f.CurrencyPattern)
+	if strings.Contains(pattern.prefix, "¤") {
+		return "before"
+	}
+	return "after"
+}
+
+// FormatNumber formats value with the decimal pattern of the locale
+func (f *LocaleFormat) FormatNumber(value float64) string {
+	return f.format(f.DecimalPattern, value, -1, "")
+}
+
+// FormatPercent formats a ratio, 0.25 for 25 %, with the percent pattern
+func (f *LocaleFormat) FormatPercent(value float64) string {
+	return f.format(f.PercentPattern, value*100, -1, "")
+}
+
+// FormatCurrency formats an amount of an ISO 4217 currency with digits
+// fraction digits, the minor units of the currency
+func (f *LocaleFormat) FormatCurrency(amount float64, code string, digits int) string {
+	return f.format(f.CurrencyPattern, amount, digits, f.CurrencySymbol(code))
+}
+
+// FormatDate formats the date of t with the full, long, medium or short
+// pattern of the locale
+func (f *LocaleFormat) FormatDate(t time.Time, style string) (string, error) {
+	pattern, err := f.pattern(f.DatePatterns, style)
+	if err != nil {
+		return "", err
+	}
+	return f.formatTime(pattern, t), nil
+}
+
+// FormatTime formats the time of day of t with a time pattern of the locale
+func (f *LocaleFormat) FormatTime(t time.Time, style string) (string, error) {
+	pattern, err := f.pattern(f.TimePatterns, style)
+	if err != nil {
+		return "", err
+	}
+	return f.formatTime(pattern, t), nil
+}
+
+// FormatDateTime formats t by joining its date and time of the same style
+// with the date-time pattern of the locale
+func (f *LocaleFormat) FormatDateTime(t time.Time, style string) (string, error) {
+	date, err := f.FormatDate(t, style)
+	if err != nil {
+		return "", err
+	}
+	clock, err := f.FormatTime(t, style)
+	if err != nil {
+		return "", err
+	}
+	glue := unquote(f.DateTimePatterns[style])
+	return strings.NewReplacer("{1}", date, "{0}", clock).Replace(glue), nil
+}
+
+func (f *LocaleFormat) pattern(patterns map[string]string, style string) (string, error) {
+	if style == "" {
+		style = "medium"
+	}
+	pattern, ok := patterns[style]
+	if !ok {
+		return "", fmt.Errorf("unknown style %q, expected full, long, medium or short", style)
+	}
+	return pattern, nil
+}
+
+// numberPattern is a parsed CLDR number pattern such as #,##,##0.00 ¤
+type numberPattern struct {
+	prefix, suffix         string
+	negPrefix, negSuffix   string
+	hasNegative            bool
+	minInt                 int
+	minFrac, maxFrac       int
+	primaryGroup, secGroup int
+}
+
+// parseNumberPattern parses the positive and optional negative subpattern
+func parseNumberPattern(pattern string) numberPattern {
+	positive, negative, hasNegative := strings.Cut(pattern, ";")
+	p := parseSubpattern(positive)
+	if hasNegative {
+		n := parseSubpattern(negative)
+		p.negPrefix, p.negSuffix, p.hasNegative = n.prefix, n.suffix, true
+	}
+	return p
+}
+
+func parseSubpattern(pattern string) numberPattern {
+	var p numberPattern
+	start := strings.IndexAny(pattern, "#0")
+	end := strings.LastIndexAny(pattern, "#0")
+	if start < 0 {
+		p.prefix = unquote(pattern)
+		return p
+	}
+	p.prefix = unquote(pattern[:start])
+	p.suffix = unquote(pattern[end+1:])
+	body := pattern[start : end+1]
+
+	integer, fraction, _ := strings.Cut(body, ".")
+	p.minInt = strings.Count(integer, "0")
+	p.minFrac = strings.Count(fraction, "0")
+	p.maxFrac = len(fraction)
+	if groups := strings.Split(integer, ","); len(groups) > 1 {
+		p.primaryGroup = len(groups[len(groups)-1])
+		p.secGroup = p.primaryGroup
+		if len(groups) > 2 {
+			p.secGroup = len(groups[len(groups)-2])
+		}
+	}
+	return p
+}
+
+// format applies a number pattern; digits overrides the fraction digits
+// when not negative, symbol replaces the currency sign
+func (f *LocaleFormat) format(pattern string, value float64, digits int, symbol string) string {
+	p := parseNumberPattern(pattern)
+	minFrac, maxFrac := p.minFrac, p.maxFrac
+	if digits >= 0 {
+		minFrac, maxFrac = digits, digits
+	}
+
+	negative := value < 0 || (value == 0 && math.Signbit(value))
+	digitsText := strconv.FormatFloat(math.Abs(value), 'f', maxFrac, 64)
+	integer, fraction, _ := strings.Cut(digitsText, ".")
+	for len(fraction) > minFrac && strings.HasSuffix(fraction, "0") {
+		fraction = fraction[:len(fraction)-1]
+	}
+	if fraction == "" && strings.Trim(integer, "0") == "" {
+		negative = false
+	}
+	for len(integer) < p.minInt {
+		integer = "0" + integer
+	}
+
+	var number strings.Builder
+	number.WriteString(group(integer, p.primaryGroup, p.secGroup, f.Group))
+	if fraction != "" {
+		number.WriteString(f.Decimal)
+		number.WriteString(fraction)
+	}
+
+	prefix, suffix := p.prefix, p.suffix
+	if negative {
+		if p.hasNegative {
+			prefix, suffix = p.negPrefix, p.negSuffix
+		} else {
+			prefix = "-" + prefix
+		}
+	}
+	prefix = f.localizeAffix(prefix, symbol, true)
+	suffix = f.localizeAffix(suffix, symbol, false)
+	return prefix + number.String() + suffix
+}
+
+// localizeAffix replaces the pattern symbols of a prefix or suffix. A
+// currency symbol ending in a letter next to the digits is separated from
+// them by a no-break space, as in CHF 12.00.
+func (f *LocaleFormat) localizeAffix(affix, symbol string, isPrefix bool) string {
+	if strings.Contains(affix, "¤") {
+		adjacent := (isPrefix && strings.HasSuffix(affix, "¤")) || (!isPrefix && strings.HasPrefix(affix, "¤"))
+		if adjacent && symbol != "" {
+			var r rune
+			if isPrefix {
+				r, _ = utf8.DecodeLastRuneInString(symbol)
+			} else {
+				r, _ = utf8.DecodeRuneInString(symbol)
+			}
+			if unicode.IsLetter(r) {
+				if isPrefix {
+					symbol += " "
+				} else {
+					symbol = " " + symbol
+				}
+			}
+		}
+		affix = strings.ReplaceAll(affix, "¤", symbol)
+	}
+	return strings.NewReplacer("-", f.MinusSign, "%", f.PercentSign).Replace(affix)
+}
+
+// group inserts separators into an integer digit string, primary digits from
+// the right, then secondary digits per group
+func group(integer string, primary, secondary int, separator string) string {
+	if primary <= 0 || len(integer) <= primary {
+		return integer
+	}
+	head, tail := integer[:len(integer)-primary], integer[len(integer)-primary:]
+	var groups []string
+	for len(head) > secondary {
+		groups = append([]string{head[len(head)-secondary:]}, groups...)
+		head = head[:len(head)-secondary]
+	}
+	groups = append([]string{head}, groups...)
+	return strings.Join(append(groups, tail), separator)
+}
+
+// formatTime applies a CLDR date/time pattern. Fields cover the Gregorian
+// calendar subset used by the bundled patterns: y, M, L, d, E, c, a, h, H,
+// K, k, m, s and z. zzzz gives the IANA zone name.
+func (f *LocaleFormat) formatTime(pattern string, t time.Time) string {
+	var out strings.Builder
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if r == '\'' {
+			j := i + 1
+			if j < len(runes) && runes[j] == '\'' {
+				out.WriteRune('\'')
+				i += 2
+				continue
+			}
+			for j < len(runes) && runes[j] != '\'' {
+				out.WriteRune(runes[j])
+				j++
+			}
+			i = j + 1
+			continue
+		}
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			out.WriteRune(r)
+			i++
+			continue
+		}
+		n := 1
+		for i+n < len(runes) && runes[i+n] == r {
+			n++
+		}
+		out.WriteString(f.field(r, n, t))
+		i += n
+	}
+	return out.String()
+}
+
+func (f *LocaleFormat) field(letter rune, n int, t time.Time) string {
+	switch letter {
+	case 'y':
+		if n == 2 {
+			return fmt.Sprintf("%02d", t.Year()%100)
+		}
+		return fmt.Sprintf("%0*d", n, t.Year())
+	case 'M', 'L':
+		switch {
+		case n >= 4:
+			return f.months[1][t.Month()-1]
+		case n == 3:
+			return f.months[0][t.Month()-1]
+		}
+		return fmt.Sprintf("%0*d", n, int(t.Month()))
+	case 'd':
+		return fmt.Sprintf("%0*d", n, t.Day())
+	case 'E', 'c':
+		if n >= 4 {
+			return f.days[1][t.Weekday()]
+		}
+		return f.days[0][t.Weekday()]
+	case 'a':
+		if t.Hour() < 12 {
+			return f.dayPeriods[0]
+		}
+		return f.dayPeriods[1]
+	case 'h':
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+		return fmt.Sprintf("%0*d", n, hour)
+	case 'H':
+		return fmt.Sprintf("%0*d", n, t.Hour())
+	case 'K':
+		return fmt.Sprintf("%0*d", n, t.Hour()%12)
+	case 'k':
+		hour := t.Hour()
+		if hour == 0 {
+			hour = 24
+		}
+		return fmt.Sprintf("%0*d", n, hour)
+	case 'm':
+		return fmt.Sprintf("%0*d", n, t.Minute())
+	case 's':
+		return fmt.Sprintf("%0*d", n, t.Second())
+	case 'z':
+		if n >= 4 {
+			return t.Location().String()
+		}
+		name, _ := t.Zone()
+		if name == "" || name[0] == '+' || name[0] == '-' {
+			return "GMT" + t.Format("-07:00")
+		}
+		return name
+	}
+	return strings.Repeat(string(letter), n)
+}
+
+// unquote removes the quoting of literal text in a pattern, where two
+// single quotes stand for one
+func unquote(pattern string) string {
+	if !strings.Contains(pattern, "'") {
+		return pattern
+	}
+	var out strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '\'' {
+			out.WriteByte(pattern[i])
+			continue
+		}
+		if i+1 < len(pattern) && pattern[i+1] == '\'' {
+			out.WriteByte('\'')
+			i++
+		}
+	}
+	return out.String()
+}
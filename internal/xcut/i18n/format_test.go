@@ -0,0 +1,96 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatNumbers(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		format func(f *LocaleFormat) string
+		want   string
+	}{
+		{"en grouping", "en", func(f *LocaleFormat) string { return f.FormatNumber(1234567.891) }, "1,234,567.891"},
+		{"en negative", "en", func(f *LocaleFormat) string { return f.FormatNumber(-0.5) }, "-0.5"},
+		{"de separators", "de", func(f *LocaleFormat) string { return f.FormatNumber(1234567.891) }, "1.234.567,891"},
+		{"de negative", "de", func(f *LocaleFormat) string { return f.FormatNumber(-0.5) }, "-0,5"},
+		{"fr narrow spaces", "fr", func(f *LocaleFormat) string { return f.FormatNumber(1234567.891) }, "1\u202f234\u202f567,891"},
+		{"de-CH apostrophe", "de-CH", func(f *LocaleFormat) string { return f.FormatNumber(1234567.891) }, "1’234’567.891"},
+		{"hi secondary grouping", "hi", func(f *LocaleFormat) string { return f.FormatNumber(1234567.891) }, "12,34,567.891"},
+		{"en percent", "en", func(f *LocaleFormat) string { return f.FormatPercent(0.256) }, "26%"},
+		{"de percent", "de", func(f *LocaleFormat) string { return f.FormatPercent(0.256) }, "26\u00a0%"},
+		{"fr percent", "fr", func(f *LocaleFormat) string { return f.FormatPercent(0.256) }, "26\u202f%"},
+		{"fr-CH percent", "fr-CH", func(f *LocaleFormat) string { return f.FormatPercent(0.256) }, "26%"},
+		{"en currency prefix", "en", func(f *LocaleFormat) string { return f.FormatCurrency(1234.5, "EUR", 2) }, "€1,234.50"},
+		{"en negative alphabetic symbol", "en", func(f *LocaleFormat) string { return f.FormatCurrency(-1234.5, "CHF", 2) }, "-CHF\u00a01,234.50"},
+		{"en no fraction digits", "en", func(f *LocaleFormat) string { return f.FormatCurrency(1234, "JPY", 0) }, "¥1,234"},
+		{"de currency suffix", "de", func(f *LocaleFormat) string { return f.FormatCurrency(1234.5, "EUR", 2) }, "1.234,50\u00a0€"},
+		{"de negative currency", "de", func(f *LocaleFormat) string { return f.FormatCurrency(-1234.5, "CHF", 2) }, "-1.234,50\u00a0CHF"},
+		{"fr currency suffix", "fr", func(f *LocaleFormat) string { return f.FormatCurrency(1234.5, "EUR", 2) }, "1\u202f234,50\u00a0€"},
+		{"fr code without symbol", "fr", func(f *LocaleFormat) string { return f.FormatCurrency(1234, "JPY", 0) }, "1\u202f234\u00a0JPY"},
+		{"de-CH currency", "de-CH", func(f *LocaleFormat) string { return f.FormatCurrency(1234.5, "EUR", 2) }, "€\u00a01’234.50"},
+		{"de-CH negative currency", "de-CH", func(f *LocaleFormat) string { return f.FormatCurrency(-1234.5, "CHF", 2) }, "CHF-1’234.50"},
+		{"pt currency", "pt", func(f *LocaleFormat) string { return f.FormatCurrency(1234.5, "EUR", 2) }, "€\u00a01.234,50"},
+		{"hi symbol", "hi", func(f *LocaleFormat) string { return f.FormatCurrency(1234, "JPY", 0) }, "JP¥1,234"},
+		{"ja full width symbol", "ja", func(f *LocaleFormat) string { return f.FormatCurrency(1234, "JPY", 0) }, "￥1,234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format(locale(t, tt.locale)); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDates(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	at := time.Date(2024, 3, 5, 14, 7, 9, 0, berlin)
+
+	date := (*LocaleFormat).FormatDate
+	clock := (*LocaleFormat).FormatTime
+	dateTime := (*LocaleFormat).FormatDateTime
+
+	tests := []struct {
+		name    string
+		locale  string
+		format  func(*LocaleFormat, time.Time, string) (string, error)
+		style   string
+		want    string
+		wantErr bool
+	}{
+		{name: "en full date", locale: "en", format: date, style: "full", want: "Tuesday, March 5, 2024"},
+		{name: "en short date", locale: "en", format: date, style: "short", want: "3/5/24"},
+		{name: "en short time", locale: "en", format: clock, style: "short", want: "2:07 PM"},
+		{name: "en medium date time", locale: "en", format: dateTime, style: "medium", want: "Mar 5, 2024, 2:07:09 PM"},
+		{name: "en-GB full date", locale: "en-GB", format: date, style: "full", want: "Tuesday 5 March 2024"},
+		{name: "en-IN medium date time", locale: "en-IN", format: dateTime, style: "medium", want: "05-Mar-2024, 2:07:09 pm"},
+		{name: "de full date", locale: "de", format: date, style: "full", want: "Dienstag, 5. März 2024"},
+		{name: "de short date", locale: "de", format: date, style: "short", want: "05.03.24"},
+		{name: "de short time", locale: "de", format: clock, style: "short", want: "14:07"},
+		{name: "de medium date time", locale: "de", format: dateTime, style: "medium", want: "05.03.2024, 14:07:09"},
+		{name: "fr full date", locale: "fr", format: date, style: "full", want: "mardi 5 mars 2024"},
+		{name: "fr short date", locale: "fr", format: date, style: "short", want: "05/03/2024"},
+		{name: "fr medium date time", locale: "fr", format: dateTime, style: "medium", want: "5 mars 2024, 14:07:09"},
+		{name: "ja full date", locale: "ja", format: date, style: "full", want: "2024年3月5日火曜日"},
+		{name: "unknown style", locale: "en", format: date, style: "tiny", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.format(locale(t, tt.locale), at, tt.style)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
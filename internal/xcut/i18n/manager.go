@@ -3,7 +3,6 @@ package i18n
 import (
 	"context"
 	"fmt"
-	"strings"
 )
 
 type I18nManager struct {
@@ -37,19 +36,21 @@ func (im *I18nManager) GetText(ctx context.Context, key, locale string) string {
 	return key // Return key if no translation found
 }
 
+// FormatNumber formats value with two fraction digits and the separators of
+// locale from the bundled CLDR data
 func (im *I18nManager) FormatNumber(value float64, locale string) string {
-	switch locale {
-	case "en-US":
-		return fmt.Sprintf("%.2f", value)
-	case "de-DE":
-		str := fmt.Sprintf("%.2f", value)
-		return strings.Replace(str, ".", ",", 1)
-	case "fr-FR":
-		str := fmt.Sprintf("%.2f", value)
-		return strings.Replace(str, ".", ",", 1)
-	default:
+	cldr, err := DefaultCLDR()
+	if err != nil {
 		return fmt.Sprintf("%.2f", value)
 	}
+	format, err := cldr.Locale(locale)
+	if err != nil {
+		format, err = cldr.Locale(im.fallback)
+		if err != nil {
+			return fmt.Sprintf("%.2f", value)
+		}
+	}
+	return format.format(format.DecimalPattern, value, 2, "")
 }
 
 func (im *I18nManager) AddTranslation(locale, key, text string) {
@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage returns the canonical language tags of an
+// Accept-Language header, most preferred first. The wildcard, tags with
+// q=0 and malformed tags are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var ranges []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			q = parsed
+		}
+		tag, err := CanonicalTag(strings.TrimSpace(fields[0]))
+		if err != nil || q == 0 {
+			continue
+		}
+		ranges = append(ranges, weighted{tag, q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	tags := make([]string, len(ranges))
+	for i, r := range ranges {
+		tags[i] = r.tag
+	}
+	return tags
+}
+
+// FallbackChain returns the tags a canonical tag is looked up as, most
+// specific first, as in the lookup of RFC 4647: de-CH, de. A tag with a
+// script is first tried without it.
+func FallbackChain(tag string) []string {
+	return parentChain(tag)
+}
+
+// Negotiate picks the supported locale that best serves a list of
+// preferred tags. Each tag is looked up along its fallback chain; failing
+// that, a supported locale of the same language is taken, so ja finds ja-JP,
+// unless the tag names a script. It returns the empty string when no tag can be served.
+func Negotiate(preferred, supported []string) string {
+	available := make(map[string]bool, len(supported))
+	for _, tag := range supported {
+		available[tag] = true
+	}
+	for _, tag := range preferred {
+		for _, candidate := range FallbackChain(tag) {
+			if available[candidate] {
+				return candidate
+			}
+		}
+		parts := strings.Split(tag, "-")
+		if len(parts) > 1 && len(parts[1]) == 4 {
+			// zh-Hant is not served by zh-CN, written in another script
+			continue
+		}
+		language := parts[0]
+		for _, candidate := range supported {
+			if strings.SplitN(candidate, "-", 2)[0] == language {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
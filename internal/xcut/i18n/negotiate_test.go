@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{"empty", "", []string{}},
+		{"single tag", "de-CH", []string{"de-CH"}},
+		{"ordered by quality", "fr;q=0.5, de-ch, en;q=0.8", []string{"de-CH", "en", "fr"}},
+		{"equal quality keeps header order", "es, pt;q=0.9, it;q=0.9", []string{"es", "pt", "it"}},
+		{"other parameters are ignored", "ja; level=1; q=0.7, en", []string{"en", "ja"}},
+		{"wildcard is dropped", "*, fr;q=0.5", []string{"fr"}},
+		{"zero quality is dropped", "en, fr;q=0", []string{"en"}},
+		{"invalid quality counts as zero", "en;q=2, fr;q=abc, de", []string{"de"}},
+		{"malformed tags are dropped", "en-, x, 1234, pt-br", []string{"pt-BR"}},
+		{"script is title cased", "zh-hant-tw", []string{"zh-Hant-TW"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []string
+	}{
+		{"de", []string{"de"}},
+		{"de-CH", []string{"de-CH", "de"}},
+		{"zh-Hant", []string{"zh-Hant", "zh"}},
+		{"zh-Hant-TW", []string{"zh-TW", "zh-Hant-TW", "zh-Hant", "zh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := FallbackChain(tt.tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FallbackChain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"en", "en-GB", "de", "fr-CA", "ja-JP", "zh-CN", "zh-Hant"}
+
+	tests := []struct {
+		name      string
+		preferred []string
+		want      string
+	}{
+		{"exact match", []string{"en-GB"}, "en-GB"},
+		{"parent of a region", []string{"de-AT"}, "de"},
+		{"first preference that can be served", []string{"it", "de-CH", "en"}, "de"},
+		{"sibling of the same language", []string{"fr-FR"}, "fr-CA"},
+		{"region of a bare language", []string{"ja"}, "ja-JP"},
+		{"script match", []string{"zh-Hant-TW"}, "zh-Hant"},
+		{"other script is not served", []string{"zh-Hant-HK", "en"}, "zh-Hant"},
+		{"language without script takes any region", []string{"zh"}, "zh-CN"},
+		{"no match", []string{"ru", "pl"}, ""},
+		{"no preference", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.preferred, supported); got != tt.want {
+				t.Errorf("Negotiate(%v) = %q, want %q", tt.preferred, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiateScript(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred []string
+		supported []string
+		want      string
+	}{
+		{"traditional is not served by simplified", []string{"zh-Hant"}, []string{"zh-CN"}, ""},
+		{"traditional falls through to the next preference", []string{"zh-Hant-TW", "en"}, []string{"zh-CN", "en"}, "en"},
+		{"serbian latin is served by a region without script", []string{"sr-Latn-RS"}, []string{"sr-RS"}, "sr-RS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.preferred, tt.supported); got != tt.want {
+				t.Errorf("Negotiate(%v, %v) = %q, want %q", tt.preferred, tt.supported, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// pluralRule is a CLDR plural rule such as "i = 1 and v = 0", kept as an OR
+// of ANDs of relations
+type pluralRule struct {
+	category  string
+	condition [][]pluralRelation
+}
+
+// pluralRelation is "operand [% mod] (= | !=) ranges"
+type pluralRelation struct {
+	operand byte
+	mod     float64
+	negate  bool
+	ranges  [][2]float64
+}
+
+// pluralOperands are the operands of a number as defined by UTS #35
+type pluralOperands struct {
+	n, i, v, w, f, t, e float64
+}
+
+// parsePluralRules parses the pluralRule-count-* entries of one language.
+// The other category has no condition and is not returned.
+func parsePluralRules(rules map[string]string) ([]pluralRule, error) {
+	var parsed []pluralRule
+	for key, text := range rules {
+		category := strings.TrimPrefix(key, "pluralRule-count-")
+		if category == "other" {
+			continue
+		}
+		if i := strings.Index(text, "@"); i >= 0 {
+			text = text[:i]
+		}
+		rule := pluralRule{category: category}
+		for _, alternative := range strings.Split(text, " or ") {
+			var relations []pluralRelation
+			for _, part := range strings.Split(alternative, " and ") {
+				relation, err := parsePluralRelation(strings.TrimSpace(part))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", category, err)
+				}
+				relations = append(relations, relation)
+			}
+			rule.condition = append(rule.condition, relations)
+		}
+		parsed = append(parsed, rule)
+	}
+	// Categories are tested in CLDR order so that the result is stable
+	for i := 1; i < len(parsed); i++ {
+		for j := i; j > 0 && pluralRank(parsed[j].category) < pluralRank(parsed[j-1].category); j-- {
+			parsed[j], parsed[j-1] = parsed[j-1], parsed[j]
+		}
+	}
+	return parsed, nil
+}
+
+func parsePluralRelation(text string) (pluralRelation, error) {
+	var relation pluralRelation
+	left, right, found := strings.Cut(text, "!=")
+	if found {
+		relation.negate = true
+	} else if left, right, found = strings.Cut(text, "="); !found {
+		return relation, fmt.Errorf("invalid relation %q", text)
+	}
+
+	fields := strings.Fields(strings.ReplaceAll(left, "mod", "%"))
+	switch {
+	case len(fields) == 1:
+	case len(fields) == 3 && fields[1] == "%":
+		mod, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || mod <= 0 {
+			return relation, fmt.Errorf("invalid modulus in %q", text)
+		}
+		relation.mod = mod
+	default:
+		return relation, fmt.Errorf("invalid relation %q", text)
+	}
+	if len(fields[0]) != 1 || !strings.Contains("nivwftec", fields[0]) {
+		return relation, fmt.Errorf("unknown operand in %q", text)
+	}
+	relation.operand = fields[0][0]
+
+	for _, item := range strings.Split(right, ",") {
+		low, high, isRange := strings.Cut(strings.TrimSpace(item), "..")
+		if !isRange {
+			high = low
+		}
+		lo, err := strconv.ParseFloat(strings.TrimSpace(low), 64)
+		if err != nil {
+			return relation, fmt.Errorf("invalid value in %q", text)
+		}
+		hi, err := strconv.ParseFloat(strings.TrimSpace(high), 64)
+		if err != nil {
+			return relation, fmt.Errorf("invalid value in %q", text)
+		}
+		relation.ranges = append(relation.ranges, [2]float64{lo, hi})
+	}
+	return relation, nil
+}
+
+func (r pluralRule) matches(ops pluralOperands) bool {
+	for _, relations := range r.condition {
+		matched := true
+		for _, relation := range relations {
+			if !relation.matches(ops) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (r pluralRelation) matches(ops pluralOperands) bool {
+	var value float64
+	switch r.operand {
+	case 'n':
+		value = ops.n
+	case 'i':
+		value = ops.i
+	case 'v':
+		value = ops.v
+	case 'w':
+		value = ops.w
+	case 'f':
+		value = ops.f
+	case 't':
+		value = ops.t
+	case 'e', 'c':
+		value = ops.e
+	}
+	if r.mod > 0 {
+		value = math.Mod(value, r.mod)
+	}
+	in := false
+	for _, bounds := range r.ranges {
+		// A range holds integers only, so n = 1..3 is false for 1.5
+		if value == math.Trunc(value) && value >= bounds[0] && value <= bounds[1] {
+			in = true
+			break
+		}
+	}
+	return in != r.negate
+}
+
+// newPluralOperands computes the operands of a decimal number as written,
+// so that 1.0 and 1 differ in v. A compact exponent is written 1.2c6 or 1.2e6.
+func newPluralOperands(value string) (pluralOperands, error) {
+	var ops pluralOperands
+	text := strings.TrimPrefix(strings.TrimSpace(value), "-")
+	if i := strings.IndexAny(text, "ce"); i >= 0 {
+		exponent, err := strconv.Atoi(text[i+1:])
+		if err != nil || exponent < 0 {
+			return ops, fmt.Errorf("invalid number %q", value)
+		}
+		ops.e = float64(exponent)
+		text = shiftDecimal(text[:i], exponent)
+	}
+
+	integer, fraction, _ := strings.Cut(text, ".")
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return ops, fmt.Errorf("invalid number %q", value)
+	}
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return ops, fmt.Errorf("invalid number %q", value)
+	}
+	ops.n = n
+	ops.i, _ = strconv.ParseFloat(integer, 64)
+	ops.v = float64(len(fraction))
+	trimmed := strings.TrimRight(fraction, "0")
+	ops.w = float64(len(trimmed))
+	if fraction != "" {
+		ops.f, _ = strconv.ParseFloat(fraction, 64)
+	}
+	if trimmed != "" {
+		ops.t, _ = strconv.ParseFloat(trimmed, 64)
+	}
+	return ops, nil
+}
+
+// shiftDecimal moves the decimal point of a plain decimal string right
+func shiftDecimal(text string, places int) string {
+	integer, fraction, _ := strings.Cut(text, ".")
+	for places > 0 {
+		if fraction == "" {
+			fraction = "0"
+		}
+		integer, fraction = integer+fraction[:1], fraction[1:]
+		places--
+	}
+	if fraction == "" {
+		return integer
+	}
+	return integer + "." + fraction
+}
+
+// PluralCategory returns the CLDR cardinal plural category of a number
+// written as a decimal string, e.g. one for "1" and other for "1.0" in
+// English
+func (f *LocaleFormat) PluralCategory(value string) (string, error) {
+	ops, err := newPluralOperands(value)
+	if err != nil {
+		return "", err
+	}
+	for _, rule := range f.plurals {
+		if rule.matches(ops) {
+			return rule.category, nil
+		}
+	}
+	return "other", nil
+}
@@ -0,0 +1,110 @@
+package i18n
+
+import "testing"
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		locale  string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{locale: "en", value: "1", want: "one"},
+		{locale: "en", value: "-1", want: "one"},
+		{locale: "en", value: "1.0", want: "other"},
+		{locale: "en", value: "2", want: "other"},
+		{locale: "en", value: "1e3", want: "other"},
+		{locale: "fr", value: "0", want: "one"},
+		{locale: "fr", value: "1.5", want: "one"},
+		{locale: "fr", value: "2", want: "other"},
+		{locale: "fr", value: "2000", want: "other"},
+		{locale: "fr", value: "1000000", want: "many"},
+		{locale: "fr", value: "1c6", want: "many"},
+		{locale: "fr", value: "1.2c6", want: "many"},
+		{locale: "es", value: "1.0", want: "one"},
+		{locale: "es", value: "1000000", want: "many"},
+		{locale: "pt", value: "0", want: "one"},
+		{locale: "hi", value: "0", want: "one"},
+		{locale: "hi", value: "0.04", want: "one"},
+		{locale: "hi", value: "1.5", want: "other"},
+		{locale: "ja", value: "1", want: "other"},
+		{locale: "en", value: "abc", wantErr: true},
+		{locale: "en", value: "", wantErr: true},
+		{locale: "en", value: "1e-2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+" "+tt.value, func(t *testing.T) {
+			got, err := locale(t, tt.locale).PluralCategory(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PluralCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PluralCategory() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Languages with rules but no bundled locale data are checked against
+// their parsed rules directly
+func TestPluralRules(t *testing.T) {
+	c, err := DefaultCLDR()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		language string
+		value    string
+		want     string
+	}{
+		{"ru", "1", "one"},
+		{"ru", "21", "one"},
+		{"ru", "11", "many"},
+		{"ru", "2", "few"},
+		{"ru", "24", "few"},
+		{"ru", "12", "many"},
+		{"ru", "5", "many"},
+		{"ru", "1.5", "other"},
+		{"ar", "0", "zero"},
+		{"ar", "1", "one"},
+		{"ar", "2", "two"},
+		{"ar", "3", "few"},
+		{"ar", "103", "few"},
+		{"ar", "11", "many"},
+		{"ar", "100", "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language+" "+tt.value, func(t *testing.T) {
+			format := &LocaleFormat{plurals: c.plurals[tt.language]}
+			got, err := format.PluralCategory(tt.value)
+			if err != nil {
+				t.Fatalf("PluralCategory() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PluralCategory() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePluralRulesRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"unknown operand", "q = 1"},
+		{"missing range", "n ="},
+		{"bad modulus", "n % x = 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parsePluralRules(map[string]string{"pluralRule-count-one": tt.rule}); err == nil {
+				t.Error("parsePluralRules() succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/exporters"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/query"
+)
+
+// RunExport runs the export subcommand. It streams one dataset:
+//
+//	server export -dataset countries [-format csv] [-fields country_code,country_name] [-as-of 2024-01-01] [-tenant default-tenant] -out countries.csv
+//
+// or, with -dir, writes the full extract of every dataset for the data
+// warehouse, e.g. from a nightly cron job:
+//
+//	server export -dir /var/exports [-format csv] [-tenant a,b]
+//
+// Exports go to files because the server logs to stdout. It returns the
+// process exit code.
+func RunExport(ctx context.Context, svc *applicationservices.ExportAppService, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(out)
+	dataset := flags.String("dataset", "", "dataset to export: "+strings.Join(svc.Datasets(), ", "))
+	dir := flags.String("dir", "", "write the full extract of every dataset below this directory")
+	format := flags.String("format", exporters.FormatCSV, "csv, ndjson or geojson")
+	fields := flags.String("fields", "", "comma-separated columns to export (default: all)")
+	asOf := flags.String("as-of", "", "export the rows valid on this date, YYYY-MM-DD (default: today)")
+	tenant := flags.String("tenant", "default-tenant", "tenant to export; with -dir a comma-separated list")
+	file := flags.String("out", "", "output file")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *dir != "" {
+		cfg := applicationservices.ExtractConfig{Dir: *dir, Format: *format, Tenants: splitList(*tenant)}
+		if err := svc.Extract(ctx, cfg, time.Now()); err != nil {
+			fmt.Fprintf(out, "export: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if *dataset == "" || *file == "" {
+		fmt.Fprintln(out, "export: -dataset and -out, or -dir, are required")
+		flags.Usage()
+		return 2
+	}
+
+	req := applicationservices.ExportRequest{Dataset: *dataset, Format: strings.ToLower(*format), Fields: splitList(*fields)}
+	if *asOf != "" {
+		day, err := query.ParseAsOf(*asOf)
+		if err != nil {
+			fmt.Fprintf(out, "export: %v\n", err)
+			return 2
+		}
+		req.AsOf = &day
+	}
+	if err := svc.Validate(req); err != nil {
+		fmt.Fprintf(out, "export: %v\n", err)
+		return 2
+	}
+
+	f, err := os.Create(*file)
+	if err != nil {
+		fmt.Fprintf(out, "export: %v\n", err)
+		return 1
+	}
+	rows, err := svc.Export(ctx, *tenant, req, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(out, "export: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(out, "exported %d %s rows to %s\n", rows, req.Dataset, *file)
+	return 0
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
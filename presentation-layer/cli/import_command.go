@@ -0,0 +1,77 @@
+// Package cli holds the subcommands of the server binary
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/importers"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+)
+
+// RunImport runs the import subcommand:
+//
+//	server import -file iso3166-2.json [-format json] [-tenant default-tenant] [-apply] [-reason "..."]
+//
+// Without -apply it prints the dry-run diff only. It returns the process exit
+// code.
+func RunImport(ctx context.Context, svc *applicationservices.ImportAppService, args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(out)
+	file := flags.String("file", "", "ISO 3166-1 or 3166-2 CSV or JSON file, - for stdin")
+	format := flags.String("format", "", "csv or json (default: from the file extension)")
+	tenant := flags.String("tenant", "default-tenant", "tenant to import into")
+	apply := flags.Bool("apply", false, "apply the changes; without it only the dry-run diff is printed")
+	reason := flags.String("reason", "", "change reason recorded in the entity history")
+	user := flags.String("user", os.Getenv("USER"), "user recorded in the audit columns")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(out, "import: -file is required")
+		flags.Usage()
+		return 2
+	}
+
+	in := io.Reader(os.Stdin)
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(out, "import: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	dataset, err := importers.Parse(*format, in)
+	if err != nil {
+		fmt.Fprintf(out, "import: %v\n", err)
+		return 1
+	}
+
+	ctx = audit.WithRequest(ctx, audit.RequestContext{UserID: *user})
+	result, err := svc.Import(ctx, *tenant, dataset, applicationservices.ImportOptions{Apply: *apply, Reason: *reason})
+	if err != nil {
+		fmt.Fprintf(out, "import: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		fmt.Fprintf(out, "import: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+)
+
+// ChangeReasonHeader carries the free-text reason recorded in entity history
+const ChangeReasonHeader = "X-Change-Reason"
+
+// SessionHeader carries the client session for unauthenticated requests
+const SessionHeader = "X-Session-ID"
+
+// Geolocation headers set by the edge proxy, first match wins
+var (
+	countryHeaders = []string{"CF-IPCountry", "X-Geo-Country", "CloudFront-Viewer-Country"}
+	regionHeaders  = []string{"X-Geo-Region", "CloudFront-Viewer-Country-Region"}
+	cityHeaders    = []string{"X-Geo-City", "CloudFront-Viewer-City"}
+)
+
+// AuditContextMiddleware passes the change reason and the request origin (IP,
+// session, device and location) to the data layer through the request context.
+// When secretKey is set, the user and session of a valid bearer token are
+// recorded too; requests without one pass through anonymously.
+func AuditContextMiddleware(secretKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if claims, ok := bearerClaims(c, secretKey); ok {
+			c.Set("user_id", claims.UserID)
+			ctx = logging.WithUserID(ctx, claims.UserID)
+			if claims.SessionID != "" {
+				ctx = audit.WithSessionID(ctx, claims.SessionID)
+			}
+		}
+		if reason := strings.TrimSpace(c.GetHeader(ChangeReasonHeader)); reason != "" {
+			ctx = audit.WithChangeReason(ctx, reason)
+		}
+		c.Request = c.Request.WithContext(audit.WithRequest(ctx, captureRequest(c)))
+		c.Next()
+	}
+}
+
+func captureRequest(c *gin.Context) audit.RequestContext {
+	request := audit.RequestContext{
+		UserID:    c.GetString("user_id"),
+		IP:        net.ParseIP(c.ClientIP()),
+		SessionID: strings.TrimSpace(c.GetHeader(SessionHeader)),
+		Device:    audit.ParseDevice(c.Request.UserAgent()),
+	}
+
+	location := audit.Location{
+		CountryCode: firstHeader(c, countryHeaders),
+		Region:      firstHeader(c, regionHeaders),
+		City:        firstHeader(c, cityHeaders),
+	}
+	// Cloudflare reports XX for unknown and T1 for Tor exits
+	if location.CountryCode == "XX" || location.CountryCode == "T1" {
+		location.CountryCode = ""
+	}
+	if location != (audit.Location{}) {
+		request.Location = &location
+	}
+	return request
+}
+
+func firstHeader(c *gin.Context, names []string) string {
+	for _, name := range names {
+		if value := strings.TrimSpace(c.GetHeader(name)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+)
+
+const testSecret = "test-secret"
+
+func signedToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims JWTClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestAuditContextMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := JWTClaims{UserID: "8f14e45f-ceea-467f-a0e6-7a1d1c0b1a2e", SessionID: "s-1"}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    audit.RequestContext
+		reason  string
+	}{
+		{
+			name:    "anonymous request",
+			headers: map[string]string{SessionHeader: "browser-session", "CF-IPCountry": "DE", "X-Geo-City": "Berlin"},
+			want:    audit.RequestContext{SessionID: "browser-session", Location: &audit.Location{CountryCode: "DE", City: "Berlin"}},
+		},
+		{
+			name: "bearer token names user and session",
+			headers: map[string]string{
+				"Authorization": "Bearer " + signedToken(t, jwt.SigningMethodHS256, []byte(testSecret), user),
+				SessionHeader:   "browser-session",
+			},
+			want: audit.RequestContext{UserID: "8f14e45f-ceea-467f-a0e6-7a1d1c0b1a2e", SessionID: "s-1"},
+		},
+		{
+			name:    "token naming a user by another ID is ignored",
+			headers: map[string]string{"Authorization": "Bearer " + signedToken(t, jwt.SigningMethodHS256, []byte(testSecret), JWTClaims{UserID: "u-1", SessionID: "s-1"})},
+			want:    audit.RequestContext{},
+		},
+		{
+			name:    "token signed with another key is ignored",
+			headers: map[string]string{"Authorization": "Bearer " + signedToken(t, jwt.SigningMethodHS256, []byte("other"), user)},
+			want:    audit.RequestContext{},
+		},
+		{
+			name:    "unsigned token is ignored",
+			headers: map[string]string{"Authorization": "Bearer " + signedToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, user)},
+			want:    audit.RequestContext{},
+		},
+		{
+			name:    "unknown country is dropped",
+			headers: map[string]string{"CF-IPCountry": "XX", ChangeReasonHeader: " treaty of 1990 "},
+			want:    audit.RequestContext{},
+			reason:  "treaty of 1990",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got audit.RequestContext
+			var reason string
+			router := gin.New()
+			router.Use(AuditContextMiddleware(testSecret))
+			router.GET("/", func(c *gin.Context) {
+				got = audit.Request(c.Request.Context())
+				reason = audit.ChangeReason(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "203.0.113.7:4321"
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got.IP.String() != "203.0.113.7" {
+				t.Errorf("IP = %v, want 203.0.113.7", got.IP)
+			}
+			got.IP, got.Device = nil, nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Request() = %+v, want %+v", got, tt.want)
+			}
+			if reason != tt.reason {
+				t.Errorf("ChangeReason() = %q, want %q", reason, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// AnyVersion is returned by IfMatchVersion for "If-Match: *"
+const AnyVersion = 0
+
+// ETag formats an entity version as a strong entity tag
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// LocalizedETag tags the representation of an entity version whose names are
+// in locale. Each locale yields a distinct tag; the empty locale yields ETag.
+func LocalizedETag(version int, locale string) string {
+	if locale == "" {
+		return ETag(version)
+	}
+	return `"` + strconv.Itoa(version) + "-" + locale + `"`
+}
+
+// SetETag emits the ETag header for an entity version
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", ETag(version))
+}
+
+// SetLocalizedETag emits the ETag header for an entity version named in locale
+func SetLocalizedETag(c *gin.Context, version int, locale string) {
+	c.Header("ETag", LocalizedETag(version, locale))
+}
+
+// IfMatchVersion parses the If-Match header into the version the client last
+// saw. "*" yields AnyVersion; a localized tag yields the version it was
+// issued for.
+func IfMatchVersion(c *gin.Context) (int, *errors.LayerError) {
+	raw := strings.TrimSpace(c.GetHeader("If-Match"))
+	if raw == "" {
+		return 0, &errors.LayerError{
+			Layer:   "presentation",
+			Code:    errors.CodePreconditionRequired,
+			Message: "If-Match header with the entity ETag is required",
+		}
+	}
+	if raw == "*" {
+		return AnyVersion, nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(raw, "W/"), `"`)
+	tag, _, _ = strings.Cut(tag, "-")
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return 0, errors.NewValidationError("If-Match", "must be an ETag returned by this API")
+	}
+	return version, nil
+}
+
+// RequireIfMatchMiddleware rejects PUT, PATCH and DELETE requests that do not
+// carry an If-Match header with 428 Precondition Required
+func RequireIfMatchMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			if _, err := IfMatchVersion(c); err != nil {
+				c.AbortWithStatusJSON(err.HTTPStatus(), gin.H{"error": err.Error(), "code": err.Code})
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLocalizedETag(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		locale  string
+		want    string
+	}{
+		{"no locale", 3, "", `"3"`},
+		{"language", 3, "fr", `"3-fr"`},
+		{"language and region", 12, "pt-BR", `"12-pt-BR"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LocalizedETag(tt.version, tt.locale); got != tt.want {
+				t.Errorf("LocalizedETag() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		ifMatch    string
+		want       int
+		wantStatus int
+	}{
+		{"strong tag", `"3"`, 3, 0},
+		{"weak tag", `W/"3"`, 3, 0},
+		{"unquoted", `3`, 3, 0},
+		{"localized tag", `"3-pt-BR"`, 3, 0},
+		{"any version", `*`, AnyVersion, 0},
+		{"missing", "", 0, http.StatusPreconditionRequired},
+		{"blank", "   ", 0, http.StatusPreconditionRequired},
+		{"not a version", `"abc"`, 0, http.StatusBadRequest},
+		{"version zero", `"0"`, 0, http.StatusBadRequest},
+		{"negative version", `"-3"`, 0, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/countries/DE", nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			got, err := IfMatchVersion(c)
+			if tt.wantStatus != 0 {
+				if err == nil {
+					t.Fatalf("IfMatchVersion() = %d, want status %d", got, tt.wantStatus)
+				}
+				if err.HTTPStatus() != tt.wantStatus {
+					t.Errorf("IfMatchVersion() status = %d, want %d", err.HTTPStatus(), tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("IfMatchVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IfMatchVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireIfMatchMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequireIfMatchMiddleware())
+	router.Any("/countries/DE", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	tests := []struct {
+		method  string
+		ifMatch string
+		want    int
+	}{
+		{http.MethodGet, "", http.StatusNoContent},
+		{http.MethodPost, "", http.StatusNoContent},
+		{http.MethodPut, "", http.StatusPreconditionRequired},
+		{http.MethodPatch, "", http.StatusPreconditionRequired},
+		{http.MethodDelete, "", http.StatusPreconditionRequired},
+		{http.MethodDelete, `"2"`, http.StatusNoContent},
+		{http.MethodPut, `"x"`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.ifMatch, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/countries/DE", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
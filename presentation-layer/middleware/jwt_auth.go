@@ -6,12 +6,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/audit"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
 )
 
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	TenantID string `json:"tenant_id"`
-	Role     string `json:"role"`
+	UserID    string `json:"user_id"`
+	TenantID  string `json:"tenant_id"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -57,7 +61,7 @@ func JWTAuthMiddleware(secretKey string) gin.HandlerFunc {
 		}
 
 		claims, ok := token.Claims.(*JWTClaims)
-		if !ok {
+		if !ok || !uuidSubject(claims) {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": gin.H{
 					"code":    "AUTH_004",
@@ -71,6 +75,34 @@ func JWTAuthMiddleware(secretKey string) gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("tenant_id", claims.TenantID)
 		c.Set("role", claims.Role)
+		ctx := logging.WithUserID(c.Request.Context(), claims.UserID)
+		if claims.SessionID != "" {
+			ctx = audit.WithSessionID(ctx, claims.SessionID)
+		}
+		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// bearerClaims returns the claims of the request's bearer token if it is
+// signed with secretKey and names its user by UUID, and false when there is
+// no such token
+func bearerClaims(c *gin.Context, secretKey string) (*JWTClaims, bool) {
+	tokenString, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if secretKey == "" || !found {
+		return nil, false
+	}
+	claims := &JWTClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secretKey), nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	return claims, err == nil && token.Valid && uuidSubject(claims)
+}
+
+// uuidSubject reports whether claims name the user by UUID. The created_by,
+// updated_by and deleted_by columns the user ID fills are uuid-typed, so any
+// other ID would be written as NULL.
+func uuidSubject(claims *JWTClaims) bool {
+	_, err := uuid.Parse(claims.UserID)
+	return err == nil
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantCode      string
+		wantUserID    string
+	}{
+		{
+			name:          "user named by UUID",
+			authorization: "Bearer " + signedToken(t, jwt.SigningMethodHS256, []byte(testSecret), JWTClaims{UserID: "8f14e45f-ceea-467f-a0e6-7a1d1c0b1a2e"}),
+			wantStatus:    http.StatusOK,
+			wantUserID:    "8f14e45f-ceea-467f-a0e6-7a1d1c0b1a2e",
+		},
+		{
+			name:          "user named by another ID",
+			authorization: "Bearer " + signedToken(t, jwt.SigningMethodHS256, []byte(testSecret), JWTClaims{UserID: "u-1"}),
+			wantStatus:    http.StatusUnauthorized,
+			wantCode:      "AUTH_004",
+		},
+		{
+			name:          "token without a user",
+			authorization: "Bearer " + signedToken(t, jwt.SigningMethodHS256, []byte(testSecret), JWTClaims{TenantID: "default-tenant"}),
+			wantStatus:    http.StatusUnauthorized,
+			wantCode:      "AUTH_004",
+		},
+		{
+			name:          "token signed with another key",
+			authorization: "Bearer " + signedToken(t, jwt.SigningMethodHS256, []byte("other"), JWTClaims{UserID: "8f14e45f-ceea-467f-a0e6-7a1d1c0b1a2e"}),
+			wantStatus:    http.StatusUnauthorized,
+			wantCode:      "AUTH_003",
+		},
+		{
+			name:       "no authorization header",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "AUTH_001",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userID string
+			router := gin.New()
+			router.Use(JWTAuthMiddleware(testSecret))
+			router.GET("/", func(c *gin.Context) {
+				userID = c.GetString("user_id")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if userID != tt.wantUserID {
+				t.Errorf("user_id = %q, want %q", userID, tt.wantUserID)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var body struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", body.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// Cursor is the decoded form of an opaque pagination cursor. Key values are
+// carried as text so that timestamps, UUIDs and numbers survive the round
+// trip unchanged; PostgreSQL casts them back to the column type. A NULL key
+// value is encoded as JSON null so it stays distinct from the empty string.
+type Cursor struct {
+	Sort     string    `json:"s"`
+	Key      []*string `json:"k"`
+	Backward bool      `json:"b,omitempty"`
+}
+
+// EncodeCursor builds an opaque cursor for the given sort expression and key
+func EncodeCursor(sort string, key []interface{}, backward bool) string {
+	c := Cursor{Sort: sort, Key: make([]*string, len(key)), Backward: backward}
+	for i, v := range key {
+		c.Key[i] = keyText(v)
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses an opaque cursor and checks it was issued for sort
+func DecodeCursor(token, sort string) (*Cursor, *errors.LayerError) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.NewValidationError("cursor", "malformed cursor")
+	}
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil || len(c.Key) == 0 {
+		return nil, errors.NewValidationError("cursor", "malformed cursor")
+	}
+	if c.Sort != sort {
+		return nil, errors.NewValidationError("cursor", "cursor was issued for a different sort order")
+	}
+	return &c, nil
+}
+
+// Values returns the cursor key as repository keyset values
+func (c *Cursor) Values() []interface{} {
+	values := make([]interface{}, len(c.Key))
+	for i, k := range c.Key {
+		if k != nil {
+			values[i] = *k
+		}
+	}
+	return values
+}
+
+func keyText(v interface{}) *string {
+	if v == nil {
+		return nil
+	}
+	text := valueText(v)
+	return &text
+}
+
+func valueText(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	case bool:
+		return strconv.FormatBool(t)
+	case fmt.Stringer:
+		return t.String()
+	default:
+		return fmt.Sprint(t)
+	}
+}
@@ -0,0 +1,135 @@
+package query
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.MustParse("7f1d3c52-3a0e-4c1b-9d55-0c2d0b7e8a11")
+	at := time.Date(2024, 3, 1, 12, 30, 0, 500, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name     string
+		sort     string
+		key      []interface{}
+		backward bool
+		want     []interface{}
+	}{
+		{"string and uuid", "country_name", []interface{}{"Germany", id}, false, []interface{}{"Germany", id.String()}},
+		{"timestamp in UTC", "-updated_at", []interface{}{at, id}, false, []interface{}{"2024-03-01T11:30:00.0000005Z", id.String()}},
+		{"number and bool", "numeric_code,is_active", []interface{}{276, true, id}, true, []interface{}{"276", "true", id.String()}},
+		{"null stays distinct from empty", "capital_city,official_name", []interface{}{nil, "", id}, false, []interface{}{nil, "", id.String()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := EncodeCursor(tt.sort, tt.key, tt.backward)
+			cursor, err := DecodeCursor(token, tt.sort)
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if cursor.Backward != tt.backward {
+				t.Errorf("DecodeCursor() backward = %v, want %v", cursor.Backward, tt.backward)
+			}
+			if got := cursor.Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	valid := EncodeCursor("country_name", []interface{}{"Germany"}, false)
+
+	tests := []struct {
+		name  string
+		token string
+		sort  string
+	}{
+		{"not base64", "%%%", "country_name"},
+		{"not json", "bm90IGpzb24", "country_name"},
+		{"no key", EncodeCursor("country_name", nil, false), "country_name"},
+		{"other sort", valid, "-country_name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.token, tt.sort); err == nil {
+				t.Error("DecodeCursor() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestListOptions(t *testing.T) {
+	asOf := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := EncodeCursor("-country_name", []interface{}{"Germany", "7f1d3c52-3a0e-4c1b-9d55-0c2d0b7e8a11"}, false)
+	prev := EncodeCursor("", []interface{}{nil, "7f1d3c52-3a0e-4c1b-9d55-0c2d0b7e8a11"}, true)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    repositories.ListOptions
+		wantErr bool
+	}{
+		{name: "defaults", query: "", want: repositories.ListOptions{}},
+		{
+			name:  "paging, totals and deleted rows",
+			query: "limit=20&offset=40&include_total=true&include_deleted=only",
+			want:  repositories.ListOptions{Limit: 20, Offset: 40, IncludeTotal: true, Deleted: repositories.DeletedOnly},
+		},
+		{
+			name:  "filter, sort and as_of",
+			query: "filter=" + url.QueryEscape("continent_code eq 'EU'") + "&sort=-country_name&as_of=2015-01-01",
+			want: repositories.ListOptions{
+				Filters: []repositories.Filter{{Column: "continent_code", Op: repositories.OpEq, Value: "EU"}},
+				Sort:    []repositories.SortField{{Column: "country_name", Desc: true}},
+				AsOf:    &asOf,
+			},
+		},
+		{
+			name:  "cursor replaces the offset",
+			query: "sort=-country_name&offset=40&cursor=" + next,
+			want: repositories.ListOptions{
+				Sort:  []repositories.SortField{{Column: "country_name", Desc: true}},
+				After: []interface{}{"Germany", "7f1d3c52-3a0e-4c1b-9d55-0c2d0b7e8a11"},
+			},
+		},
+		{
+			name:  "backward cursor with a null key",
+			query: "cursor=" + prev,
+			want:  repositories.ListOptions{Before: []interface{}{nil, "7f1d3c52-3a0e-4c1b-9d55-0c2d0b7e8a11"}},
+		},
+		{name: "zero limit", query: "limit=0", wantErr: true},
+		{name: "negative offset", query: "offset=-1", wantErr: true},
+		{name: "bad include_deleted", query: "include_deleted=yes", wantErr: true},
+		{name: "bad as_of", query: "as_of=01/01/2015", wantErr: true},
+		{name: "cursor for another sort", query: "sort=country_code&cursor=" + next, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, verr := ListOptions(values, knownColumn)
+			if (verr != nil) != tt.wantErr {
+				t.Fatalf("ListOptions() error = %v, wantErr %v", verr, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Package query parses the list query language shared by all list endpoints:
+//
+//	?filter=continent_code eq 'EU' and numeric_code gt 100
+//	?sort=-country_name,country_code
+//	?limit=50&cursor=<opaque>
+//	?as_of=2015-01-01
+//	?include_deleted=only
+//
+// Column names are validated against the model behind each endpoint.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// ColumnChecker reports whether a column may be used in filter and sort expressions
+type ColumnChecker func(column string) bool
+
+var operators = map[string]repositories.FilterOp{
+	"eq":   repositories.OpEq,
+	"ne":   repositories.OpNe,
+	"gt":   repositories.OpGt,
+	"ge":   repositories.OpGte,
+	"lt":   repositories.OpLt,
+	"le":   repositories.OpLte,
+	"in":   repositories.OpIn,
+	"like": repositories.OpLike,
+}
+
+// ParseFilter parses expressions of the form `column op value [and ...]`.
+// Values are single-quoted strings (a doubled quote escapes a quote), numbers, true,
+// false or null; `in` takes a parenthesised list. `eq null` and `ne null`
+// become IS NULL / IS NOT NULL checks.
+func ParseFilter(expr string, valid ColumnChecker) ([]repositories.Filter, *errors.LayerError) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var filters []repositories.Filter
+	p := &parser{tokens: tokens}
+	for !p.done() {
+		if len(filters) > 0 && !p.acceptWord("and") {
+			return nil, errors.NewValidationError("filter", fmt.Sprintf("expected 'and' at %q", p.peek().text))
+		}
+
+		column := p.next()
+		if column.kind != tokenWord {
+			return nil, errors.NewValidationError("filter", fmt.Sprintf("expected column name at %q", column.text))
+		}
+		if !valid(column.text) {
+			return nil, errors.NewValidationError("filter", fmt.Sprintf("unknown column %q", column.text))
+		}
+
+		opToken := p.next()
+		op, ok := operators[strings.ToLower(opToken.text)]
+		if opToken.kind != tokenWord || !ok {
+			return nil, errors.NewValidationError("filter", fmt.Sprintf("unknown operator %q", opToken.text))
+		}
+
+		f := repositories.Filter{Column: column.text, Op: op}
+		if op == repositories.OpIn {
+			values, err := p.list()
+			if err != nil {
+				return nil, err
+			}
+			f.Value = values
+		} else {
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				switch op {
+				case repositories.OpEq:
+					f = repositories.Filter{Column: column.text, Op: repositories.OpIsNull, Value: true}
+				case repositories.OpNe:
+					f = repositories.Filter{Column: column.text, Op: repositories.OpIsNull, Value: false}
+				default:
+					return nil, errors.NewValidationError("filter", fmt.Sprintf("null cannot be used with %q", opToken.text))
+				}
+			} else {
+				f.Value = value
+			}
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// ParseSort parses a comma separated column list; a leading '-' sorts descending
+func ParseSort(expr string, valid ColumnChecker) ([]repositories.SortField, *errors.LayerError) {
+	var fields []repositories.SortField
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field := repositories.SortField{Column: strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+")}
+		field.Desc = strings.HasPrefix(part, "-")
+		if !valid(field.Column) {
+			return nil, errors.NewValidationError("sort", fmt.Sprintf("unknown column %q", field.Column))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expr string) ([]token, *errors.LayerError) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")"})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ","})
+			i++
+		case r == '\'':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					closed = true
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, errors.NewValidationError("filter", "unterminated string literal")
+			}
+			tokens = append(tokens, token{tokenString, sb.String()})
+		case r == '-' || r == '.' || unicode.IsDigit(r):
+			start := i
+			i++
+			for i < len(runes) && (runes[i] == '.' || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i])})
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[start:i])})
+		default:
+			return nil, errors.NewValidationError("filter", fmt.Sprintf("unexpected character %q", r))
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{tokenEOF, "end of filter"}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptWord(word string) bool {
+	if t := p.peek(); t.kind == tokenWord && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) value() (interface{}, *errors.LayerError) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return t.text, nil
+	case tokenNumber:
+		if n, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(t.text, 64); err == nil {
+			return f, nil
+		}
+		return nil, errors.NewValidationError("filter", fmt.Sprintf("invalid number %q", t.text))
+	case tokenWord:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	}
+	return nil, errors.NewValidationError("filter", fmt.Sprintf("expected value at %q", t.text))
+}
+
+func (p *parser) list() ([]interface{}, *errors.LayerError) {
+	if t := p.next(); t.kind != tokenLParen {
+		return nil, errors.NewValidationError("filter", fmt.Sprintf("expected '(' at %q", t.text))
+	}
+	var values []interface{}
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, errors.NewValidationError("filter", "null is not allowed in an 'in' list")
+		}
+		values = append(values, value)
+
+		switch t := p.next(); t.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return values, nil
+		default:
+			return nil, errors.NewValidationError("filter", fmt.Sprintf("expected ',' or ')' at %q", t.text))
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+)
+
+var countryColumns = map[string]bool{
+	"country_code":   true,
+	"country_name":   true,
+	"continent_code": true,
+	"numeric_code":   true,
+	"is_active":      true,
+	"capital_city":   true,
+}
+
+func knownColumn(column string) bool {
+	return countryColumns[column]
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    []repositories.Filter
+		wantErr bool
+	}{
+		{
+			name: "string equality",
+			expr: "continent_code eq 'EU'",
+			want: []repositories.Filter{{Column: "continent_code", Op: repositories.OpEq, Value: "EU"}},
+		},
+		{
+			name: "conjunction of number and boolean",
+			expr: "numeric_code gt 100 and is_active eq true",
+			want: []repositories.Filter{
+				{Column: "numeric_code", Op: repositories.OpGt, Value: int64(100)},
+				{Column: "is_active", Op: repositories.OpEq, Value: true},
+			},
+		},
+		{
+			name: "operators and keywords are case insensitive",
+			expr: "numeric_code GE 1.5 AND is_active Eq FALSE",
+			want: []repositories.Filter{
+				{Column: "numeric_code", Op: repositories.OpGte, Value: 1.5},
+				{Column: "is_active", Op: repositories.OpEq, Value: false},
+			},
+		},
+		{
+			name: "doubled quote escapes a quote",
+			expr: "country_name eq 'Cote d''Ivoire'",
+			want: []repositories.Filter{{Column: "country_name", Op: repositories.OpEq, Value: "Cote d'Ivoire"}},
+		},
+		{
+			name: "negative number",
+			expr: "numeric_code lt -1",
+			want: []repositories.Filter{{Column: "numeric_code", Op: repositories.OpLt, Value: int64(-1)}},
+		},
+		{
+			name: "in list",
+			expr: "country_code in ('DE', 'FR')",
+			want: []repositories.Filter{{Column: "country_code", Op: repositories.OpIn, Value: []interface{}{"DE", "FR"}}},
+		},
+		{
+			name: "eq null is an IS NULL check",
+			expr: "capital_city eq null",
+			want: []repositories.Filter{{Column: "capital_city", Op: repositories.OpIsNull, Value: true}},
+		},
+		{
+			name: "ne null is an IS NOT NULL check",
+			expr: "capital_city ne null",
+			want: []repositories.Filter{{Column: "capital_city", Op: repositories.OpIsNull, Value: false}},
+		},
+		{
+			name: "like",
+			expr: "country_name like 'Ger%'",
+			want: []repositories.Filter{{Column: "country_name", Op: repositories.OpLike, Value: "Ger%"}},
+		},
+		{name: "unknown column", expr: "population gt 1", wantErr: true},
+		{name: "unknown operator", expr: "country_code is 'DE'", wantErr: true},
+		{name: "missing and", expr: "country_code eq 'DE' country_name eq 'Germany'", wantErr: true},
+		{name: "missing value", expr: "country_code eq", wantErr: true},
+		{name: "unterminated string", expr: "country_code eq 'DE", wantErr: true},
+		{name: "null with an ordering operator", expr: "numeric_code gt null", wantErr: true},
+		{name: "null in an in list", expr: "country_code in ('DE', null)", wantErr: true},
+		{name: "unclosed in list", expr: "country_code in ('DE' 'FR')", wantErr: true},
+		{name: "unexpected character", expr: "country_code = 'DE'", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilter(tt.expr, knownColumn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFilter() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    []repositories.SortField
+		wantErr bool
+	}{
+		{"single ascending", "country_name", []repositories.SortField{{Column: "country_name"}}, false},
+		{"explicit ascending", "+country_name", []repositories.SortField{{Column: "country_name"}}, false},
+		{"descending then ascending", "-numeric_code, country_code", []repositories.SortField{{Column: "numeric_code", Desc: true}, {Column: "country_code"}}, false},
+		{"empty parts are skipped", "country_code,,", []repositories.SortField{{Column: "country_code"}}, false},
+		{"unknown column", "-population", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.expr, knownColumn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
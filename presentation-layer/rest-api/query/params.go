@@ -0,0 +1,118 @@
+package query
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// ListOptions reads limit, offset, cursor, filter, sort, include_total,
+// include_deleted and as_of from the query string
+func ListOptions(values url.Values, valid ColumnChecker) (repositories.ListOptions, *errors.LayerError) {
+	var opts repositories.ListOptions
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return opts, errors.NewValidationError("limit", "must be a positive integer")
+		}
+		opts.Limit = limit
+	}
+	if raw := values.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return opts, errors.NewValidationError("offset", "must be a non-negative integer")
+		}
+		opts.Offset = offset
+	}
+	opts.IncludeTotal = values.Get("include_total") == "true"
+
+	switch raw := values.Get("include_deleted"); raw {
+	case "", "false":
+	case "true":
+		opts.Deleted = repositories.DeletedInclude
+	case "only":
+		opts.Deleted = repositories.DeletedOnly
+	default:
+		return opts, errors.NewValidationError("include_deleted", "must be true, false or only")
+	}
+
+	if raw := values.Get("as_of"); raw != "" {
+		asOf, err := ParseAsOf(raw)
+		if err != nil {
+			return opts, err
+		}
+		opts.AsOf = &asOf
+	}
+
+	if raw := values.Get("filter"); raw != "" {
+		filters, err := ParseFilter(raw, valid)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filters = filters
+	}
+
+	sort := values.Get("sort")
+	if sort != "" {
+		fields, err := ParseSort(sort, valid)
+		if err != nil {
+			return opts, err
+		}
+		opts.Sort = fields
+	}
+
+	if raw := values.Get("cursor"); raw != "" {
+		cursor, err := DecodeCursor(raw, sort)
+		if err != nil {
+			return opts, err
+		}
+		if cursor.Backward {
+			opts.Before = cursor.Values()
+		} else {
+			opts.After = cursor.Values()
+		}
+		opts.Offset = 0
+	}
+
+	return opts, nil
+}
+
+// ParseAsOf parses an as_of date (YYYY-MM-DD or an RFC 3339 timestamp)
+func ParseAsOf(raw string) (time.Time, *errors.LayerError) {
+	if t, err := time.Parse(repositories.DateLayout, raw); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.NewValidationError("as_of", "must be a date in YYYY-MM-DD format")
+}
+
+// Links builds self/next/prev links for a page, replacing the cursor (and
+// dropping the offset) of the request URL
+func Links[T any](u *url.URL, page *repositories.Page[T]) map[string]string {
+	links := map[string]string{"self": u.RequestURI()}
+	sort := u.Query().Get("sort")
+
+	if page.HasMore && len(page.NextKey) > 0 {
+		links["next"] = withCursor(u, EncodeCursor(sort, page.NextKey, false))
+	}
+	if page.HasPrev && len(page.PrevKey) > 0 {
+		links["prev"] = withCursor(u, EncodeCursor(sort, page.PrevKey, true))
+	}
+	return links
+}
+
+func withCursor(u *url.URL, cursor string) string {
+	q := u.Query()
+	q.Del("offset")
+	q.Set("cursor", cursor)
+	next := *u
+	next.RawQuery = q.Encode()
+	return strings.TrimSuffix(next.RequestURI(), "?")
+}
@@ -0,0 +1,34 @@
+package query
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAsOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    time.Time
+		wantErr bool
+	}{
+		{"date", "1990-10-03", time.Date(1990, 10, 3, 0, 0, 0, 0, time.UTC), false},
+		{"timestamp", "1990-10-03T00:00:00Z", time.Date(1990, 10, 3, 0, 0, 0, 0, time.UTC), false},
+		{"timestamp with offset", "1990-10-03T01:00:00+01:00", time.Date(1990, 10, 3, 0, 0, 0, 0, time.UTC), false},
+		{"day first", "03-10-1990", time.Time{}, true},
+		{"no day", "1990-10", time.Time{}, true},
+		{"impossible date", "1990-02-30", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAsOf(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAsOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseAsOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
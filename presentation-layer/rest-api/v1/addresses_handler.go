@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// CountryAddressFormatHandler handles the address format of a country,
+// /countries/{code}/address-format
+type CountryAddressFormatHandler struct {
+	repo        *repositories.AddressFormatRepository
+	countryRepo *repositories.CountryRepository
+}
+
+func NewCountryAddressFormatHandler(repo *repositories.AddressFormatRepository, countryRepo *repositories.CountryRepository) *CountryAddressFormatHandler {
+	return &CountryAddressFormatHandler{repo: repo, countryRepo: countryRepo}
+}
+
+// Get serves GET /countries/{code}/address-format
+func (h *CountryAddressFormatHandler) Get(c *gin.Context) {
+	format, ok := h.format(c)
+	if !ok {
+		return
+	}
+	middleware.SetETag(c, format.Version)
+	c.JSON(http.StatusOK, format)
+}
+
+// Create serves POST /countries/{code}/address-format
+func (h *CountryAddressFormatHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var format models.AddressFormat
+	if err := c.ShouldBindJSON(&format); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return
+	}
+	format.CountryID = country.CountryID
+	if err := h.repo.Create(c.Request.Context(), tenantID, &format); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, format.Version)
+	c.JSON(http.StatusCreated, format)
+}
+
+// Update serves PUT /countries/{code}/address-format
+func (h *CountryAddressFormatHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.format(c)
+	if !ok {
+		return
+	}
+	format := current.AddressFormat
+	version, ok := bindUpdate(c, &format, current.Version)
+	if !ok {
+		return
+	}
+	format.CountryID = current.CountryID
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.AddressFormatID, &format, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, format.Version)
+	c.JSON(http.StatusOK, format)
+}
+
+// Delete serves DELETE /countries/{code}/address-format
+func (h *CountryAddressFormatHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	format, ok := h.format(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, format.AddressFormatID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "address format deleted"})
+}
+
+// format loads the address format of the country addressed by the code
+// parameter, writing the error response when it cannot
+func (h *CountryAddressFormatHandler) format(c *gin.Context) (*repositories.AddressFormatView, bool) {
+	format, err := h.repo.ForCountry(c.Request.Context(), c.GetString("tenant_id"), c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if format == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "address format not found"})
+		return nil, false
+	}
+	return format, true
+}
+
+// AddressesHandler lays out structured addresses for their country
+type AddressesHandler struct {
+	countryRepo *repositories.CountryRepository
+	svc         *applicationservices.AddressAppService
+}
+
+func NewAddressesHandler(countryRepo *repositories.CountryRepository, svc *applicationservices.AddressAppService) *AddressesHandler {
+	return &AddressesHandler{countryRepo: countryRepo, svc: svc}
+}
+
+// addressRequest is an address with the ISO code of its country
+type addressRequest struct {
+	CountryCode string `json:"country_code" binding:"required"`
+	applicationservices.Address
+}
+
+// Action serves the collection custom method POST /addresses:render, which
+// returns the lines of an address and what is missing or invalid in it
+func (h *AddressesHandler) Action(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	if c.Param("action") != ":render" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown action, expected :render"})
+		return
+	}
+	var req addressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, strings.ToUpper(req.CountryCode))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return
+	}
+	result, err := h.svc.Render(c.Request.Context(), tenantID, country, req.Address)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// CountryBordersHandler handles the borders of a country and queries over
+// the border graph
+type CountryBordersHandler struct {
+	repo        *repositories.CountryBorderRepository
+	countryRepo *repositories.CountryRepository
+	svc         *applicationservices.BorderAppService
+}
+
+func NewCountryBordersHandler(repo *repositories.CountryBorderRepository, countryRepo *repositories.CountryRepository, svc *applicationservices.BorderAppService) *CountryBordersHandler {
+	return &CountryBordersHandler{repo: repo, countryRepo: countryRepo, svc: svc}
+}
+
+// countryBorderRequest is a border whose neighbor may be given by code
+type countryBorderRequest struct {
+	models.CountryBorder
+	NeighborCode string `json:"neighbor_code"`
+}
+
+// Neighbors serves GET /countries/{code}/neighbors, optionally of one
+// ?type=LAND or MARITIME
+func (h *CountryBordersHandler) Neighbors(c *gin.Context) {
+	country, ok := h.country(c, c.Param("code"))
+	if !ok {
+		return
+	}
+	borderType := strings.ToUpper(c.Query("type"))
+	borders, err := h.svc.Neighbors(c.Request.Context(), c.GetString("tenant_id"), country, borderType)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "neighbors": borders, "count": len(borders)})
+}
+
+// Reachable serves GET /countries/{code}/reachable?borders=N, the countries
+// at most N borders away, optionally over borders of one ?type=
+func (h *CountryBordersHandler) Reachable(c *gin.Context) {
+	borders, err := strconv.Atoi(c.Query("borders"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "borders must be a whole number", "code": "VALIDATION_FAILED"})
+		return
+	}
+	country, ok := h.country(c, c.Param("code"))
+	if !ok {
+		return
+	}
+	borderType := strings.ToUpper(c.Query("type"))
+	steps, err := h.svc.Within(c.Request.Context(), c.GetString("tenant_id"), country, borders, borderType)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "borders": borders, "countries": steps, "count": len(steps)})
+}
+
+// LandRoute serves GET /countries/{code}/land-route?to=XX, the land route to
+// another country crossing the fewest borders
+func (h *CountryBordersHandler) LandRoute(c *gin.Context) {
+	if c.Query("to") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to is required", "code": "VALIDATION_FAILED"})
+		return
+	}
+	from, ok := h.country(c, c.Param("code"))
+	if !ok {
+		return
+	}
+	to, ok := h.country(c, c.Query("to"))
+	if !ok {
+		return
+	}
+	route, err := h.svc.Route(c.Request.Context(), c.GetString("tenant_id"), from, to)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, route)
+}
+
+// Create serves POST /countries/{code}/borders
+func (h *CountryBordersHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var req countryBorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, ok := h.country(c, c.Param("code"))
+	if !ok {
+		return
+	}
+	if req.NeighborCode != "" {
+		neighbor, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, req.NeighborCode)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if neighbor == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "neighbor country not found", "code": "VALIDATION_FAILED"})
+			return
+		}
+		req.NeighborCountryID = neighbor.CountryID
+	}
+	border := req.CountryBorder
+	border.CountryID = country.CountryID
+	if err := h.repo.Create(c.Request.Context(), tenantID, &border); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, border.Version)
+	c.JSON(http.StatusCreated, border)
+}
+
+// Update serves PUT /countries/{code}/borders/{id}. The countries of a
+// border cannot change; delete it and create another instead.
+func (h *CountryBordersHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.border(c)
+	if !ok {
+		return
+	}
+	req := countryBorderRequest{CountryBorder: *current}
+	version, ok := bindUpdate(c, &req, current.Version)
+	if !ok {
+		return
+	}
+	border := req.CountryBorder
+	border.CountryID = current.CountryID
+	border.NeighborCountryID = current.NeighborCountryID
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.CountryBorderID, &border, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, border.Version)
+	c.JSON(http.StatusOK, border)
+}
+
+// Delete serves DELETE /countries/{code}/borders/{id}
+func (h *CountryBordersHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	border, ok := h.border(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, border.CountryBorderID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "country border deleted"})
+}
+
+// country loads a country by code, writing the error response when it
+// cannot
+func (h *CountryBordersHandler) country(c *gin.Context, code string) (*models.Country, bool) {
+	tenantID := c.GetString("tenant_id")
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, code)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return nil, false
+	}
+	return country, true
+}
+
+// border loads the border addressed by the id parameter, which must be one
+// of the country addressed by the code parameter, on either side
+func (h *CountryBordersHandler) border(c *gin.Context) (*models.CountryBorder, bool) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return nil, false
+	}
+	country, ok := h.country(c, c.Param("code"))
+	if !ok {
+		return nil, false
+	}
+	border, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if border == nil || (border.CountryID != country.CountryID && border.NeighborCountryID != country.CountryID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country border not found"})
+		return nil, false
+	}
+	return border, true
+}
@@ -2,29 +2,54 @@ package v1
 
 import (
 	"net/http"
+	"strings"
+	"time"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
 	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/i18n"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
 )
 
 // RegionsHandler handles region endpoints
 type RegionsHandler struct {
-	repo *repositories.RegionRepository
+	repo        *repositories.RegionRepository
+	countryRepo *repositories.CountryRepository
+	names       *applicationservices.LocalizationAppService
 }
 
-func NewRegionsHandler(repo *repositories.RegionRepository) *RegionsHandler {
-	return &RegionsHandler{repo: repo}
+func NewRegionsHandler(repo *repositories.RegionRepository, countryRepo *repositories.CountryRepository, names *applicationservices.LocalizationAppService) *RegionsHandler {
+	return &RegionsHandler{repo: repo, countryRepo: countryRepo, names: names}
 }
 
 func (h *RegionsHandler) GetAll(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
-	regions, err := h.repo.GetAll(c.Request.Context(), tenantID)
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+	body := pageResponse(c, "regions", page)
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"regions": regions, "count": len(regions)})
+	if locale != "" {
+		localized, err := h.names.Regions(c.Request.Context(), tenantID, locale, page.Items)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body["regions"] = localized
+	}
+	c.JSON(http.StatusOK, body)
 }
 
 func (h *RegionsHandler) GetByCode(c *gin.Context) {
@@ -32,13 +57,27 @@ func (h *RegionsHandler) GetByCode(c *gin.Context) {
 	code := c.Param("code")
 	region, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 	if region == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "region not found"})
 		return
 	}
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
+		return
+	}
+	middleware.SetLocalizedETag(c, region.Version, locale)
+	if locale != "" {
+		localized, err := h.names.Regions(c.Request.Context(), tenantID, locale, []models.Region{*region})
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, localized[0])
+		return
+	}
 	c.JSON(http.StatusOK, region)
 }
 
@@ -50,55 +89,187 @@ func (h *RegionsHandler) Create(c *gin.Context) {
 		return
 	}
 	if err := h.repo.Create(c.Request.Context(), tenantID, &region); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, region.Version)
 	c.JSON(http.StatusCreated, region)
 }
 
 func (h *RegionsHandler) Update(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
 	code := c.Param("code")
-	var region models.Region
-	if err := c.ShouldBindJSON(&region); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	region, ok := h.region(c)
+	if !ok {
+		return
+	}
+	version, ok := bindUpdate(c, region, region.Version)
+	if !ok {
 		return
 	}
 	region.RegionCode = code
-	if err := h.repo.Update(c.Request.Context(), tenantID, &region); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Update(c.Request.Context(), tenantID, code, region, version); err != nil {
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, region.Version)
 	c.JSON(http.StatusOK, region)
 }
 
 func (h *RegionsHandler) Delete(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
 	code := c.Param("code")
-	if err := h.repo.Delete(c.Request.Context(), tenantID, code); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Delete(c.Request.Context(), tenantID, code, version); err != nil {
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "region deleted"})
 }
 
+// Children lists the direct sub-regions of a region
+func (h *RegionsHandler) Children(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	region, ok := h.region(c)
+	if !ok {
+		return
+	}
+	children, err := h.repo.Children(c.Request.Context(), tenantID, region.RegionID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"region": region.RegionCode, "children": children, "count": len(children)})
+}
+
+// Descendants lists every region below a region with its depth
+func (h *RegionsHandler) Descendants(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	region, ok := h.region(c)
+	if !ok {
+		return
+	}
+	descendants, err := h.repo.Descendants(c.Request.Context(), tenantID, region.RegionID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"region": region.RegionCode, "descendants": descendants, "count": len(descendants)})
+}
+
+// Ancestors lists the regions above a region, nearest first
+func (h *RegionsHandler) Ancestors(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	region, ok := h.region(c)
+	if !ok {
+		return
+	}
+	ancestors, err := h.repo.Ancestors(c.Request.Context(), tenantID, region.RegionID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"region": region.RegionCode, "ancestors": ancestors, "count": len(ancestors)})
+}
+
+// Countries lists the countries of a region and of all its sub-regions,
+// accepting the usual list parameters
+func (h *RegionsHandler) Countries(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	region, ok := h.region(c)
+	if !ok {
+		return
+	}
+	opts, verr := listOptionsFromQuery(c, h.countryRepo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	ids, err := h.repo.SubtreeIDs(c.Request.Context(), tenantID, region.RegionID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	opts.Filters = append(opts.Filters, repositories.Filter{Column: "region_id", Op: repositories.OpIn, Value: ids})
+	page, err := h.countryRepo.List(c.Request.Context(), tenantID, opts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pageResponse(c, "countries", page))
+}
+
+// Action serves the collection custom methods, currently GET /regions:tree
+func (h *RegionsHandler) Action(c *gin.Context) {
+	if c.Param("action") != ":tree" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown action, expected :tree"})
+		return
+	}
+	tenantID := c.GetString("tenant_id")
+	tree, err := h.repo.Tree(c.Request.Context(), tenantID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"regions": tree, "count": len(tree)})
+}
+
+// region loads the region named by the code parameter, responding 404 when
+// it does not exist
+func (h *RegionsHandler) region(c *gin.Context) (*models.Region, bool) {
+	region, err := h.repo.GetByCode(c.Request.Context(), c.GetString("tenant_id"), c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if region == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "region not found"})
+		return nil, false
+	}
+	return region, true
+}
+
 // LanguagesHandler handles language endpoints
 type LanguagesHandler struct {
-	repo *repositories.LanguageRepository
+	repo     *repositories.LanguageRepository
+	linkRepo *repositories.CountryLanguageRepository
+	names    *applicationservices.LocalizationAppService
 }
 
-func NewLanguagesHandler(repo *repositories.LanguageRepository) *LanguagesHandler {
-	return &LanguagesHandler{repo: repo}
+func NewLanguagesHandler(repo *repositories.LanguageRepository, linkRepo *repositories.CountryLanguageRepository, names *applicationservices.LocalizationAppService) *LanguagesHandler {
+	return &LanguagesHandler{repo: repo, linkRepo: linkRepo, names: names}
 }
 
 func (h *LanguagesHandler) GetAll(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
-	languages, err := h.repo.GetAll(c.Request.Context(), tenantID)
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+	body := pageResponse(c, "languages", page)
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"languages": languages, "count": len(languages)})
+	if locale != "" {
+		localized, err := h.names.Languages(c.Request.Context(), tenantID, locale, page.Items)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body["languages"] = localized
+	}
+	c.JSON(http.StatusOK, body)
 }
 
 func (h *LanguagesHandler) GetByCode(c *gin.Context) {
@@ -106,13 +277,27 @@ func (h *LanguagesHandler) GetByCode(c *gin.Context) {
 	code := c.Param("code")
 	language, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 	if language == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "language not found"})
 		return
 	}
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
+		return
+	}
+	middleware.SetLocalizedETag(c, language.Version, locale)
+	if locale != "" {
+		localized, err := h.names.Languages(c.Request.Context(), tenantID, locale, []models.Language{*language})
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, localized[0])
+		return
+	}
 	c.JSON(http.StatusOK, language)
 }
 
@@ -124,55 +309,98 @@ func (h *LanguagesHandler) Create(c *gin.Context) {
 		return
 	}
 	if err := h.repo.Create(c.Request.Context(), tenantID, &language); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, language.Version)
 	c.JSON(http.StatusCreated, language)
 }
 
 func (h *LanguagesHandler) Update(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
 	code := c.Param("code")
-	var language models.Language
-	if err := c.ShouldBindJSON(&language); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	language, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if language == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "language not found"})
+		return
+	}
+	version, ok := bindUpdate(c, language, language.Version)
+	if !ok {
 		return
 	}
 	language.LanguageCode = code
-	if err := h.repo.Update(c.Request.Context(), tenantID, &language); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Update(c.Request.Context(), tenantID, code, language, version); err != nil {
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, language.Version)
 	c.JSON(http.StatusOK, language)
 }
 
 func (h *LanguagesHandler) Delete(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
 	code := c.Param("code")
-	if err := h.repo.Delete(c.Request.Context(), tenantID, code); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Delete(c.Request.Context(), tenantID, code, version); err != nil {
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "language deleted"})
 }
 
+// Countries serves GET /languages/{code}/countries, listing the countries
+// where a language is spoken with its status there
+func (h *LanguagesHandler) Countries(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	code := c.Param("code")
+	language, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if language == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "language not found"})
+		return
+	}
+	countries, err := h.linkRepo.ForLanguage(c.Request.Context(), tenantID, language.LanguageID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"language": language.LanguageCode, "countries": countries, "count": len(countries)})
+}
+
 // TimezonesHandler handles timezone endpoints
 type TimezonesHandler struct {
 	repo *repositories.TimezoneRepository
+	svc  *applicationservices.TimezoneAppService
 }
 
-func NewTimezonesHandler(repo *repositories.TimezoneRepository) *TimezonesHandler {
-	return &TimezonesHandler{repo: repo}
+func NewTimezonesHandler(repo *repositories.TimezoneRepository, svc *applicationservices.TimezoneAppService) *TimezonesHandler {
+	return &TimezonesHandler{repo: repo, svc: svc}
 }
 
 func (h *TimezonesHandler) GetAll(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
-	timezones, err := h.repo.GetAll(c.Request.Context(), tenantID)
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"timezones": timezones, "count": len(timezones)})
+	c.JSON(http.StatusOK, pageResponse(c, "timezones", page))
 }
 
 func (h *TimezonesHandler) GetByCode(c *gin.Context) {
@@ -180,13 +408,14 @@ func (h *TimezonesHandler) GetByCode(c *gin.Context) {
 	code := c.Param("code")
 	timezone, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 	if timezone == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "timezone not found"})
 		return
 	}
+	middleware.SetETag(c, timezone.Version)
 	c.JSON(http.StatusOK, timezone)
 }
 
@@ -198,55 +427,116 @@ func (h *TimezonesHandler) Create(c *gin.Context) {
 		return
 	}
 	if err := h.repo.Create(c.Request.Context(), tenantID, &timezone); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, timezone.Version)
 	c.JSON(http.StatusCreated, timezone)
 }
 
 func (h *TimezonesHandler) Update(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
 	code := c.Param("code")
-	var timezone models.Timezone
-	if err := c.ShouldBindJSON(&timezone); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	timezone, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if timezone == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "timezone not found"})
+		return
+	}
+	version, ok := bindUpdate(c, timezone, timezone.Version)
+	if !ok {
 		return
 	}
 	timezone.TimezoneCode = code
-	if err := h.repo.Update(c.Request.Context(), tenantID, &timezone); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Update(c.Request.Context(), tenantID, code, timezone, version); err != nil {
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, timezone.Version)
 	c.JSON(http.StatusOK, timezone)
 }
 
 func (h *TimezonesHandler) Delete(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
 	code := c.Param("code")
-	if err := h.repo.Delete(c.Request.Context(), tenantID, code); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Delete(c.Request.Context(), tenantID, code, version); err != nil {
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "timezone deleted"})
 }
 
+// Action serves the collection custom methods, currently
+// GET /timezones:resolve?zone=America/New_York&at=2025-03-09T06:30:00Z. It
+// returns the offset, abbreviation and daylight saving state of the zone at
+// the instant at (default now) and the next transition.
+func (h *TimezonesHandler) Action(c *gin.Context) {
+	if c.Param("action") != ":resolve" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown action, expected :resolve"})
+		return
+	}
+	at := time.Now()
+	if raw := c.Query("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at must be an RFC 3339 instant", "code": "VALIDATION_FAILED"})
+			return
+		}
+		at = parsed
+	}
+	resolution, err := h.svc.Resolve(c.Request.Context(), c.Query("zone"), at)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resolution)
+}
+
 // SubdivisionsHandler handles subdivision endpoints
 type SubdivisionsHandler struct {
-	repo *repositories.SubdivisionRepository
+	repo        *repositories.SubdivisionRepository
+	countryRepo *repositories.CountryRepository
+	names       *applicationservices.LocalizationAppService
 }
 
-func NewSubdivisionsHandler(repo *repositories.SubdivisionRepository) *SubdivisionsHandler {
-	return &SubdivisionsHandler{repo: repo}
+func NewSubdivisionsHandler(repo *repositories.SubdivisionRepository, countryRepo *repositories.CountryRepository, names *applicationservices.LocalizationAppService) *SubdivisionsHandler {
+	return &SubdivisionsHandler{repo: repo, countryRepo: countryRepo, names: names}
 }
 
 func (h *SubdivisionsHandler) GetAll(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
-	subdivisions, err := h.repo.GetAll(c.Request.Context(), tenantID)
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"subdivisions": subdivisions, "count": len(subdivisions)})
+	body := pageResponse(c, "subdivisions", page)
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
+		return
+	}
+	if locale != "" {
+		localized, err := h.names.Subdivisions(c.Request.Context(), tenantID, locale, page.Items)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body["subdivisions"] = localized
+	}
+	c.JSON(http.StatusOK, body)
 }
 
 func (h *SubdivisionsHandler) GetByCountry(c *gin.Context) {
@@ -257,12 +547,30 @@ func (h *SubdivisionsHandler) GetByCountry(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid country ID"})
 		return
 	}
-	subdivisions, err := h.repo.GetByCountry(c.Request.Context(), tenantID, countryID)
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.GetByCountry(c.Request.Context(), tenantID, countryID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+	body := pageResponse(c, "subdivisions", page)
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"subdivisions": subdivisions, "count": len(subdivisions)})
+	if locale != "" {
+		localized, err := h.names.Subdivisions(c.Request.Context(), tenantID, locale, page.Items)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body["subdivisions"] = localized
+	}
+	c.JSON(http.StatusOK, body)
 }
 
 func (h *SubdivisionsHandler) Create(c *gin.Context) {
@@ -273,65 +581,198 @@ func (h *SubdivisionsHandler) Create(c *gin.Context) {
 		return
 	}
 	if err := h.repo.Create(c.Request.Context(), tenantID, &subdivision); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, subdivision.Version)
 	c.JSON(http.StatusCreated, subdivision)
 }
 
 func (h *SubdivisionsHandler) Update(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
 		return
 	}
-	var subdivision models.CountrySubdivision
-	if err := c.ShouldBindJSON(&subdivision); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	subdivision, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if subdivision == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "subdivision not found"})
 		return
 	}
-	subdivision.SubdivisionID = id
-	if err := h.repo.Update(c.Request.Context(), tenantID, &subdivision); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, ok := bindUpdate(c, subdivision, subdivision.Version)
+	if !ok {
 		return
 	}
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, id, subdivision, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, subdivision.Version)
 	c.JSON(http.StatusOK, subdivision)
 }
 
 func (h *SubdivisionsHandler) Delete(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
 		return
 	}
-	if err := h.repo.Delete(c.Request.Context(), tenantID, id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, id, version); err != nil {
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "subdivision deleted"})
 }
 
+// ByCountryCode serves GET /countries/{code}/subdivisions?depth=, listing
+// the subdivision hierarchy of a country level by level. depth limits the
+// number of levels; it defaults to all of them.
+func (h *SubdivisionsHandler) ByCountryCode(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	depth, verr := depthFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	code := c.Param("code")
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return
+	}
+	subdivisions, err := h.repo.CountryTree(c.Request.Context(), tenantID, country.CountryID, depth)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "subdivisions": subdivisions, "count": len(subdivisions)})
+}
+
+// Descendants lists the subdivisions below a subdivision, limited by depth
+func (h *SubdivisionsHandler) Descendants(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	depth, verr := depthFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	descendants, err := h.repo.Descendants(c.Request.Context(), tenantID, id, depth)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subdivision_id": id, "descendants": descendants, "count": len(descendants)})
+}
+
+// Ancestors lists the subdivisions above a subdivision, nearest first
+func (h *SubdivisionsHandler) Ancestors(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	ancestors, err := h.repo.Ancestors(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subdivision_id": id, "ancestors": ancestors, "count": len(ancestors)})
+}
+
+// Periods lists the names and types a subdivision carried over time, oldest
+// first
+func (h *SubdivisionsHandler) Periods(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	periods, err := h.repo.Periods(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subdivision_id": id, "periods": periods, "count": len(periods)})
+}
+
+// RecordPeriod starts a new naming period for a subdivision, closing the open
+// one on its valid_from
+func (h *SubdivisionsHandler) RecordPeriod(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	subdivision, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if subdivision == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "subdivision not found"})
+		return
+	}
+	var period models.SubdivisionPeriod
+	if err := c.ShouldBindJSON(&period); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	period.SubdivisionID = id
+	if err := h.repo.RecordPeriod(c.Request.Context(), tenantID, &period); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, period)
+}
+
 // LocalesHandler handles locale endpoints
 type LocalesHandler struct {
-	repo *repositories.LocaleRepository
+	repo         *repositories.LocaleRepository
+	currencyRepo *repositories.CurrencyRepository
+	formats      *applicationservices.LocaleFormatAppService
 }
 
-func NewLocalesHandler(repo *repositories.LocaleRepository) *LocalesHandler {
-	return &LocalesHandler{repo: repo}
+func NewLocalesHandler(repo *repositories.LocaleRepository, currencyRepo *repositories.CurrencyRepository, formats *applicationservices.LocaleFormatAppService) *LocalesHandler {
+	return &LocalesHandler{repo: repo, currencyRepo: currencyRepo, formats: formats}
 }
 
 func (h *LocalesHandler) GetAll(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
-	locales, err := h.repo.GetAll(c.Request.Context(), tenantID)
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"locales": locales, "count": len(locales)})
+	c.JSON(http.StatusOK, pageResponse(c, "locales", page))
 }
 
 func (h *LocalesHandler) GetByCode(c *gin.Context) {
@@ -339,13 +780,14 @@ func (h *LocalesHandler) GetByCode(c *gin.Context) {
 	code := c.Param("code")
 	locale, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 	if locale == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "locale not found"})
 		return
 	}
+	middleware.SetETag(c, locale.Version)
 	c.JSON(http.StatusOK, locale)
 }
 
@@ -357,34 +799,118 @@ func (h *LocalesHandler) Create(c *gin.Context) {
 		return
 	}
 	if err := h.repo.Create(c.Request.Context(), tenantID, &locale); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, locale.Version)
 	c.JSON(http.StatusCreated, locale)
 }
 
 func (h *LocalesHandler) Update(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
 	code := c.Param("code")
-	var locale models.Locales
-	if err := c.ShouldBindJSON(&locale); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	locale, err := h.repo.GetByCode(c.Request.Context(), tenantID, code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if locale == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "locale not found"})
+		return
+	}
+	version, ok := bindUpdate(c, locale, locale.Version)
+	if !ok {
 		return
 	}
 	locale.LocaleCode = code
-	if err := h.repo.Update(c.Request.Context(), tenantID, &locale); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Update(c.Request.Context(), tenantID, code, locale, version); err != nil {
+		respondError(c, err)
 		return
 	}
+	middleware.SetETag(c, locale.Version)
 	c.JSON(http.StatusOK, locale)
 }
 
 func (h *LocalesHandler) Delete(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
 	code := c.Param("code")
-	if err := h.repo.Delete(c.Request.Context(), tenantID, code); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.repo.Delete(c.Request.Context(), tenantID, code, version); err != nil {
+		respondError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "locale deleted"})
-}
\ No newline at end of file
+}
+
+// Formats returns the CLDR number, currency and date formats of a locale
+func (h *LocalesHandler) Formats(c *gin.Context) {
+	code, ok := h.locale(c)
+	if !ok {
+		return
+	}
+	format, err := h.formats.Formats(c.Request.Context(), code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, format)
+}
+
+// Format formats the value query parameter for a locale, e.g.
+// GET /locales/de-DE/format?type=currency&value=1234.5&currency=EUR
+func (h *LocalesHandler) Format(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	code, ok := h.locale(c)
+	if !ok {
+		return
+	}
+	req := applicationservices.FormatRequest{
+		Type:     c.Query("type"),
+		Value:    c.Query("value"),
+		Currency: strings.ToUpper(c.Query("currency")),
+		Style:    c.Query("style"),
+		Zone:     c.Query("zone"),
+	}
+	if req.Type == applicationservices.FormatTypeCurrency && req.Currency != "" {
+		// Amounts show the minor units of the currency, two when unknown
+		req.CurrencyDigits = 2
+		currency, err := h.currencyRepo.GetByCode(c.Request.Context(), tenantID, req.Currency)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if currency != nil && currency.MinorUnits != nil {
+			req.CurrencyDigits = int(*currency.MinorUnits)
+		}
+	}
+	result, err := h.formats.Format(c.Request.Context(), code, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// locale resolves the :code parameter to the canonical tag of an existing
+// locale, writing the error response when it is unknown
+func (h *LocalesHandler) locale(c *gin.Context) (string, bool) {
+	code, err := i18n.CanonicalTag(c.Param("code"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": "VALIDATION_FAILED"})
+		return "", false
+	}
+	locale, err := h.repo.GetByCode(c.Request.Context(), c.GetString("tenant_id"), code)
+	if err != nil {
+		respondError(c, err)
+		return "", false
+	}
+	if locale == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "locale not found"})
+		return "", false
+	}
+	return code, true
+}
@@ -7,38 +7,60 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
 	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
 	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
 )
 
 type CountriesHandler struct {
 	countryService *applicationservices.CountryAppService
+	countries      *repositories.CountryRepository
+	names          *applicationservices.LocalizationAppService
 	logger         logging.Logger
 }
 
 func NewCountriesHandler(
 	countryService *applicationservices.CountryAppService,
+	countries *repositories.CountryRepository,
+	names *applicationservices.LocalizationAppService,
 	logger logging.Logger,
 ) *CountriesHandler {
 	return &CountriesHandler{
 		countryService: countryService,
+		countries:      countries,
+		names:          names,
 		logger:         logger,
 	}
 }
 
-// GetAllCountries handles GET /countries
+// GetAllCountries handles GET /countries, returning a page of countries
+// named in the locale negotiated from Accept-Language
 func (h *CountriesHandler) GetAllCountries(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
-	
-	countries, err := h.countryService.GetAllCountries(c.Request.Context(), tenantID)
+	opts, verr := listOptionsFromQuery(c, h.countries.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.countries.List(c.Request.Context(), tenantID, opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"countries": countries,
-		"count":     len(countries),
-	})
+	body := pageResponse(c, "countries", page)
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
+		return
+	}
+	if locale != "" {
+		localized, err := h.names.Countries(c.Request.Context(), tenantID, locale, page.Items)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body["countries"] = localized
+	}
+	c.JSON(http.StatusOK, body)
 }
 
 // CreateCountry handles POST /countries
@@ -57,29 +79,158 @@ func (h *CountriesHandler) CreateCountry(c *gin.Context) {
 		return
 	}
 
+	middleware.SetETag(c, country.Version)
 	c.JSON(http.StatusCreated, country)
 }
 
-// GetCountryByCode handles GET /countries/:code
+// GetCountryByCode handles GET /countries/:code, naming the country in the
+// locale negotiated from Accept-Language. With as_of it returns the country
+// as it was on that date, carrying the names then in force.
 func (h *CountriesHandler) GetCountryByCode(c *gin.Context) {
 	tenantID := c.GetString("tenant_id")
 	code := c.Param("code")
 
-	country, err := h.countryService.GetCountryByCode(c.Request.Context(), tenantID, code)
+	var country *models.Country
+	if c.Query("as_of") != "" {
+		asOf, verr := asOfFromQuery(c)
+		if verr != nil {
+			respondError(c, verr)
+			return
+		}
+		found, err := h.countries.GetByCodeAsOf(c.Request.Context(), tenantID, code, asOf)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if found == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "country not found on " + asOf.Format(repositories.DateLayout)})
+			return
+		}
+		country = found
+	} else {
+		found, err := h.countryService.GetCountryByCode(c.Request.Context(), tenantID, code)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		country = found
+	}
+
+	locale, ok := negotiateLocale(c, h.names)
+	if !ok {
+		return
+	}
+	middleware.SetLocalizedETag(c, country.Version, locale)
+	if locale != "" {
+		localized, err := h.names.Countries(c.Request.Context(), tenantID, locale, []models.Country{*country})
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, localized[0])
+		return
+	}
+	c.JSON(http.StatusOK, country)
+}
+
+// Periods handles GET /countries/:code/periods, listing the names the
+// country carried over time, oldest first
+func (h *CountriesHandler) Periods(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	periods, err := h.countries.Periods(c.Request.Context(), tenantID, country.CountryID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	c.JSON(http.StatusOK, gin.H{
+		"country_code": country.CountryCode,
+		"valid_from":   country.ValidFrom,
+		"valid_to":     country.ValidTo,
+		"periods":      periods,
+		"count":        len(periods),
+	})
+}
 
-	c.JSON(http.StatusOK, country)
+// RecordPeriod handles POST /countries/:code/periods, starting a new naming
+// period and closing the open one on its valid_from
+func (h *CountriesHandler) RecordPeriod(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	var period models.CountryPeriod
+	if err := c.ShouldBindJSON(&period); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	period.CountryID = country.CountryID
+	if err := h.countries.RecordPeriod(c.Request.Context(), tenantID, &period); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, period)
 }
 
-// UpdateCountry handles PUT /countries/:code
+// country loads the country named by the :code parameter, writing the error
+// response and returning false when there is none
+func (h *CountriesHandler) country(c *gin.Context) (*models.Country, bool) {
+	country, err := h.countries.GetByCode(c.Request.Context(), c.GetString("tenant_id"), c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return nil, false
+	}
+	return country, true
+}
+
+// UpdateCountry handles PUT /countries/:code; fields left out of the body
+// keep their stored values
 func (h *CountriesHandler) UpdateCountry(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	tenantID := c.GetString("tenant_id")
+	code := c.Param("code")
+
+	country, err := h.countryService.GetCountryByCode(c.Request.Context(), tenantID, code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	version, ok := bindUpdate(c, country, country.Version)
+	if !ok {
+		return
+	}
+	country.CountryCode = code
+	country.Version = version
+
+	if err := h.countryService.UpdateCountry(c.Request.Context(), tenantID, country); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	middleware.SetETag(c, country.Version)
+	c.JSON(http.StatusOK, country)
 }
 
 // DeleteCountry handles DELETE /countries/:code
 func (h *CountriesHandler) DeleteCountry(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented"})
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+
+	if err := h.countryService.DeleteCountry(c.Request.Context(), tenantID, c.Param("code"), version); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Country deleted successfully"})
 }
\ No newline at end of file
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// CountryLanguagesHandler handles the languages of a country,
+// /countries/{code}/languages
+type CountryLanguagesHandler struct {
+	repo         *repositories.CountryLanguageRepository
+	countryRepo  *repositories.CountryRepository
+	languageRepo *repositories.LanguageRepository
+}
+
+func NewCountryLanguagesHandler(repo *repositories.CountryLanguageRepository, countryRepo *repositories.CountryRepository, languageRepo *repositories.LanguageRepository) *CountryLanguagesHandler {
+	return &CountryLanguagesHandler{repo: repo, countryRepo: countryRepo, languageRepo: languageRepo}
+}
+
+// countryLanguageRequest is a link whose language may be given by code
+type countryLanguageRequest struct {
+	models.CountryLanguage
+	LanguageCode string `json:"language_code"`
+}
+
+// List serves GET /countries/{code}/languages, primary language first
+func (h *CountryLanguagesHandler) List(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	languages, err := h.repo.ForCountry(c.Request.Context(), tenantID, country.CountryID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "languages": languages, "count": len(languages)})
+}
+
+// Create serves POST /countries/{code}/languages
+func (h *CountryLanguagesHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var req countryLanguageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	if !h.resolveLanguage(c, &req) {
+		return
+	}
+	link := req.CountryLanguage
+	link.CountryID = country.CountryID
+	if err := h.repo.Create(c.Request.Context(), tenantID, &link); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, link.Version)
+	c.JSON(http.StatusCreated, link)
+}
+
+// Update serves PUT /countries/{code}/languages/{id}
+func (h *CountryLanguagesHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.link(c)
+	if !ok {
+		return
+	}
+	req := countryLanguageRequest{CountryLanguage: *current}
+	version, ok := bindUpdate(c, &req, current.Version)
+	if !ok {
+		return
+	}
+	if !h.resolveLanguage(c, &req) {
+		return
+	}
+	link := req.CountryLanguage
+	link.CountryID = current.CountryID
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.CountryLanguageID, &link, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, link.Version)
+	c.JSON(http.StatusOK, link)
+}
+
+// Delete serves DELETE /countries/{code}/languages/{id}
+func (h *CountryLanguagesHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	link, ok := h.link(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, link.CountryLanguageID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "country language deleted"})
+}
+
+// country loads the country addressed by the code parameter, writing the
+// error response when it cannot
+func (h *CountryLanguagesHandler) country(c *gin.Context) (*models.Country, bool) {
+	tenantID := c.GetString("tenant_id")
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return nil, false
+	}
+	return country, true
+}
+
+// link loads the link addressed by the id parameter, which must belong to
+// the country addressed by the code parameter
+func (h *CountryLanguagesHandler) link(c *gin.Context) (*models.CountryLanguage, bool) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return nil, false
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return nil, false
+	}
+	link, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if link == nil || link.CountryID != country.CountryID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country language not found"})
+		return nil, false
+	}
+	return link, true
+}
+
+// resolveLanguage sets the language ID of req from its language code, if
+// given
+func (h *CountryLanguagesHandler) resolveLanguage(c *gin.Context, req *countryLanguageRequest) bool {
+	if req.LanguageCode == "" {
+		return true
+	}
+	language, err := h.languageRepo.GetByCode(c.Request.Context(), c.GetString("tenant_id"), req.LanguageCode)
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	if language == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "language not found", "code": "VALIDATION_FAILED"})
+		return false
+	}
+	req.LanguageID = language.LanguageID
+	return true
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// CountryTimezonesHandler handles the time zones of a country and its
+// subdivisions, /countries/{code}/timezones
+type CountryTimezonesHandler struct {
+	repo            *repositories.CountryTimezoneRepository
+	countryRepo     *repositories.CountryRepository
+	timezoneRepo    *repositories.TimezoneRepository
+	subdivisionRepo *repositories.SubdivisionRepository
+}
+
+func NewCountryTimezonesHandler(repo *repositories.CountryTimezoneRepository, countryRepo *repositories.CountryRepository, timezoneRepo *repositories.TimezoneRepository, subdivisionRepo *repositories.SubdivisionRepository) *CountryTimezonesHandler {
+	return &CountryTimezonesHandler{repo: repo, countryRepo: countryRepo, timezoneRepo: timezoneRepo, subdivisionRepo: subdivisionRepo}
+}
+
+// countryTimezoneRequest links a zone, given by code, to a country or to one
+// of its subdivisions
+type countryTimezoneRequest struct {
+	TimezoneCode    string     `json:"timezone_code" binding:"required"`
+	SubdivisionID   *uuid.UUID `json:"subdivision_id"`
+	SubdivisionCode string     `json:"subdivision_code"`
+}
+
+// List serves GET /countries/{code}/timezones, country-wide zones first,
+// then the zones of each subdivision
+func (h *CountryTimezonesHandler) List(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	timezones, err := h.repo.ForCountry(c.Request.Context(), tenantID, country.CountryID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "timezones": timezones, "count": len(timezones)})
+}
+
+// Create serves POST /countries/{code}/timezones
+func (h *CountryTimezonesHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var req countryTimezoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	timezone, err := h.timezoneRepo.GetByCode(c.Request.Context(), tenantID, req.TimezoneCode)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if timezone == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "timezone not found", "code": "VALIDATION_FAILED"})
+		return
+	}
+	link := models.CountryTimezone{CountryID: country.CountryID, TimezoneID: timezone.TimezoneID, SubdivisionID: req.SubdivisionID}
+	if req.SubdivisionCode != "" {
+		subdivision, err := h.subdivisionRepo.GetByCountryAndCode(c.Request.Context(), tenantID, country.CountryID, req.SubdivisionCode)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if subdivision == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "subdivision not found", "code": "VALIDATION_FAILED"})
+			return
+		}
+		link.SubdivisionID = &subdivision.SubdivisionID
+	}
+	if err := h.repo.Create(c.Request.Context(), tenantID, &link); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, link.Version)
+	c.JSON(http.StatusCreated, link)
+}
+
+// Delete serves DELETE /countries/{code}/timezones/{id}
+func (h *CountryTimezonesHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	link, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if link == nil || link.CountryID != country.CountryID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country timezone not found"})
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, id, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "country timezone deleted"})
+}
+
+// country loads the country addressed by the code parameter, writing the
+// error response when it cannot
+func (h *CountryTimezonesHandler) country(c *gin.Context) (*models.Country, bool) {
+	tenantID := c.GetString("tenant_id")
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return nil, false
+	}
+	return country, true
+}
@@ -0,0 +1,313 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// CurrenciesHandler handles currency endpoints
+type CurrenciesHandler struct {
+	repo     *repositories.CurrencyRepository
+	linkRepo *repositories.CountryCurrencyRepository
+}
+
+func NewCurrenciesHandler(repo *repositories.CurrencyRepository, linkRepo *repositories.CountryCurrencyRepository) *CurrenciesHandler {
+	return &CurrenciesHandler{repo: repo, linkRepo: linkRepo}
+}
+
+func (h *CurrenciesHandler) GetAll(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pageResponse(c, "currencies", page))
+}
+
+func (h *CurrenciesHandler) GetByCode(c *gin.Context) {
+	currency, ok := h.currency(c)
+	if !ok {
+		return
+	}
+	middleware.SetETag(c, currency.Version)
+	c.JSON(http.StatusOK, currency)
+}
+
+func (h *CurrenciesHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var currency models.Currency
+	if err := c.ShouldBindJSON(&currency); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.repo.Create(c.Request.Context(), tenantID, &currency); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, currency.Version)
+	c.JSON(http.StatusCreated, currency)
+}
+
+func (h *CurrenciesHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	code := c.Param("code")
+	currency, ok := h.currency(c)
+	if !ok {
+		return
+	}
+	version, ok := bindUpdate(c, currency, currency.Version)
+	if !ok {
+		return
+	}
+	currency.CurrencyCode = code
+	if err := h.repo.Update(c.Request.Context(), tenantID, code, currency, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, currency.Version)
+	c.JSON(http.StatusOK, currency)
+}
+
+func (h *CurrenciesHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	code := c.Param("code")
+	if err := h.repo.Delete(c.Request.Context(), tenantID, code, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "currency deleted"})
+}
+
+// Countries serves GET /currencies/{code}/countries?as_of=, listing the
+// countries using a currency on as_of (default today). history=true lists
+// every period instead.
+func (h *CurrenciesHandler) Countries(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	asOf, verr := asOfFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	currency, ok := h.currency(c)
+	if !ok {
+		return
+	}
+	day := &asOf
+	if c.Query("history") == "true" {
+		day = nil
+	}
+	countries, err := h.linkRepo.ForCurrency(c.Request.Context(), tenantID, currency.CurrencyID, day)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"currency": currency.CurrencyCode, "countries": countries, "count": len(countries)})
+}
+
+// currency loads the currency addressed by the code parameter, writing the
+// error response when it cannot
+func (h *CurrenciesHandler) currency(c *gin.Context) (*models.Currency, bool) {
+	tenantID := c.GetString("tenant_id")
+	currency, err := h.repo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if currency == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "currency not found"})
+		return nil, false
+	}
+	return currency, true
+}
+
+// CountryCurrenciesHandler handles the currencies of a country,
+// /countries/{code}/currencies
+type CountryCurrenciesHandler struct {
+	repo         *repositories.CountryCurrencyRepository
+	countryRepo  *repositories.CountryRepository
+	currencyRepo *repositories.CurrencyRepository
+}
+
+func NewCountryCurrenciesHandler(repo *repositories.CountryCurrencyRepository, countryRepo *repositories.CountryRepository, currencyRepo *repositories.CurrencyRepository) *CountryCurrenciesHandler {
+	return &CountryCurrenciesHandler{repo: repo, countryRepo: countryRepo, currencyRepo: currencyRepo}
+}
+
+// countryCurrencyRequest is a link whose currency may be given by code
+type countryCurrencyRequest struct {
+	models.CountryCurrency
+	CurrencyCode string `json:"currency_code"`
+}
+
+// List serves GET /countries/{code}/currencies?as_of=, listing the
+// currencies of a country on as_of (default today). history=true lists
+// every period instead.
+func (h *CountryCurrenciesHandler) List(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	asOf, verr := asOfFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	day := &asOf
+	if c.Query("history") == "true" {
+		day = nil
+	}
+	currencies, err := h.repo.ForCountry(c.Request.Context(), tenantID, country.CountryID, day)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "currencies": currencies, "count": len(currencies)})
+}
+
+// Create serves POST /countries/{code}/currencies. Links are legal tender
+// unless is_legal_tender is false.
+func (h *CountryCurrenciesHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	legalTender := true
+	req := countryCurrencyRequest{CountryCurrency: models.CountryCurrency{IsLegalTender: &legalTender}}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	if !h.resolveCurrency(c, &req) {
+		return
+	}
+	link := req.CountryCurrency
+	link.CountryID = country.CountryID
+	if err := h.repo.Create(c.Request.Context(), tenantID, &link); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, link.Version)
+	c.JSON(http.StatusCreated, link)
+}
+
+// Update serves PUT /countries/{code}/currencies/{id}
+func (h *CountryCurrenciesHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.link(c)
+	if !ok {
+		return
+	}
+	req := countryCurrencyRequest{CountryCurrency: *current}
+	version, ok := bindUpdate(c, &req, current.Version)
+	if !ok {
+		return
+	}
+	if !h.resolveCurrency(c, &req) {
+		return
+	}
+	link := req.CountryCurrency
+	link.CountryID = current.CountryID
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.CountryCurrencyID, &link, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, link.Version)
+	c.JSON(http.StatusOK, link)
+}
+
+// Delete serves DELETE /countries/{code}/currencies/{id}
+func (h *CountryCurrenciesHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	link, ok := h.link(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, link.CountryCurrencyID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "country currency deleted"})
+}
+
+// country loads the country addressed by the code parameter, writing the
+// error response when it cannot
+func (h *CountryCurrenciesHandler) country(c *gin.Context) (*models.Country, bool) {
+	tenantID := c.GetString("tenant_id")
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return nil, false
+	}
+	return country, true
+}
+
+// link loads the link addressed by the id parameter, which must belong to
+// the country addressed by the code parameter
+func (h *CountryCurrenciesHandler) link(c *gin.Context) (*models.CountryCurrency, bool) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return nil, false
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return nil, false
+	}
+	link, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if link == nil || link.CountryID != country.CountryID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country currency not found"})
+		return nil, false
+	}
+	return link, true
+}
+
+// resolveCurrency sets the currency ID of req from its currency code, if
+// given
+func (h *CountryCurrenciesHandler) resolveCurrency(c *gin.Context, req *countryCurrencyRequest) bool {
+	if req.CurrencyCode == "" {
+		return true
+	}
+	currency, err := h.currencyRepo.GetByCode(c.Request.Context(), c.GetString("tenant_id"), req.CurrencyCode)
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	if currency == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "currency not found", "code": "VALIDATION_FAILED"})
+		return false
+	}
+	req.CurrencyID = currency.CurrencyID
+	return true
+}
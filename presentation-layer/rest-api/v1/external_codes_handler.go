@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// CrosswalkHandler translates country codes between code systems
+type CrosswalkHandler struct {
+	svc *applicationservices.CrosswalkAppService
+}
+
+func NewCrosswalkHandler(svc *applicationservices.CrosswalkAppService) *CrosswalkHandler {
+	return &CrosswalkHandler{svc: svc}
+}
+
+// crosswalkRequest is a batch of codes of one system
+type crosswalkRequest struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Codes []string `json:"codes"`
+}
+
+// Get serves GET /crosswalk?from=fips&code=GM&to=iso2&as_of=, translating
+// one code as the systems stood on as_of (default today)
+func (h *CrosswalkHandler) Get(c *gin.Context) {
+	asOf, verr := asOfFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	if c.Query("code") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "code is required", "code": "VALIDATION_FAILED"})
+		return
+	}
+	result, err := h.svc.Translate(c.Request.Context(), c.GetString("tenant_id"), c.Query("from"), c.Query("code"), c.Query("to"), asOf)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if !result.Found {
+		c.JSON(http.StatusNotFound, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Action serves the collection custom method POST /crosswalk:translate?as_of=,
+// which translates a batch of codes in order
+func (h *CrosswalkHandler) Action(c *gin.Context) {
+	if c.Param("action") != ":translate" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown action, expected :translate"})
+		return
+	}
+	asOf, verr := asOfFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	var req crosswalkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	results, err := h.svc.TranslateAll(c.Request.Context(), c.GetString("tenant_id"), req.From, req.Codes, req.To, asOf)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	found := 0
+	for _, result := range results {
+		if result.Found {
+			found++
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"results": results, "count": len(results), "found": found})
+}
+
+// CountryExternalCodesHandler handles the codes of a country in external
+// code systems, /countries/{code}/external-codes
+type CountryExternalCodesHandler struct {
+	repo        *repositories.CountryExternalCodeRepository
+	countryRepo *repositories.CountryRepository
+}
+
+func NewCountryExternalCodesHandler(repo *repositories.CountryExternalCodeRepository, countryRepo *repositories.CountryRepository) *CountryExternalCodesHandler {
+	return &CountryExternalCodesHandler{repo: repo, countryRepo: countryRepo}
+}
+
+// List serves GET /countries/{code}/external-codes?as_of=, listing the
+// codes of a country valid on as_of (default today). history=true lists
+// codes of all periods.
+func (h *CountryExternalCodesHandler) List(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	asOf, verr := asOfFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	day := &asOf
+	if c.Query("history") == "true" {
+		day = nil
+	}
+	codes, err := h.repo.ForCountry(c.Request.Context(), tenantID, country.CountryID, day)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "external_codes": codes, "count": len(codes)})
+}
+
+// Create serves POST /countries/{code}/external-codes
+func (h *CountryExternalCodesHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var code models.CountryExternalCode
+	if err := c.ShouldBindJSON(&code); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	code.CountryID = country.CountryID
+	normalizeExternalCode(&code)
+	if err := h.repo.Create(c.Request.Context(), tenantID, &code); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, code.Version)
+	c.JSON(http.StatusCreated, code)
+}
+
+// Update serves PUT /countries/{code}/external-codes/{id}
+func (h *CountryExternalCodesHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.code(c)
+	if !ok {
+		return
+	}
+	code := *current
+	version, ok := bindUpdate(c, &code, current.Version)
+	if !ok {
+		return
+	}
+	code.CountryID = current.CountryID
+	normalizeExternalCode(&code)
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.CountryExternalCodeID, &code, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, code.Version)
+	c.JSON(http.StatusOK, code)
+}
+
+// Delete serves DELETE /countries/{code}/external-codes/{id}
+func (h *CountryExternalCodesHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	code, ok := h.code(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, code.CountryExternalCodeID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "country external code deleted"})
+}
+
+// country loads the country addressed by the code parameter, writing the
+// error response when it cannot
+func (h *CountryExternalCodesHandler) country(c *gin.Context) (*models.Country, bool) {
+	tenantID := c.GetString("tenant_id")
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return nil, false
+	}
+	return country, true
+}
+
+// code loads the external code addressed by the id parameter, which must
+// belong to the country addressed by the code parameter
+func (h *CountryExternalCodesHandler) code(c *gin.Context) (*models.CountryExternalCode, bool) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return nil, false
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return nil, false
+	}
+	code, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if code == nil || code.CountryID != country.CountryID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country external code not found"})
+		return nil, false
+	}
+	return code, true
+}
+
+// normalizeExternalCode lowercases the system and uppercases the code, the
+// forms they are stored in
+func normalizeExternalCode(code *models.CountryExternalCode) {
+	code.CodeSystem = strings.ToLower(strings.TrimSpace(code.CodeSystem))
+	code.Code = strings.ToUpper(strings.TrimSpace(code.Code))
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/geo"
+)
+
+// GeoHandler reverse geocodes points with the bundled boundaries
+type GeoHandler struct {
+	svc *applicationservices.GeoAppService
+}
+
+func NewGeoHandler(svc *applicationservices.GeoAppService) *GeoHandler {
+	return &GeoHandler{svc: svc}
+}
+
+// Lookup serves GET /geo/lookup?lat=&lon=, the country and subdivision
+// containing a point. With country= it also reports whether the point lies
+// in that declared country.
+func (h *GeoHandler) Lookup(c *gin.Context) {
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lat must be a number of degrees", "code": "VALIDATION_FAILED"})
+		return
+	}
+	lon, err := strconv.ParseFloat(c.Query("lon"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lon must be a number of degrees", "code": "VALIDATION_FAILED"})
+		return
+	}
+	result, err := h.svc.Lookup(c.Request.Context(), c.GetString("tenant_id"), geo.Point{Latitude: lat, Longitude: lon}, c.Query("country"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Boundary serves GET /geo/boundaries/{code}, the simplified outline of a
+// country or subdivision as a GeoJSON Feature
+func (h *GeoHandler) Boundary(c *gin.Context) {
+	feature, err := h.svc.Boundary(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Content-Type", "application/geo+json")
+	c.JSON(http.StatusOK, feature)
+}
@@ -0,0 +1,303 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// GroupingsHandler handles groupings of countries and their members,
+// /groupings/{code}/members
+type GroupingsHandler struct {
+	repo        *repositories.GroupingRepository
+	memberRepo  *repositories.GroupingMembershipRepository
+	countryRepo *repositories.CountryRepository
+}
+
+func NewGroupingsHandler(repo *repositories.GroupingRepository, memberRepo *repositories.GroupingMembershipRepository, countryRepo *repositories.CountryRepository) *GroupingsHandler {
+	return &GroupingsHandler{repo: repo, memberRepo: memberRepo, countryRepo: countryRepo}
+}
+
+// groupingMembershipRequest is a membership whose country may be given by
+// code
+type groupingMembershipRequest struct {
+	models.GroupingMembership
+	CountryCode string `json:"country_code"`
+}
+
+func (h *GroupingsHandler) GetAll(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pageResponse(c, "groupings", page))
+}
+
+func (h *GroupingsHandler) GetByCode(c *gin.Context) {
+	grouping, ok := h.grouping(c)
+	if !ok {
+		return
+	}
+	middleware.SetETag(c, grouping.Version)
+	c.JSON(http.StatusOK, grouping)
+}
+
+func (h *GroupingsHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var grouping models.Grouping
+	if err := c.ShouldBindJSON(&grouping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.repo.Create(c.Request.Context(), tenantID, &grouping); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, grouping.Version)
+	c.JSON(http.StatusCreated, grouping)
+}
+
+func (h *GroupingsHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	code := c.Param("code")
+	grouping, ok := h.grouping(c)
+	if !ok {
+		return
+	}
+	version, ok := bindUpdate(c, grouping, grouping.Version)
+	if !ok {
+		return
+	}
+	grouping.GroupingCode = code
+	if err := h.repo.Update(c.Request.Context(), tenantID, code, grouping, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, grouping.Version)
+	c.JSON(http.StatusOK, grouping)
+}
+
+func (h *GroupingsHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	if err := h.repo.Delete(c.Request.Context(), tenantID, c.Param("code"), version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "grouping deleted"})
+}
+
+// Members serves GET /groupings/{code}/members?as_of=&type=, listing the
+// countries belonging to a grouping on as_of (default today), optionally
+// of one membership type. history=true lists every period instead.
+func (h *GroupingsHandler) Members(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	asOf, verr := asOfFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	grouping, ok := h.grouping(c)
+	if !ok {
+		return
+	}
+	day := &asOf
+	if c.Query("history") == "true" {
+		day = nil
+	}
+	members, err := h.memberRepo.ForGrouping(c.Request.Context(), tenantID, grouping.GroupingID, day, strings.ToUpper(c.Query("type")))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"grouping": grouping.GroupingCode, "members": members, "count": len(members)})
+}
+
+// AddMember serves POST /groupings/{code}/members. The country is given by
+// country_code or country_id.
+func (h *GroupingsHandler) AddMember(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	req := groupingMembershipRequest{GroupingMembership: models.GroupingMembership{MembershipType: models.MembershipTypeMember}}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	grouping, ok := h.grouping(c)
+	if !ok {
+		return
+	}
+	if !h.resolveCountry(c, &req) {
+		return
+	}
+	membership := req.GroupingMembership
+	membership.GroupingID = grouping.GroupingID
+	membership.MembershipType = strings.ToUpper(membership.MembershipType)
+	if err := h.memberRepo.Create(c.Request.Context(), tenantID, &membership); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, membership.Version)
+	c.JSON(http.StatusCreated, membership)
+}
+
+// UpdateMember serves PUT /groupings/{code}/members/{id}. The grouping and
+// country of a membership cannot change.
+func (h *GroupingsHandler) UpdateMember(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.membership(c)
+	if !ok {
+		return
+	}
+	membership := *current
+	version, ok := bindUpdate(c, &membership, current.Version)
+	if !ok {
+		return
+	}
+	membership.GroupingID, membership.CountryID = current.GroupingID, current.CountryID
+	membership.MembershipType = strings.ToUpper(membership.MembershipType)
+	if err := h.memberRepo.UpdateByID(c.Request.Context(), tenantID, current.GroupingMembershipID, &membership, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, membership.Version)
+	c.JSON(http.StatusOK, membership)
+}
+
+// RemoveMember serves DELETE /groupings/{code}/members/{id}. It deletes a
+// membership recorded in error; a country leaving a grouping is recorded by
+// setting valid_to.
+func (h *GroupingsHandler) RemoveMember(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	membership, ok := h.membership(c)
+	if !ok {
+		return
+	}
+	if err := h.memberRepo.DeleteByID(c.Request.Context(), tenantID, membership.GroupingMembershipID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "grouping membership deleted"})
+}
+
+// CountryMemberships serves GET /countries/{code}/memberships?as_of=,
+// listing the groupings a country belongs to on as_of (default today).
+// grouping=EU-CU keeps the memberships of one grouping, answering whether
+// the country belonged to it on that date; history=true lists every period
+// instead.
+func (h *GroupingsHandler) CountryMemberships(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	asOf, verr := asOfFromQuery(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return
+	}
+	day := &asOf
+	if c.Query("history") == "true" {
+		day = nil
+	}
+	memberships, err := h.memberRepo.ForCountry(c.Request.Context(), tenantID, country.CountryID, day)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if code := c.Query("grouping"); code != "" {
+		kept := memberships[:0]
+		for _, m := range memberships {
+			if strings.EqualFold(m.GroupingCode, code) {
+				kept = append(kept, m)
+			}
+		}
+		memberships = kept
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "memberships": memberships, "count": len(memberships)})
+}
+
+// grouping loads the grouping addressed by the code parameter, writing the
+// error response when it cannot
+func (h *GroupingsHandler) grouping(c *gin.Context) (*models.Grouping, bool) {
+	tenantID := c.GetString("tenant_id")
+	grouping, err := h.repo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if grouping == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "grouping not found"})
+		return nil, false
+	}
+	return grouping, true
+}
+
+// membership loads the membership addressed by the id parameter, which
+// must belong to the grouping addressed by the code parameter
+func (h *GroupingsHandler) membership(c *gin.Context) (*models.GroupingMembership, bool) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return nil, false
+	}
+	grouping, ok := h.grouping(c)
+	if !ok {
+		return nil, false
+	}
+	membership, err := h.memberRepo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if membership == nil || membership.GroupingID != grouping.GroupingID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "grouping membership not found"})
+		return nil, false
+	}
+	return membership, true
+}
+
+// resolveCountry sets the country ID of req from its country code, if given
+func (h *GroupingsHandler) resolveCountry(c *gin.Context, req *groupingMembershipRequest) bool {
+	if req.CountryCode == "" {
+		return true
+	}
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), c.GetString("tenant_id"), req.CountryCode)
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	if country == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "country not found", "code": "VALIDATION_FAILED"})
+		return false
+	}
+	req.CountryID = country.CountryID
+	return true
+}
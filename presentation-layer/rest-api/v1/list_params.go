@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/query"
+)
+
+// listOptionsFromQuery reads paging, cursor, filter and sort parameters,
+// validating column names against the endpoint's model
+func listOptionsFromQuery(c *gin.Context, valid query.ColumnChecker) (repositories.ListOptions, *errors.LayerError) {
+	return query.ListOptions(c.Request.URL.Query(), valid)
+}
+
+// depthFromQuery reads the depth parameter of hierarchy endpoints; 0 means
+// unlimited
+func depthFromQuery(c *gin.Context) (int, *errors.LayerError) {
+	raw := c.Query("depth")
+	if raw == "" {
+		return 0, nil
+	}
+	depth, err := strconv.Atoi(raw)
+	if err != nil || depth < 0 {
+		return 0, errors.NewValidationError("depth", "must be a non-negative integer")
+	}
+	return depth, nil
+}
+
+// pageResponse renders a page under the entity's collection key with
+// next/prev cursor links
+func pageResponse[T any](c *gin.Context, key string, page *repositories.Page[T]) gin.H {
+	body := gin.H{
+		key:        page.Items,
+		"count":    len(page.Items),
+		"limit":    page.Limit,
+		"has_more": page.HasMore,
+		"links":    query.Links(c.Request.URL, page),
+	}
+	if page.Offset > 0 {
+		body["offset"] = page.Offset
+	}
+	if page.Total != nil {
+		body["total"] = *page.Total
+	}
+	return body
+}
+
+// negotiateLocale picks the locale of localized names from the
+// Accept-Language header, announcing it in Content-Language. It returns the
+// empty string when the client accepts none of the tenant's locales, and
+// false after writing the error response when negotiation fails.
+func negotiateLocale(c *gin.Context, names *applicationservices.LocalizationAppService) (string, bool) {
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	locale, err := names.Negotiate(c.Request.Context(), c.GetString("tenant_id"), c.GetHeader("Accept-Language"))
+	if err != nil {
+		respondError(c, err)
+		return "", false
+	}
+	if locale != "" {
+		c.Header("Content-Language", locale)
+	}
+	return locale, true
+}
+
+// bindUpdate decodes a PUT body over current, the stored row, so that fields
+// the client leaves out keep their values while explicit zeros and nulls are
+// written. It returns the version the write must match: the If-Match version,
+// or for "If-Match: *" the version current was read at, so that a write
+// landing in between is not overwritten with stale fields. It writes the
+// error response and returns false when it cannot.
+func bindUpdate(c *gin.Context, current interface{}, readVersion int) (int, bool) {
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return 0, false
+	}
+	if err := c.ShouldBindJSON(current); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return 0, false
+	}
+	if version == middleware.AnyVersion {
+		version = readVersion
+	}
+	return version, true
+}
+
+// respondError maps layer errors onto their HTTP status and everything else onto 500
+func respondError(c *gin.Context, err error) {
+	if layerErr, ok := err.(*errors.LayerError); ok {
+		c.JSON(layerErr.HTTPStatus(), gin.H{"error": layerErr.Error(), "code": layerErr.Code})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// asOfFromQuery reads the as_of query parameter, defaulting to today
+func asOfFromQuery(c *gin.Context) (time.Time, *errors.LayerError) {
+	raw := c.Query("as_of")
+	if raw == "" {
+		return time.Now(), nil
+	}
+	return query.ParseAsOf(raw)
+}
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+)
+
+func TestBindUpdate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		body        string
+		wantOK      bool
+		wantStatus  int
+		wantVersion int
+		want        func(r models.Region) models.Region
+	}{
+		{
+			name:        "omitted fields keep stored values",
+			ifMatch:     `"3"`,
+			body:        `{"region_name":"European Union"}`,
+			wantOK:      true,
+			wantVersion: 3,
+			want: func(r models.Region) models.Region {
+				r.RegionName = "European Union"
+				return r
+			},
+		},
+		{
+			name:        "explicit zero values are kept",
+			ifMatch:     `"3"`,
+			body:        `{"is_active":false,"parent_region_id":null}`,
+			wantOK:      true,
+			wantVersion: 3,
+			want: func(r models.Region) models.Region {
+				r.IsActive, r.ParentRegionID = false, nil
+				return r
+			},
+		},
+		{
+			name:        "any version matches the version read",
+			ifMatch:     "*",
+			body:        `{}`,
+			wantOK:      true,
+			wantVersion: 3,
+			want:        func(r models.Region) models.Region { return r },
+		},
+		{
+			name:       "missing If-Match",
+			body:       `{}`,
+			wantStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:       "malformed body",
+			ifMatch:    `"3"`,
+			body:       `{"region_name":`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := uuid.New()
+			stored := models.Region{
+				RegionCode:     "EU",
+				RegionName:     "Europe",
+				RegionType:     "CONTINENT",
+				ParentRegionID: &parent,
+				IsActive:       true,
+				Version:        3,
+			}
+			region := stored
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/regions/EU", strings.NewReader(tt.body))
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			version, ok := bindUpdate(c, &region, stored.Version)
+			if ok != tt.wantOK {
+				t.Fatalf("bindUpdate() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if w.Code != tt.wantStatus {
+					t.Errorf("bindUpdate() status = %d, want %d", w.Code, tt.wantStatus)
+				}
+				return
+			}
+			if version != tt.wantVersion {
+				t.Errorf("bindUpdate() version = %d, want %d", version, tt.wantVersion)
+			}
+			want := tt.want(stored)
+			if region.RegionName != want.RegionName || region.IsActive != want.IsActive ||
+				region.RegionType != want.RegionType || (region.ParentRegionID == nil) != (want.ParentRegionID == nil) {
+				t.Errorf("bindUpdate() region = %+v, want %+v", region, want)
+			}
+		})
+	}
+}
+
+func TestDepthFromQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{"unlimited by default", "", 0, false},
+		{"explicit unlimited", "depth=0", 0, false},
+		{"one level", "depth=1", 1, false},
+		{"negative", "depth=-1", 0, true},
+		{"not a number", "depth=all", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/subdivisions/tree?"+tt.query, nil)
+
+			got, err := depthFromQuery(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("depthFromQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("depthFromQuery() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsOfFromQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{"today by default", "", time.Now().Format("2006-01-02"), false},
+		{"date", "as_of=1999-01-01", "1999-01-01", false},
+		{"timestamp", "as_of=2001-12-31T23:00:00Z", "2001-12-31", false},
+		{"malformed", "as_of=yesterday", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/countries/DE/currencies?"+tt.query, nil)
+
+			got, err := asOfFromQuery(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("asOfFromQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Format("2006-01-02") != tt.want {
+				t.Errorf("asOfFromQuery() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
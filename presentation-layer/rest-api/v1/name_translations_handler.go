@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/i18n"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// NameTranslationsHandler handles the names of countries, regions,
+// languages and subdivisions in other locales
+type NameTranslationsHandler struct {
+	repo *repositories.NameTranslationRepository
+}
+
+func NewNameTranslationsHandler(repo *repositories.NameTranslationRepository) *NameTranslationsHandler {
+	return &NameTranslationsHandler{repo: repo}
+}
+
+// GetAll serves GET /name-translations, accepting the usual list
+// parameters, e.g. ?entity_type=country&locale_code=ja
+func (h *NameTranslationsHandler) GetAll(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	opts, verr := listOptionsFromQuery(c, h.repo.HasColumn)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	page, err := h.repo.List(c.Request.Context(), tenantID, opts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pageResponse(c, "name_translations", page))
+}
+
+// GetByID serves GET /name-translations/{id}
+func (h *NameTranslationsHandler) GetByID(c *gin.Context) {
+	translation, ok := h.translation(c)
+	if !ok {
+		return
+	}
+	middleware.SetETag(c, translation.Version)
+	c.JSON(http.StatusOK, translation)
+}
+
+// Create serves POST /name-translations
+func (h *NameTranslationsHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var translation models.NameTranslation
+	if err := c.ShouldBindJSON(&translation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	normalizeNameTranslation(&translation)
+	if err := h.repo.Create(c.Request.Context(), tenantID, &translation); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, translation.Version)
+	c.JSON(http.StatusCreated, translation)
+}
+
+// Update serves PUT /name-translations/{id}. The entity a translation names
+// cannot change.
+func (h *NameTranslationsHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.translation(c)
+	if !ok {
+		return
+	}
+	translation := *current
+	version, ok := bindUpdate(c, &translation, current.Version)
+	if !ok {
+		return
+	}
+	translation.EntityType, translation.EntityID = current.EntityType, current.EntityID
+	normalizeNameTranslation(&translation)
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.NameTranslationID, &translation, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, translation.Version)
+	c.JSON(http.StatusOK, translation)
+}
+
+// Delete serves DELETE /name-translations/{id}
+func (h *NameTranslationsHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	translation, ok := h.translation(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, translation.NameTranslationID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "name translation deleted"})
+}
+
+// translation loads the translation addressed by the id parameter, writing
+// the error response when it cannot
+func (h *NameTranslationsHandler) translation(c *gin.Context) (*models.NameTranslation, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return nil, false
+	}
+	translation, err := h.repo.GetByID(c.Request.Context(), c.GetString("tenant_id"), id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if translation == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "name translation not found"})
+		return nil, false
+	}
+	return translation, true
+}
+
+// normalizeNameTranslation lowercases the entity type and writes the locale
+// as its canonical tag, the forms they are stored in. A malformed locale is
+// left for the repository to reject.
+func normalizeNameTranslation(translation *models.NameTranslation) {
+	translation.EntityType = strings.ToLower(strings.TrimSpace(translation.EntityType))
+	if tag, err := i18n.CanonicalTag(translation.LocaleCode); err == nil {
+		translation.LocaleCode = tag
+	}
+}
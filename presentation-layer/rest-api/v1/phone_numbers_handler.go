@@ -0,0 +1,193 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// PhoneNumbersHandler validates and normalizes phone numbers
+type PhoneNumbersHandler struct {
+	svc *applicationservices.PhoneNumberAppService
+}
+
+func NewPhoneNumbersHandler(svc *applicationservices.PhoneNumberAppService) *PhoneNumbersHandler {
+	return &PhoneNumbersHandler{svc: svc}
+}
+
+// phoneNumbersRequest holds one number or, for :normalize, a batch
+type phoneNumbersRequest struct {
+	Number         string   `json:"number"`
+	Numbers        []string `json:"numbers"`
+	DefaultCountry string   `json:"default_country"`
+}
+
+// Action serves the collection custom methods POST /phone-numbers:validate,
+// which reports whether a number is valid, and POST /phone-numbers:normalize,
+// which returns its E.164 form and also accepts a numbers batch
+func (h *PhoneNumbersHandler) Action(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	action := c.Param("action")
+	if action != ":validate" && action != ":normalize" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown action, expected :validate or :normalize"})
+		return
+	}
+	var req phoneNumbersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if action == ":normalize" && req.Numbers != nil {
+		results, err := h.svc.ParseAll(c.Request.Context(), tenantID, req.Numbers, req.DefaultCountry)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		valid := 0
+		for _, result := range results {
+			if result.Valid {
+				valid++
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{"results": results, "count": len(results), "valid": valid})
+		return
+	}
+	if req.Number == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "number is required", "code": "VALIDATION_FAILED"})
+		return
+	}
+	result, err := h.svc.Parse(c.Request.Context(), tenantID, req.Number, req.DefaultCountry)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if action == ":normalize" && !result.Valid {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CallingCodeCountries serves GET /calling-codes/{code}/countries, the
+// countries sharing a calling code such as 1
+func (h *PhoneNumbersHandler) CallingCodeCountries(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	plans, err := h.svc.CountriesForCallingCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if len(plans) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "calling code not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"calling_code": plans[0].CallingCode, "countries": plans, "count": len(plans)})
+}
+
+// CountryPhonePlanHandler handles the numbering plan of a country,
+// /countries/{code}/phone-plan
+type CountryPhonePlanHandler struct {
+	repo        *repositories.PhoneNumberingPlanRepository
+	countryRepo *repositories.CountryRepository
+}
+
+func NewCountryPhonePlanHandler(repo *repositories.PhoneNumberingPlanRepository, countryRepo *repositories.CountryRepository) *CountryPhonePlanHandler {
+	return &CountryPhonePlanHandler{repo: repo, countryRepo: countryRepo}
+}
+
+// Get serves GET /countries/{code}/phone-plan
+func (h *CountryPhonePlanHandler) Get(c *gin.Context) {
+	plan, ok := h.plan(c)
+	if !ok {
+		return
+	}
+	middleware.SetETag(c, plan.Version)
+	c.JSON(http.StatusOK, plan)
+}
+
+// Create serves POST /countries/{code}/phone-plan
+func (h *CountryPhonePlanHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var plan models.PhoneNumberingPlan
+	if err := c.ShouldBindJSON(&plan); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return
+	}
+	plan.CountryID = country.CountryID
+	if err := h.repo.Create(c.Request.Context(), tenantID, &plan); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, plan.Version)
+	c.JSON(http.StatusCreated, plan)
+}
+
+// Update serves PUT /countries/{code}/phone-plan
+func (h *CountryPhonePlanHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.plan(c)
+	if !ok {
+		return
+	}
+	plan := current.PhoneNumberingPlan
+	version, ok := bindUpdate(c, &plan, current.Version)
+	if !ok {
+		return
+	}
+	plan.CountryID = current.CountryID
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.PhoneNumberingPlanID, &plan, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, plan.Version)
+	c.JSON(http.StatusOK, plan)
+}
+
+// Delete serves DELETE /countries/{code}/phone-plan
+func (h *CountryPhonePlanHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	plan, ok := h.plan(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, plan.PhoneNumberingPlanID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "phone numbering plan deleted"})
+}
+
+// plan loads the plan of the country addressed by the code parameter,
+// writing the error response when it cannot
+func (h *CountryPhonePlanHandler) plan(c *gin.Context) (*repositories.PhoneNumberingPlanView, bool) {
+	plan, err := h.repo.ForCountry(c.Request.Context(), c.GetString("tenant_id"), c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if plan == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "phone numbering plan not found"})
+		return nil, false
+	}
+	return plan, true
+}
@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	models "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/orm-odm-abstractions"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// CountryPostalCodesHandler handles the postal code rules of a country,
+// /countries/{code}/postal-code-formats, and validates its postal codes
+type CountryPostalCodesHandler struct {
+	repo            *repositories.PostalCodeFormatRepository
+	countryRepo     *repositories.CountryRepository
+	subdivisionRepo *repositories.SubdivisionRepository
+	svc             *applicationservices.PostalCodeAppService
+}
+
+func NewCountryPostalCodesHandler(repo *repositories.PostalCodeFormatRepository, countryRepo *repositories.CountryRepository, subdivisionRepo *repositories.SubdivisionRepository, svc *applicationservices.PostalCodeAppService) *CountryPostalCodesHandler {
+	return &CountryPostalCodesHandler{repo: repo, countryRepo: countryRepo, subdivisionRepo: subdivisionRepo, svc: svc}
+}
+
+// postalCodeFormatRequest is a rule whose subdivision may be given by code
+type postalCodeFormatRequest struct {
+	models.PostalCodeFormat
+	SubdivisionCode string `json:"subdivision_code"`
+}
+
+// postalCodeRequest is the body of POST /countries/{code}/postal-codes:validate
+type postalCodeRequest struct {
+	PostalCode      string `json:"postal_code"`
+	SubdivisionCode string `json:"subdivision_code"`
+}
+
+// Action serves POST /countries/{code}/postal-codes:validate, which reports
+// whether a postal code is valid, its normalized form and its subdivision
+func (h *CountryPostalCodesHandler) Action(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	if c.Param("action") != ":validate" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown action, expected :validate"})
+		return
+	}
+	var req postalCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	result, err := h.svc.Validate(c.Request.Context(), tenantID, country, req.PostalCode, req.SubdivisionCode)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// List serves GET /countries/{code}/postal-code-formats, country rules first
+func (h *CountryPostalCodesHandler) List(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	formats, err := h.repo.ForCountry(c.Request.Context(), tenantID, country.CountryID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"country": country.CountryCode, "postal_code_formats": formats, "count": len(formats)})
+}
+
+// Create serves POST /countries/{code}/postal-code-formats
+func (h *CountryPostalCodesHandler) Create(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	var req postalCodeFormatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return
+	}
+	req.CountryID = country.CountryID
+	if !h.resolveSubdivision(c, &req) {
+		return
+	}
+	format := req.PostalCodeFormat
+	if err := h.repo.Create(c.Request.Context(), tenantID, &format); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, format.Version)
+	c.JSON(http.StatusCreated, format)
+}
+
+// Update serves PUT /countries/{code}/postal-code-formats/{id}
+func (h *CountryPostalCodesHandler) Update(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	current, ok := h.format(c)
+	if !ok {
+		return
+	}
+	req := postalCodeFormatRequest{PostalCodeFormat: *current}
+	version, ok := bindUpdate(c, &req, current.Version)
+	if !ok {
+		return
+	}
+	req.CountryID = current.CountryID
+	if !h.resolveSubdivision(c, &req) {
+		return
+	}
+	format := req.PostalCodeFormat
+	if err := h.repo.UpdateByID(c.Request.Context(), tenantID, current.PostalCodeFormatID, &format, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	middleware.SetETag(c, format.Version)
+	c.JSON(http.StatusOK, format)
+}
+
+// Delete serves DELETE /countries/{code}/postal-code-formats/{id}
+func (h *CountryPostalCodesHandler) Delete(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+	version, verr := middleware.IfMatchVersion(c)
+	if verr != nil {
+		respondError(c, verr)
+		return
+	}
+	format, ok := h.format(c)
+	if !ok {
+		return
+	}
+	if err := h.repo.DeleteByID(c.Request.Context(), tenantID, format.PostalCodeFormatID, version); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "postal code format deleted"})
+}
+
+// country loads the country addressed by the code parameter, writing the
+// error response when it cannot
+func (h *CountryPostalCodesHandler) country(c *gin.Context) (*models.Country, bool) {
+	tenantID := c.GetString("tenant_id")
+	country, err := h.countryRepo.GetByCode(c.Request.Context(), tenantID, c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if country == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "country not found"})
+		return nil, false
+	}
+	return country, true
+}
+
+// format loads the rule addressed by the id parameter, which must belong to
+// the country addressed by the code parameter
+func (h *CountryPostalCodesHandler) format(c *gin.Context) (*models.PostalCodeFormat, bool) {
+	tenantID := c.GetString("tenant_id")
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return nil, false
+	}
+	country, ok := h.country(c)
+	if !ok {
+		return nil, false
+	}
+	format, err := h.repo.GetByID(c.Request.Context(), tenantID, id)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	if format == nil || format.CountryID != country.CountryID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "postal code format not found"})
+		return nil, false
+	}
+	return format, true
+}
+
+// resolveSubdivision sets the subdivision ID of req from its subdivision
+// code, if given
+func (h *CountryPostalCodesHandler) resolveSubdivision(c *gin.Context, req *postalCodeFormatRequest) bool {
+	if req.SubdivisionCode == "" {
+		return true
+	}
+	subdivision, err := h.subdivisionRepo.GetByCountryAndCode(c.Request.Context(), c.GetString("tenant_id"), req.CountryID, req.SubdivisionCode)
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	if subdivision == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "subdivision not found", "code": "VALIDATION_FAILED"})
+		return false
+	}
+	req.SubdivisionID = &subdivision.SubdivisionID
+	return true
+}
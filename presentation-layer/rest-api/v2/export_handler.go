@@ -0,0 +1,80 @@
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/exporters"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/query"
+)
+
+// ExportHandler serves GET /api/v2/exports and
+// GET /api/v2/exports/{dataset}?format=csv|ndjson|geojson&fields=&as_of=
+type ExportHandler struct {
+	exportService *applicationservices.ExportAppService
+}
+
+func NewExportHandler(exportService *applicationservices.ExportAppService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// RegisterRoutes adds the export routes
+func (h *ExportHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/exports", h.Datasets)
+	group.GET("/exports/:dataset", h.Export)
+}
+
+// Datasets lists the exportable datasets and formats
+func (h *ExportHandler) Datasets(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"datasets": h.exportService.Datasets(),
+		"formats":  []string{exporters.FormatCSV, exporters.FormatNDJSON, exporters.FormatGeoJSON},
+	})
+}
+
+// Export streams a dataset of the tenant as an attachment. Errors found once
+// rows have been sent cut the response short.
+func (h *ExportHandler) Export(c *gin.Context) {
+	req := applicationservices.ExportRequest{
+		Dataset: c.Param("dataset"),
+		Format:  strings.ToLower(c.DefaultQuery("format", exporters.FormatNDJSON)),
+	}
+	if raw := c.Query("as_of"); raw != "" {
+		asOf, err := query.ParseAsOf(raw)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		req.AsOf = &asOf
+	}
+	if raw := c.Query("fields"); raw != "" {
+		for _, field := range strings.Split(raw, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				req.Fields = append(req.Fields, field)
+			}
+		}
+	}
+	if err := h.exportService.Validate(req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", exporters.ContentType(req.Format))
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s%s"`, req.Dataset, exporters.Extension(req.Format)))
+	c.Status(http.StatusOK)
+
+	if _, err := h.exportService.Export(c.Request.Context(), c.GetString("tenant_id"), req, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			header.Del("Content-Type")
+			header.Del("Content-Disposition")
+			respondError(c, err)
+			return
+		}
+		c.Abort()
+	}
+}
@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/rest-api/query"
+)
+
+// historyEntities maps route collections to the entity types recorded in history
+var historyEntities = map[string]string{
+	"countries":              repositories.CountrySpec.Name,
+	"regions":                repositories.RegionSpec.Name,
+	"languages":              repositories.LanguageSpec.Name,
+	"timezones":              repositories.TimezoneSpec.Name,
+	"subdivisions":           repositories.SubdivisionSpec.Name,
+	"locales":                repositories.LocaleSpec.Name,
+	"currencies":             repositories.CurrencySpec.Name,
+	"country-currencies":     repositories.CountryCurrencySpec.Name,
+	"country-languages":      repositories.CountryLanguageSpec.Name,
+	"country-timezones":      repositories.CountryTimezoneSpec.Name,
+	"phone-plans":            repositories.PhoneNumberingPlanSpec.Name,
+	"postal-code-formats":    repositories.PostalCodeFormatSpec.Name,
+	"address-formats":        repositories.AddressFormatSpec.Name,
+	"country-borders":        repositories.CountryBorderSpec.Name,
+	"country-external-codes": repositories.CountryExternalCodeSpec.Name,
+	"name-translations":      repositories.NameTranslationSpec.Name,
+	"groupings":              repositories.GroupingSpec.Name,
+	"grouping-memberships":   repositories.GroupingMembershipSpec.Name,
+}
+
+// HistoryHandler serves GET /api/v2/{entity}/{code}/history
+type HistoryHandler struct {
+	historyRepo *repositories.HistoryRepository
+}
+
+func NewHistoryHandler(historyRepo *repositories.HistoryRepository) *HistoryHandler {
+	return &HistoryHandler{historyRepo: historyRepo}
+}
+
+// RegisterRoutes adds a history route for every reference entity.
+// Subdivisions, phone plans, postal code and address formats, country borders
+// and external codes, name translations, grouping memberships and the country
+// currency, language and time zone links are addressed by ID, all other
+// entities by code.
+func (h *HistoryHandler) RegisterRoutes(group *gin.RouterGroup) {
+	for collection, entityType := range historyEntities {
+		group.GET("/"+collection+"/:code/history", h.history(entityType))
+	}
+}
+
+// history lists the changes of one entity, newest first, paged by limit/offset
+func (h *HistoryHandler) history(entityType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.GetString("tenant_id")
+		code := c.Param("code")
+
+		// History rows cannot be filtered or sorted by column
+		opts, verr := query.ListOptions(c.Request.URL.Query(), func(string) bool { return false })
+		if verr != nil {
+			respondError(c, verr)
+			return
+		}
+
+		page, err := h.historyRepo.List(c.Request.Context(), tenantID, entityType, code, opts)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+
+		body := gin.H{
+			"entity_type": entityType,
+			"code":        code,
+			"history":     page.Items,
+			"count":       len(page.Items),
+			"limit":       page.Limit,
+			"offset":      page.Offset,
+			"has_more":    page.HasMore,
+		}
+		if page.Total != nil {
+			body["total"] = *page.Total
+		}
+		c.JSON(http.StatusOK, body)
+	}
+}
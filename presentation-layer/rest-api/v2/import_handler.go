@@ -0,0 +1,86 @@
+package v2
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/importers"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// maxImportSize bounds the body of an import request
+const maxImportSize = 32 << 20
+
+// ImportHandler serves POST /api/v2/admin/imports/iso3166. The body is an ISO
+// 3166 CSV or JSON file; ?format= overrides the Content-Type. The import is a
+// dry run unless ?dry_run=false, and is limited to callers holding one of the
+// admin roles.
+type ImportHandler struct {
+	importService *applicationservices.ImportAppService
+	adminRoles    map[string]bool
+}
+
+func NewImportHandler(importService *applicationservices.ImportAppService, adminRoles ...string) *ImportHandler {
+	roles := make(map[string]bool, len(adminRoles))
+	for _, role := range adminRoles {
+		roles[role] = true
+	}
+	return &ImportHandler{importService: importService, adminRoles: roles}
+}
+
+// RegisterRoutes adds the import route
+func (h *ImportHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/admin/imports/iso3166", h.ISO3166)
+}
+
+// ISO3166 plans, and unless dry_run, applies an ISO 3166 import
+func (h *ImportHandler) ISO3166(c *gin.Context) {
+	if !h.adminRoles[c.GetString("role")] {
+		respondError(c, errors.NewPresentationError("FORBIDDEN", "imports require an admin role", nil))
+		return
+	}
+
+	dryRun := true
+	if raw := c.Query("dry_run"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(c, errors.NewValidationError("dry_run", "must be true or false"))
+			return
+		}
+		dryRun = value
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		switch {
+		case strings.HasSuffix(mediaType, "json"):
+			format = importers.FormatJSON
+		case strings.HasSuffix(mediaType, "csv"):
+			format = importers.FormatCSV
+		default:
+			respondError(c, errors.NewValidationError("format", "give ?format=csv|json or a text/csv or application/json body"))
+			return
+		}
+	}
+
+	dataset, err := importers.Parse(format, http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	result, err := h.importService.Import(c.Request.Context(), c.GetString("tenant_id"), dataset,
+		applicationservices.ImportOptions{Apply: !dryRun, Reason: c.GetHeader(middleware.ChangeReasonHeader)})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
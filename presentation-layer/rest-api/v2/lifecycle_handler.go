@@ -0,0 +1,119 @@
+package v2
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/presentation-layer/middleware"
+)
+
+// lifecycleOps restores and purges the soft-deleted rows of one collection
+type lifecycleOps struct {
+	restore func(ctx context.Context, tenantID, key string, version int) (interface{}, int, error)
+	purge   func(ctx context.Context, tenantID, key string, version int) error
+}
+
+// LifecycleHandler serves POST /api/v2/{entity}/{code}:restore and
+// POST /api/v2/{entity}/{code}:purge. Both require If-Match; purge is limited
+// to callers holding one of the purge roles.
+type LifecycleHandler struct {
+	collections map[string]lifecycleOps
+	purgeRoles  map[string]bool
+}
+
+func NewLifecycleHandler(purgeRoles ...string) *LifecycleHandler {
+	roles := make(map[string]bool, len(purgeRoles))
+	for _, role := range purgeRoles {
+		roles[role] = true
+	}
+	return &LifecycleHandler{collections: make(map[string]lifecycleOps), purgeRoles: roles}
+}
+
+// AddLifecycle serves restore and purge of collection from repo. Entities
+// without a natural key are addressed by ID.
+func AddLifecycle[T any](h *LifecycleHandler, collection string, repo *repositories.Repository[T]) {
+	byID := repo.Spec().CodeColumn == ""
+	h.collections[collection] = lifecycleOps{
+		restore: func(ctx context.Context, tenantID, key string, version int) (interface{}, int, error) {
+			var entity *T
+			var err error
+			if byID {
+				id, perr := uuid.Parse(key)
+				if perr != nil {
+					return nil, 0, errors.NewValidationError("id", "invalid ID format")
+				}
+				entity, err = repo.RestoreByID(ctx, tenantID, id, version)
+			} else {
+				entity, err = repo.Restore(ctx, tenantID, key, version)
+			}
+			if err != nil {
+				return nil, 0, err
+			}
+			return entity, repo.VersionOf(ctx, entity), nil
+		},
+		purge: func(ctx context.Context, tenantID, key string, version int) error {
+			if byID {
+				id, err := uuid.Parse(key)
+				if err != nil {
+					return errors.NewValidationError("id", "invalid ID format")
+				}
+				return repo.PurgeByID(ctx, tenantID, id, version)
+			}
+			return repo.Purge(ctx, tenantID, key, version)
+		},
+	}
+}
+
+// RegisterRoutes adds the custom-method routes of every added collection
+func (h *LifecycleHandler) RegisterRoutes(group *gin.RouterGroup) {
+	for collection, ops := range h.collections {
+		group.POST("/"+collection+"/:code", h.action(ops))
+	}
+}
+
+// action dispatches {code}:restore and {code}:purge
+func (h *LifecycleHandler) action(ops lifecycleOps) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.GetString("tenant_id")
+		param := c.Param("code")
+		sep := strings.LastIndex(param, ":")
+		if sep < 0 || (param[sep+1:] != "restore" && param[sep+1:] != "purge") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "unknown action, expected :restore or :purge"})
+			return
+		}
+		key, method := param[:sep], param[sep+1:]
+
+		version, verr := middleware.IfMatchVersion(c)
+		if verr != nil {
+			respondError(c, verr)
+			return
+		}
+
+		if method == "restore" {
+			entity, newVersion, err := ops.restore(c.Request.Context(), tenantID, key, version)
+			if err != nil {
+				respondError(c, err)
+				return
+			}
+			middleware.SetETag(c, newVersion)
+			c.JSON(http.StatusOK, entity)
+			return
+		}
+
+		if !h.purgeRoles[c.GetString("role")] {
+			respondError(c, errors.NewPresentationError("FORBIDDEN", "purge requires a data steward role", nil))
+			return
+		}
+		if err := ops.purge(c.Request.Context(), tenantID, key, version); err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "purged", "code": key})
+	}
+}
@@ -0,0 +1,51 @@
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// defaultReportLimit is the number of retention reports returned by default
+const defaultReportLimit = 100
+
+// RetentionHandler serves GET /api/v2/retention/reports
+type RetentionHandler struct {
+	retentionService *applicationservices.RetentionAppService
+}
+
+func NewRetentionHandler(retentionService *applicationservices.RetentionAppService) *RetentionHandler {
+	return &RetentionHandler{retentionService: retentionService}
+}
+
+// Reports lists the latest retention reports of the tenant, newest first
+func (h *RetentionHandler) Reports(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	limit := defaultReportLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			respondError(c, errors.NewValidationError("limit", "must be a positive integer"))
+			return
+		}
+		limit = min(n, repositories.MaxPageSize)
+	}
+
+	reports, err := h.retentionService.Reports(c.Request.Context(), tenantID, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reports":          reports,
+		"count":            len(reports),
+		"default_policies": applicationservices.DefaultRetentionPolicies,
+	})
+}
@@ -0,0 +1,67 @@
+// Package v2 implements REST API v2 endpoints
+package v2
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/errors"
+)
+
+// SearchHandler serves GET /api/v2/search
+type SearchHandler struct {
+	searchService *applicationservices.SearchAppService
+}
+
+func NewSearchHandler(searchService *applicationservices.SearchAppService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search handles GET /search?q=&types=country,subdivision&limit=
+func (h *SearchHandler) Search(c *gin.Context) {
+	tenantID := c.GetString("tenant_id")
+
+	query := repositories.SearchQuery{Text: c.Query("q")}
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				query.EntityTypes = append(query.EntityTypes, t)
+			}
+		}
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			respondError(c, errors.NewValidationError("limit", "must be a positive integer"))
+			return
+		}
+		query.Limit = limit
+	}
+
+	result, err := h.searchService.Search(c.Request.Context(), tenantID, query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":  query.Text,
+		"hits":   result.Hits,
+		"count":  len(result.Hits),
+		"facets": result.Facets,
+	})
+}
+
+// respondError maps layer errors onto their HTTP status and everything else onto 500
+func respondError(c *gin.Context, err error) {
+	if layerErr, ok := err.(*errors.LayerError); ok {
+		c.JSON(layerErr.HTTPStatus(), gin.H{"error": layerErr.Error(), "code": layerErr.Code})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
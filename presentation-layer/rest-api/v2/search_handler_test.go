@@ -0,0 +1,62 @@
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	applicationservices "github.com/EhsanLasani/domain-reference-Master-Geopolitical/business-logic-layer/application-services"
+	repositories "github.com/EhsanLasani/domain-reference-Master-Geopolitical/data-access-layer/repositories-daos"
+	"github.com/EhsanLasani/domain-reference-Master-Geopolitical/internal/xcut/logging"
+)
+
+// noopTracer satisfies tracing.Tracer without exporting spans
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, name string, _ ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return noop.NewTracerProvider().Tracer("test").Start(ctx, name)
+}
+
+func (noopTracer) StartSQLSpan(ctx context.Context, _, operation string, _ string) (context.Context, oteltrace.Span) {
+	return noop.NewTracerProvider().Tracer("test").Start(ctx, "sql."+operation)
+}
+
+// TestSearchRejectsInvalidQueries covers the requests refused before the
+// search repository touches the database
+func TestSearchRejectsInvalidQueries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := applicationservices.NewSearchAppService(repositories.NewSearchRepository(nil), logging.NewStructuredLogger("test"), noopTracer{})
+	handler := NewSearchHandler(service)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing query", ""},
+		{"single character", "q=a"},
+		{"single character after trimming", "q=%20%C3%A9%20"},
+		{"non-numeric limit", "q=germ&limit=ten"},
+		{"zero limit", "q=germ&limit=0"},
+		{"unsupported entity type", "q=germ&types=country,planet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/search?"+tt.query, nil)
+
+			handler.Search(c)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Search() status = %d, want %d (%s)", w.Code, http.StatusBadRequest, w.Body.String())
+			}
+		})
+	}
+}